@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -15,14 +16,13 @@ import (
 )
 
 // CheckFastTransferAllowance queries v2 API for remaining Fast Transfer capacity
-func CheckFastTransferAllowance(baseURL string, logger log.Logger, sourceDomain types.Domain, token string) (*types.FastTransferAllowance, error) {
-	baseURL = normalizeBaseURL(baseURL)
-	url := fmt.Sprintf("%s/v2/fastBurn/%s/allowance?sourceDomain=%d", baseURL, token, sourceDomain)
+func CheckFastTransferAllowance(baseURLs []string, logger log.Logger, sourceDomain types.Domain, token string) (*types.FastTransferAllowance, error) {
+	path := fmt.Sprintf("/v2/fastBurn/%s/allowance?sourceDomain=%d", token, sourceDomain)
 
-	logger.Debug(fmt.Sprintf("Checking Fast Transfer allowance at %s", url))
+	logger.Debug(fmt.Sprintf("Checking Fast Transfer allowance at %s", path))
 
 	var allowance types.FastTransferAllowance
-	if err := httpRequest(http.MethodGet, url, &allowance); err != nil {
+	if _, err := apiRequest(http.MethodGet, baseURLs, path, &allowance); err != nil {
 		return nil, err
 	}
 
@@ -57,13 +57,17 @@ func (a *AllowanceState) Set(domain types.Domain, allowance *types.FastTransferA
 
 // AllowanceMonitor tracks Fast Transfer allowance across domains
 type AllowanceMonitor struct {
-	baseURL  string
+	baseURLs []string
 	logger   log.Logger
 	metrics  *relayer.PromMetrics
 	state    *AllowanceState
 	domains  []types.Domain
 	token    string
 	interval time.Duration
+
+	// how long after starting Fast Transfers are held while a domain's allowance is unknown, 0 doesn't hold them
+	readyTimeout time.Duration
+	started      time.Time
 }
 
 func NewAllowanceMonitor(cfg types.CircleSettings, logger log.Logger, domains []types.Domain, metrics *relayer.PromMetrics) *AllowanceMonitor {
@@ -77,13 +81,16 @@ func NewAllowanceMonitor(cfg types.CircleSettings, logger log.Logger, domains []
 	}
 
 	return &AllowanceMonitor{
-		baseURL:  cfg.AttestationBaseURL,
+		baseURLs: cfg.AttestationBaseURLs(),
 		logger:   logger.With("component", "allowance-monitor"),
 		metrics:  metrics,
 		state:    NewAllowanceState(),
 		domains:  domains,
 		token:    token,
 		interval: time.Duration(interval) * time.Second,
+
+		readyTimeout: time.Duration(cfg.AllowanceReadyTimeout) * time.Second,
+		started:      time.Now(),
 	}
 }
 
@@ -91,6 +98,29 @@ func (m *AllowanceMonitor) State() *AllowanceState {
 	return m.state
 }
 
+// Ready returns true once the allowance of the domain was fetched successfully
+func (m *AllowanceMonitor) Ready(domain types.Domain) bool {
+	return m.state.Get(domain) != nil
+}
+
+// Readiness returns whether the allowance of each monitored domain was fetched successfully
+func (m *AllowanceMonitor) Readiness() map[types.Domain]bool {
+	ready := make(map[types.Domain]bool, len(m.domains))
+	for _, domain := range m.domains {
+		ready[domain] = m.Ready(domain)
+	}
+	return ready
+}
+
+// HoldFastTransfer returns true if Fast Transfers from the source domain should not be broadcast yet because its
+// allowance wasn't fetched since starting. They are held for at most allowance-ready-timeout.
+func (m *AllowanceMonitor) HoldFastTransfer(sourceDomain types.Domain) bool {
+	if m == nil || m.readyTimeout == 0 || !slices.Contains(m.domains, sourceDomain) {
+		return false
+	}
+	return !m.Ready(sourceDomain) && time.Since(m.started) < m.readyTimeout
+}
+
 func (m *AllowanceMonitor) Start(ctx context.Context) {
 	m.logger.Info("Starting Fast Transfer allowance monitoring", "domains", m.domains, "interval", m.interval)
 	m.queryAllowances()
@@ -112,7 +142,7 @@ func (m *AllowanceMonitor) Start(ctx context.Context) {
 // queryAllowances fetches and updates Fast Transfer allowance for all monitored domains
 func (m *AllowanceMonitor) queryAllowances() {
 	for _, domain := range m.domains {
-		allowance, err := CheckFastTransferAllowance(m.baseURL, m.logger, domain, m.token)
+		allowance, err := CheckFastTransferAllowance(m.baseURLs, m.logger, domain, m.token)
 		if err != nil {
 			m.logger.Error("Failed to fetch allowance", "domain", domain, "error", err)
 			continue
@@ -131,13 +161,12 @@ func (m *AllowanceMonitor) queryAllowances() {
 // StartAllowanceMonitor starts background monitoring if v2 API and monitoring are enabled.
 // Returns nil if disabled, otherwise returns monitor instance running in background goroutine.
 func StartAllowanceMonitor(ctx context.Context, cfg types.CircleSettings, logger log.Logger, domains []types.Domain, metrics *relayer.PromMetrics) *AllowanceMonitor {
-	apiVersion, err := cfg.GetAPIVersion()
-	if err != nil {
+	if _, err := cfg.GetAPIVersion(); err != nil {
 		logger.Error("Failed to parse API version for allowance monitoring", "error", err)
 		return nil
 	}
 
-	if apiVersion != types.APIVersionV2 {
+	if !cfg.UsesAPIVersion(types.APIVersionV2) {
 		logger.Info("Fast Transfer allowance monitoring disabled (requires v2 API)")
 		return nil
 	}
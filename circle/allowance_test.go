@@ -55,7 +55,7 @@ func TestNewAllowanceMonitor_Defaults(t *testing.T) {
 	require.NotNil(t, monitor)
 	require.Equal(t, "USDC", monitor.token)
 	require.Equal(t, 30*time.Second, monitor.interval)
-	require.Equal(t, cfg.AttestationBaseURL, monitor.baseURL)
+	require.Equal(t, []string{cfg.AttestationBaseURL}, monitor.baseURLs)
 	require.Equal(t, domains, monitor.domains)
 }
 
@@ -101,3 +101,27 @@ func TestAllowanceState_ConcurrentAccess(t *testing.T) {
 	require.Equal(t, json.Number("1000000"), state.Get(types.Domain(0)).Allowance)
 	require.Equal(t, json.Number("2000000"), state.Get(types.Domain(1)).Allowance)
 }
+
+// TestAllowanceMonitor_HoldFastTransfer verifies Fast Transfers are held until the allowance is known or the timeout
+func TestAllowanceMonitor_HoldFastTransfer(t *testing.T) {
+	cfg := types.CircleSettings{AllowanceReadyTimeout: 60}
+	monitor := NewAllowanceMonitor(cfg, testLogger, []types.Domain{0, 1}, nil)
+
+	require.True(t, monitor.HoldFastTransfer(0))
+	require.False(t, monitor.HoldFastTransfer(5), "unmonitored domains are never held")
+	require.Equal(t, map[types.Domain]bool{0: false, 1: false}, monitor.Readiness())
+
+	monitor.State().Set(0, &types.FastTransferAllowance{Allowance: json.Number("1")})
+	require.False(t, monitor.HoldFastTransfer(0))
+	require.True(t, monitor.HoldFastTransfer(1))
+	require.Equal(t, map[types.Domain]bool{0: true, 1: false}, monitor.Readiness())
+
+	// past the timeout fast transfers are broadcast without allowance data
+	monitor.started = time.Now().Add(-time.Minute)
+	require.False(t, monitor.HoldFastTransfer(1))
+
+	// without a timeout nothing is held, as is a nil monitor
+	monitor = NewAllowanceMonitor(types.CircleSettings{}, testLogger, []types.Domain{0}, nil)
+	require.False(t, monitor.HoldFastTransfer(0))
+	require.False(t, (*AllowanceMonitor)(nil).HoldFastTransfer(0))
+}
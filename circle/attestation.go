@@ -2,22 +2,37 @@ package circle
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"cosmossdk.io/log"
 
+	"github.com/strangelove-ventures/noble-cctp-relayer/tracing"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
 const defaultHTTPTimeout = 10 * time.Second
 
-// httpRequest performs an HTTP request and unmarshals JSON response
-func httpRequest(method, url string, result any) error {
+// apiFailures counts consecutive Circle API requests that failed to reach the API or returned a server error
+var apiFailures atomic.Int64
+
+// ConsecutiveAPIFailures returns the number of consecutive Circle API requests that failed to reach the API or
+// returned a server error. Any other response resets the count.
+func ConsecutiveAPIFailures() int64 {
+	return apiFailures.Load()
+}
+
+// httpRequest performs an HTTP request and unmarshals JSON response. The duration and status of the request are
+// recorded by endpointType, e.g. attestation-v1.
+func httpRequest(method, url, endpointType string, result any) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultHTTPTimeout)
 	defer cancel()
 
@@ -26,11 +41,21 @@ func httpRequest(method, url string, result any) error {
 		return err
 	}
 
+	start := time.Now()
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
+		apiFailures.Add(1)
+		recordRequest(endpointType, "error", time.Since(start))
 		return err
 	}
 	defer resp.Body.Close()
+	recordRequest(endpointType, strconv.Itoa(resp.StatusCode), time.Since(start))
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		apiFailures.Add(1)
+	} else {
+		apiFailures.Store(0)
+	}
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -38,15 +63,28 @@ func httpRequest(method, url string, result any) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))
+		return &statusError{code: resp.StatusCode, body: string(respBody)}
 	}
 
 	return json.Unmarshal(respBody, result)
 }
 
-// normalizeMessageHash adds 0x prefix if missing
+// recordRequest records the duration of a Circle API request and counts it as an error unless it got a 200 response.
+// status is the response status code, or "error" if no response was received.
+func recordRequest(endpointType, status string, duration time.Duration) {
+	m := metrics.Load()
+	if m == nil {
+		return
+	}
+	m.ObserveCircleRequest(endpointType, status, duration)
+	if status != strconv.Itoa(http.StatusOK) {
+		m.IncCircleRequestErrors(endpointType, status)
+	}
+}
+
+// normalizeMessageHash adds 0x prefix to hex hashes if missing. Solana tx signatures are base58 and left as is.
 func normalizeMessageHash(hash string) string {
-	if len(hash) > 2 && hash[:2] != "0x" {
+	if _, err := hex.DecodeString(hash); err == nil && len(hash) > 2 && hash[:2] != "0x" {
 		return "0x" + hash
 	}
 	return hash
@@ -58,75 +96,109 @@ func normalizeBaseURL(url string) string {
 	return strings.TrimSuffix(url, "/attestations")
 }
 
-// buildV2MessagesURL constructs the v2 API URL for querying messages by transaction hash
-func buildV2MessagesURL(baseURL string, sourceDomain types.Domain, txHash string) string {
-	return fmt.Sprintf("%s/v2/messages/%d?transactionHash=%s", baseURL, sourceDomain, txHash)
+// buildV2MessagesPath constructs the v2 API path for querying messages by transaction hash
+func buildV2MessagesPath(sourceDomain types.Domain, txHash string) string {
+	return fmt.Sprintf("/v2/messages/%d?transactionHash=%s", sourceDomain, txHash)
 }
 
-// CheckAttestation fetches attestation from Circle API using v1 or v2 endpoint based on config
-func CheckAttestation(cfg types.CircleSettings, logger log.Logger, irisLookupID, txHash string, sourceDomain, destDomain types.Domain) *types.AttestationResponse {
-	version, err := cfg.GetAPIVersion()
+// CheckAttestation fetches attestation from Circle API using the v1 or v2 endpoint configured for the source domain,
+// unless it is still cached from an earlier check. A nil response means the attestation isn't available yet or the request failed,
+// which is logged. ErrCircuitOpen is returned while the circuit breaker short-circuits requests to the API.
+func CheckAttestation(ctx context.Context, cfg types.CircleSettings, logger log.Logger, irisLookupID, txHash string, sourceDomain, destDomain types.Domain) (*types.AttestationResponse, error) {
+	version, err := cfg.GetSourceAPIVersion(sourceDomain)
+
+	_, span := tracing.Start(ctx, "attestation_check", "api_version", string(version))
+	defer span.End()
+
 	if err != nil {
 		logger.Error("invalid API version", "error", err)
-		return nil
+		span.RecordError(err)
+		return nil, nil
 	}
 
+	var key string
 	switch version {
 	case types.APIVersionV1:
-		return checkAttestationV1(cfg.AttestationBaseURL, logger, irisLookupID)
+		key = v1CacheKey(irisLookupID)
 	case types.APIVersionV2:
-		return checkAttestationV2(cfg.AttestationBaseURL, logger, txHash, sourceDomain)
+		key = v2CacheKey(txHash, sourceDomain)
+	}
+	if response, ok := attestations.get(key, time.Now()); ok {
+		span.SetAttributes("attestation_status", response.Status, "attestation_cached", true)
+		return response, nil
+	}
+
+	var response *types.AttestationResponse
+	switch version {
+	case types.APIVersionV1:
+		response, err = checkAttestationV1(cfg.AttestationBaseURLs(), logger, irisLookupID)
+	case types.APIVersionV2:
+		response, err = checkAttestationV2(cfg.AttestationBaseURLs(), logger, txHash, sourceDomain)
 	default:
 		logger.Error("unsupported API version", "version", version)
-		return nil
 	}
+	if response != nil && key != "" {
+		attestations.set(key, response, time.Now())
+	}
+	span.RecordError(err)
+
+	if response != nil {
+		span.SetAttributes("attestation_status", response.Status)
+	}
+	return response, err
 }
 
 // checkAttestationV1 queries v1 API: GET {baseURL}/attestations/{messageHash}
-func checkAttestationV1(baseURL string, logger log.Logger, irisLookupID string) *types.AttestationResponse {
-	baseURL = normalizeBaseURL(baseURL)
+func checkAttestationV1(baseURLs []string, logger log.Logger, irisLookupID string) (*types.AttestationResponse, error) {
 	irisLookupID = normalizeMessageHash(irisLookupID)
 
-	url := fmt.Sprintf("%s/attestations/%s", baseURL, irisLookupID)
-	logger.Debug(fmt.Sprintf("Checking v1 attestation at %s", url))
+	path := "/attestations/" + irisLookupID
+	logger.Debug(fmt.Sprintf("Checking v1 attestation at %s", path))
 
 	var response types.AttestationResponse
-	if err := httpRequest(http.MethodGet, url, &response); err != nil {
+	if endpoint, err := apiRequest(http.MethodGet, baseURLs, path, &response); err != nil {
 		// Distinguish between "not found" (expected during polling) and actual errors
-		if strings.Contains(err.Error(), "status 404") {
+		switch {
+		case errors.Is(err, ErrCircuitOpen):
+			logger.Debug("Skipping v1 attestation check, circuit breaker is open", "messageHash", irisLookupID)
+			return nil, err
+		case strings.Contains(err.Error(), "status 404"):
 			logger.Debug("v1 attestation not found (may not be ready yet)", "messageHash", irisLookupID)
-		} else {
-			logger.Error("v1 attestation request failed", "error", err, "url", url)
+		default:
+			logger.Error("v1 attestation request failed", "error", err, "endpoint", endpoint, "path", path)
 		}
-		return nil
+		return nil, nil
 	}
 
 	logger.Info(fmt.Sprintf("Attestation found for %s", irisLookupID))
-	return &response
+	return &response, nil
 }
 
 // checkAttestationV2 queries v2 API: GET {baseURL}/v2/messages/{sourceDomain}?transactionHash={txHash}
 // Returns first message for backward compatibility. Use CheckAttestationV2All for multiple messages
-func checkAttestationV2(baseURL string, logger log.Logger, txHash string, sourceDomain types.Domain) *types.AttestationResponse {
-	baseURL = normalizeBaseURL(baseURL)
+func checkAttestationV2(baseURLs []string, logger log.Logger, txHash string, sourceDomain types.Domain) (*types.AttestationResponse, error) {
 	txHash = normalizeMessageHash(txHash)
 
-	url := buildV2MessagesURL(baseURL, sourceDomain, txHash)
-	logger.Debug(fmt.Sprintf("Checking v2 attestation at %s", url))
+	path := buildV2MessagesPath(sourceDomain, txHash)
+	logger.Debug(fmt.Sprintf("Checking v2 attestation at %s", path))
 
 	var v2Response types.AttestationResponseV2
-	if err := httpRequest(http.MethodGet, url, &v2Response); err != nil {
+	if endpoint, err := apiRequest(http.MethodGet, baseURLs, path, &v2Response); err != nil {
 		// Distinguish between "not found" (expected during polling) and actual errors
-		if strings.Contains(err.Error(), "status 404") {
+		switch {
+		case errors.Is(err, ErrCircuitOpen):
+			logger.Debug("Skipping v2 attestation check, circuit breaker is open", "txHash", txHash)
+			return nil, err
+		case strings.Contains(err.Error(), "status 404"):
 			logger.Debug("v2 attestation not found (may not be ready yet)", "txHash", txHash)
-		} else {
-			logger.Error("v2 attestation request failed", "error", err, "url", url)
+		default:
+			logger.Error("v2 attestation request failed", "error", err, "endpoint", endpoint, "path", path)
 		}
-		return nil
+		return nil, nil
 	}
 
 	if len(v2Response.Messages) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	if len(v2Response.Messages) > 1 {
@@ -139,19 +211,19 @@ func checkAttestationV2(baseURL string, logger log.Logger, txHash string, source
 	return &types.AttestationResponse{
 		Attestation: msg.Attestation,
 		Status:      msg.Status,
-	}
+		Message:     msg.Message,
+	}, nil
 }
 
 // CheckAttestationV2All fetches all messages for a transaction from v2 API
-func CheckAttestationV2All(baseURL string, logger log.Logger, txHash string, sourceDomain types.Domain) ([]types.MessageResponseV2, error) {
-	baseURL = normalizeBaseURL(baseURL)
+func CheckAttestationV2All(baseURLs []string, logger log.Logger, txHash string, sourceDomain types.Domain) ([]types.MessageResponseV2, error) {
 	txHash = normalizeMessageHash(txHash)
 
-	url := buildV2MessagesURL(baseURL, sourceDomain, txHash)
-	logger.Debug(fmt.Sprintf("Fetching all v2 messages at %s", url))
+	path := buildV2MessagesPath(sourceDomain, txHash)
+	logger.Debug(fmt.Sprintf("Fetching all v2 messages at %s", path))
 
 	var v2Response types.AttestationResponseV2
-	if err := httpRequest(http.MethodGet, url, &v2Response); err != nil {
+	if _, err := apiRequest(http.MethodGet, baseURLs, path, &v2Response); err != nil {
 		return nil, err
 	}
 
@@ -164,15 +236,14 @@ func CheckAttestationV2All(baseURL string, logger log.Logger, txHash string, sou
 }
 
 // GetAttestationV2Message fetches full v2 message details
-func GetAttestationV2Message(baseURL string, logger log.Logger, txHash string, sourceDomain types.Domain) (*types.MessageResponseV2, error) {
-	baseURL = normalizeBaseURL(baseURL)
+func GetAttestationV2Message(baseURLs []string, logger log.Logger, txHash string, sourceDomain types.Domain) (*types.MessageResponseV2, error) {
 	txHash = normalizeMessageHash(txHash)
 
-	url := buildV2MessagesURL(baseURL, sourceDomain, txHash)
-	logger.Debug(fmt.Sprintf("Fetching v2 message details at %s", url))
+	path := buildV2MessagesPath(sourceDomain, txHash)
+	logger.Debug(fmt.Sprintf("Fetching v2 message details at %s", path))
 
 	var v2Response types.AttestationResponseV2
-	if err := httpRequest(http.MethodGet, url, &v2Response); err != nil {
+	if _, err := apiRequest(http.MethodGet, baseURLs, path, &v2Response); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,10 @@
 package circle_test
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -34,13 +38,13 @@ func TestV1Attestation(t *testing.T) {
 
 	// Valid attestation (with and without 0x prefix, with and without trailing slash)
 	for _, hash := range []string{testMessageHash, "0x" + testMessageHash} {
-		resp := circle.CheckAttestation(cfg.Circle, logger, hash, "", 0, 4)
+		resp, _ := circle.CheckAttestation(context.Background(), cfg.Circle, logger, hash, "", 0, 4)
 		require.NotNil(t, resp)
 		require.Equal(t, "complete", resp.Status)
 	}
 
 	// Not found
-	resp := circle.CheckAttestation(cfg.Circle, logger, "not an attestation", "", 0, 4)
+	resp, _ := circle.CheckAttestation(context.Background(), cfg.Circle, logger, "not an attestation", "", 0, 4)
 	require.Nil(t, resp)
 }
 
@@ -50,12 +54,12 @@ func TestV2Attestation(t *testing.T) {
 	// Test URL normalization (with and without trailing slash, with /attestations suffix)
 	for _, url := range []string{testV2BaseURL, testV2BaseURL + "/", testV1AttestationURL} {
 		cfg.Circle.AttestationBaseURL = url
-		_ = circle.CheckAttestation(cfg.Circle, logger, testMessageHash, "", 0, 4)
+		_, _ = circle.CheckAttestation(context.Background(), cfg.Circle, logger, testMessageHash, "", 0, 4)
 	}
 
 	// Not found
 	cfg.Circle.AttestationBaseURL = testV2BaseURL
-	resp := circle.CheckAttestation(cfg.Circle, logger, "not an attestation", "", 0, 4)
+	resp, _ := circle.CheckAttestation(context.Background(), cfg.Circle, logger, "not an attestation", "", 0, 4)
 	require.Nil(t, resp)
 }
 
@@ -86,3 +90,34 @@ func TestAPIVersionParsing(t *testing.T) {
 	require.Equal(t, "v1", string(types.APIVersionV1))
 	require.Equal(t, "v2", string(types.APIVersionV2))
 }
+
+// TestCheckAttestationMixedVersions verifies source domains with an api-versions override are checked with their
+// version, and the others with the global api-version
+func TestCheckAttestationMixedVersions(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		if r.URL.Path == "/attestations/0x"+testMessageHash {
+			_ = json.NewEncoder(w).Encode(types.AttestationResponse{Status: "complete", Attestation: "0xv1"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.AttestationResponseV2{Messages: []types.MessageResponseV2{{Status: "complete", Attestation: "0xv2"}}})
+	}))
+	defer server.Close()
+
+	circleCfg := types.CircleSettings{
+		AttestationBaseURL: server.URL,
+		APIVersion:         "v2",
+		APIVersions:        map[types.Domain]string{5: "v1"},
+	}
+
+	resp, err := circle.CheckAttestation(context.Background(), circleCfg, logger, testMessageHash, "0x01", 5, 4)
+	require.NoError(t, err)
+	require.Equal(t, "0xv1", resp.Attestation)
+
+	resp, err = circle.CheckAttestation(context.Background(), circleCfg, logger, testMessageHash, "0x01", 0, 4)
+	require.NoError(t, err)
+	require.Equal(t, "0xv2", resp.Attestation)
+
+	require.Equal(t, []string{"/attestations/0x" + testMessageHash, "/v2/messages/0?transactionHash=0x01"}, paths)
+}
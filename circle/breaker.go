@@ -0,0 +1,122 @@
+package circle
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	defaultBreakerThreshold = 10
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without contacting the attestation API while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circle api circuit breaker is open")
+
+// circuit breaker states, reported by the circuit breaker state metric
+const (
+	breakerClosed   = 0
+	breakerHalfOpen = 1
+	breakerOpen     = 2
+)
+
+// breaker guards every attestation API request
+var breaker = newCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown)
+
+// ConfigureCircuitBreaker sets the number of consecutive failed requests that open the circuit breaker and how
+// long it stays open before a request is let through to test recovery
+func ConfigureCircuitBreaker(cfg types.CircleSettings) {
+	threshold := int(cfg.CircuitBreakerThreshold)
+	if threshold == 0 {
+		threshold = defaultBreakerThreshold
+	}
+	cooldown := time.Duration(cfg.CircuitBreakerCooldown) * time.Second
+	if cooldown == 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	breaker = newCircuitBreaker(threshold, cooldown)
+}
+
+// CircuitRetryAfter returns how long until the circuit breaker lets a request through, 0 if it isn't open
+func CircuitRetryAfter() time.Duration {
+	return breaker.retryAfter(time.Now())
+}
+
+// circuitBreaker stops requests to the attestation API after threshold consecutive failures. Once open, requests
+// are short-circuited for the cooldown, then a single request is let through (half-open). Its success closes
+// the circuit, its failure opens it for another cooldown.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow returns ErrCircuitOpen if a request must not be sent
+func (b *circuitBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if now.Before(b.openedAt.Add(b.cooldown)) {
+			return ErrCircuitOpen
+		}
+		b.setState(breakerHalfOpen)
+		return nil
+	case breakerHalfOpen:
+		// a request testing recovery is already in flight
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// record records the outcome of a request that was allowed
+func (b *circuitBreaker) record(success bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.failures = 0
+		b.setState(breakerClosed)
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = now
+		b.setState(breakerOpen)
+	}
+}
+
+func (b *circuitBreaker) retryAfter(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		return max(b.openedAt.Add(b.cooldown).Sub(now), 0)
+	case breakerHalfOpen:
+		return b.cooldown
+	default:
+		return 0
+	}
+}
+
+func (b *circuitBreaker) setState(state int) {
+	b.state = state
+	if m := metrics.Load(); m != nil {
+		m.SetCircuitBreakerState(state)
+	}
+}
@@ -0,0 +1,84 @@
+package circle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+	now := time.Now()
+
+	// failures below the threshold keep it closed, a success resets the count
+	for i := 0; i < 2; i++ {
+		require.NoError(t, b.allow(now))
+		b.record(false, now)
+	}
+	b.record(true, now)
+	for i := 0; i < 2; i++ {
+		b.record(false, now)
+	}
+	require.NoError(t, b.allow(now))
+
+	// the third consecutive failure opens it for the cooldown
+	b.record(false, now)
+	require.ErrorIs(t, b.allow(now), ErrCircuitOpen)
+	require.Equal(t, time.Minute, b.retryAfter(now))
+
+	// after the cooldown a single request tests recovery, a failure opens it again
+	now = now.Add(time.Minute)
+	require.NoError(t, b.allow(now))
+	require.ErrorIs(t, b.allow(now), ErrCircuitOpen)
+	b.record(false, now)
+	require.ErrorIs(t, b.allow(now.Add(time.Second)), ErrCircuitOpen)
+
+	// a successful test request closes it
+	now = now.Add(time.Minute)
+	require.NoError(t, b.allow(now))
+	b.record(true, now)
+	require.NoError(t, b.allow(now))
+	require.Zero(t, b.retryAfter(now))
+}
+
+// TestCheckAttestationCircuitOpen verifies attestation checks stop reaching the API once the breaker opens
+func TestCheckAttestationCircuitOpen(t *testing.T) {
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	prev := breaker
+	ConfigureCircuitBreaker(types.CircleSettings{CircuitBreakerThreshold: 2, CircuitBreakerCooldown: 60})
+	defer func() { breaker = prev }()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := types.CircleSettings{AttestationBaseURL: server.URL, APIVersion: "v1"}
+	for i := 0; i < 2; i++ {
+		resp, err := CheckAttestation(context.Background(), cfg, testLogger, "0x01", "", 0, 4)
+		require.NoError(t, err)
+		require.Nil(t, resp)
+	}
+
+	resp, err := CheckAttestation(context.Background(), cfg, testLogger, "0x01", "", 0, 4)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.Nil(t, resp)
+	require.Equal(t, int64(2), requests.Load())
+	require.Equal(t, float64(breakerOpen), testutil.ToFloat64(m.CircuitBreakerState))
+	require.Greater(t, CircuitRetryAfter(), 50*time.Second)
+}
@@ -0,0 +1,125 @@
+package circle
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	defaultAttestationCacheTTL  = 5 * time.Second
+	defaultAttestationCacheSize = 10000
+
+	attestationStatusComplete = "complete"
+)
+
+// attestations caches attestation responses so requeues of a message don't repeat identical requests to the API
+var attestations = newAttestationCache(defaultAttestationCacheTTL, defaultAttestationCacheSize)
+
+// ConfigureAttestationCache sets how long pending attestations are cached and how many attestations are cached
+func ConfigureAttestationCache(cfg types.CircleSettings) {
+	ttl := time.Duration(cfg.AttestationCacheTTL) * time.Second
+	if ttl == 0 {
+		ttl = defaultAttestationCacheTTL
+	}
+	size := int(cfg.AttestationCacheSize)
+	if size == 0 {
+		size = defaultAttestationCacheSize
+	}
+	attestations = newAttestationCache(ttl, size)
+}
+
+// ForgetAttestation drops the cached attestation of msg, e.g. after requesting a re-attestation that replaces it
+func ForgetAttestation(msg *types.MessageState) {
+	attestations.forget(v1CacheKey(msg.IrisLookupID))
+	attestations.forget(v2CacheKey(msg.SourceTxHash, msg.SourceDomain))
+}
+
+func v1CacheKey(irisLookupID string) string {
+	return "v1/" + normalizeMessageHash(irisLookupID)
+}
+
+func v2CacheKey(txHash string, sourceDomain types.Domain) string {
+	return fmt.Sprintf("v2/%d/%s", sourceDomain, normalizeMessageHash(txHash))
+}
+
+type cachedAttestation struct {
+	response types.AttestationResponse
+	stored   time.Time
+}
+
+// attestationCache holds complete attestations until they are evicted and other attestations, e.g. pending
+// confirmations, for the ttl. Once the cache holds size attestations, expired and then the oldest attestations are
+// evicted. It is safe for concurrent use.
+type attestationCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]cachedAttestation
+}
+
+func newAttestationCache(ttl time.Duration, size int) *attestationCache {
+	return &attestationCache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]cachedAttestation),
+	}
+}
+
+func (c *attestationCache) get(key string, now time.Time) (*types.AttestationResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.expired(entry, now) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	response := entry.response
+	return &response, true
+}
+
+func (c *attestationCache) set(key string, response *types.AttestationResponse, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict(now)
+	}
+	c.entries[key] = cachedAttestation{response: *response, stored: now}
+}
+
+func (c *attestationCache) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+func (c *attestationCache) expired(entry cachedAttestation, now time.Time) bool {
+	return entry.response.Status != attestationStatusComplete && now.Sub(entry.stored) >= c.ttl
+}
+
+// evict drops the expired attestations, or the oldest attestation if none expired. c.mu must be held.
+func (c *attestationCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if c.expired(entry, now) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.stored.Before(oldest) {
+			oldestKey, oldest = key, entry.stored
+		}
+	}
+	if len(c.entries) >= c.size {
+		delete(c.entries, oldestKey)
+	}
+}
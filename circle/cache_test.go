@@ -0,0 +1,70 @@
+package circle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestAttestationCache(t *testing.T) {
+	c := newAttestationCache(5*time.Second, 2)
+	now := time.Now()
+
+	c.set("pending", &types.AttestationResponse{Status: "pending_confirmations"}, now)
+	c.set("complete", &types.AttestationResponse{Status: attestationStatusComplete, Attestation: "0x01"}, now)
+
+	// pending attestations expire after the ttl, complete ones don't
+	_, ok := c.get("pending", now.Add(4*time.Second))
+	require.True(t, ok)
+	_, ok = c.get("pending", now.Add(5*time.Second))
+	require.False(t, ok)
+	response, ok := c.get("complete", now.Add(time.Hour))
+	require.True(t, ok)
+	require.Equal(t, "0x01", response.Attestation)
+
+	// a full cache evicts the oldest attestation
+	c.set("newer", &types.AttestationResponse{Status: attestationStatusComplete}, now.Add(time.Second))
+	c.set("newest", &types.AttestationResponse{Status: attestationStatusComplete}, now.Add(2*time.Second))
+	require.Len(t, c.entries, 2)
+	_, ok = c.get("complete", now.Add(2*time.Second))
+	require.False(t, ok)
+
+	c.forget("newest")
+	_, ok = c.get("newest", now.Add(2*time.Second))
+	require.False(t, ok)
+}
+
+// TestCheckAttestationCached verifies repeated checks of an attestation are served from the cache until it is forgotten
+func TestCheckAttestationCached(t *testing.T) {
+	prev := attestations
+	ConfigureAttestationCache(types.CircleSettings{AttestationCacheTTL: 60})
+	defer func() { attestations = prev }()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_ = json.NewEncoder(w).Encode(types.AttestationResponse{Status: "pending_confirmations"})
+	}))
+	defer server.Close()
+
+	cfg := types.CircleSettings{AttestationBaseURL: server.URL, APIVersion: "v1"}
+	for i := 0; i < 3; i++ {
+		resp, err := CheckAttestation(context.Background(), cfg, testLogger, "0x02", "", 0, 4)
+		require.NoError(t, err)
+		require.Equal(t, "pending_confirmations", resp.Status)
+	}
+	require.EqualValues(t, 1, requests.Load())
+
+	ForgetAttestation(&types.MessageState{IrisLookupID: "0x02"})
+	_, err := CheckAttestation(context.Background(), cfg, testLogger, "0x02", "", 0, 4)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, requests.Load())
+}
@@ -0,0 +1,115 @@
+package circle
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+// metrics records which endpoint served each attestation API request, nil until SetMetrics is called
+var metrics atomic.Pointer[relayer.PromMetrics]
+
+// SetMetrics sets the metrics the attestation API requests are recorded with
+func SetMetrics(m *relayer.PromMetrics) {
+	metrics.Store(m)
+}
+
+// pools holds the endpoint pool of each list of base URLs so the healthy endpoint is remembered across requests
+var pools sync.Map
+
+// endpointPool is a primary attestation API base URL and its fallbacks. Requests go to the endpoint that served
+// the last request first and fail over to the next one on connection errors and server errors.
+type endpointPool struct {
+	urls      []string
+	preferred atomic.Int64
+}
+
+func poolFor(baseURLs []string) *endpointPool {
+	key := strings.Join(baseURLs, ",")
+	if pool, ok := pools.Load(key); ok {
+		return pool.(*endpointPool)
+	}
+
+	urls := make([]string, len(baseURLs))
+	for i, baseURL := range baseURLs {
+		urls[i] = normalizeBaseURL(baseURL)
+	}
+	pool, _ := pools.LoadOrStore(key, &endpointPool{urls: urls})
+	return pool.(*endpointPool)
+}
+
+// apiRequest performs an HTTP request against path of the preferred endpoint of baseURLs, failing over to the
+// others in order. Returns the base URL that served the request, or ErrCircuitOpen without sending the request
+// while the circuit breaker is open.
+func apiRequest(method string, baseURLs []string, path string, result any) (string, error) {
+	pool := poolFor(baseURLs)
+	if len(pool.urls) == 0 {
+		return "", errors.New("no attestation base url configured")
+	}
+
+	b := breaker
+	if err := b.allow(time.Now()); err != nil {
+		return "", err
+	}
+
+	preferred := int(pool.preferred.Load())
+	var err error
+	for i := range pool.urls {
+		idx := (preferred + i) % len(pool.urls)
+		baseURL := pool.urls[idx]
+
+		err = httpRequest(method, baseURL+path, endpointType(path), result)
+		if isEndpointFailure(err) {
+			continue
+		}
+
+		b.record(true, time.Now())
+		if idx != preferred {
+			pool.preferred.Store(int64(idx))
+		}
+		if m := metrics.Load(); m != nil {
+			m.IncAttestationAPIRequests(baseURL)
+		}
+		return baseURL, err
+	}
+	b.record(false, time.Now())
+	return "", fmt.Errorf("all attestation endpoints failed: %w", err)
+}
+
+// endpointType returns the kind of Circle API request sent to path, used to label the request metrics
+func endpointType(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/v2/messages/"):
+		return "attestation-v2"
+	case strings.HasPrefix(path, "/v2/fastBurn/"):
+		return "allowance"
+	case strings.HasPrefix(path, "/v2/reattest/"):
+		return "reattest"
+	default:
+		return "attestation-v1"
+	}
+}
+
+// isEndpointFailure returns true if the endpoint could not be reached or returned a server error
+func isEndpointFailure(err error) bool {
+	var urlErr *url.Error
+	var statusErr *statusError
+	return errors.As(err, &urlErr) || (errors.As(err, &statusErr) && statusErr.code >= http.StatusInternalServerError)
+}
+
+// statusError is returned for non 200 responses
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.code, e.body)
+}
@@ -0,0 +1,102 @@
+package circle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestAPIRequestFailover verifies requests fail over to the next endpoint on server errors and connection errors,
+// and keep going to the endpoint that served the last request
+func TestAPIRequestFailover(t *testing.T) {
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	var primaryDown atomic.Bool
+	primaryDown.Store(true)
+	var primaryRequests atomic.Int64
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests.Add(1)
+		if primaryDown.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"complete"}`))
+	}))
+	defer primary.Close()
+
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/attestations/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"complete"}`))
+	}))
+	defer fallback.Close()
+
+	// unreachable endpoint
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	cfg := types.CircleSettings{
+		AttestationBaseURL:      primary.URL + "/attestations/",
+		AttestationFallbackURLs: []string{down.URL, fallback.URL},
+	}
+
+	var resp types.AttestationResponse
+	endpoint, err := apiRequest(http.MethodGet, cfg.AttestationBaseURLs(), "/attestations/0x01", &resp)
+	require.NoError(t, err)
+	require.Equal(t, fallback.URL, endpoint)
+	require.Equal(t, "complete", resp.Status)
+
+	// the fallback is preferred even once the primary recovers, a not found is not failed over
+	primaryDown.Store(false)
+	endpoint, err = apiRequest(http.MethodGet, cfg.AttestationBaseURLs(), "/attestations/missing", &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 404")
+	require.Equal(t, fallback.URL, endpoint)
+	require.Equal(t, int64(1), primaryRequests.Load())
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.AttestationAPIRequest.WithLabelValues(fallback.URL)))
+
+	// every endpoint failing returns the last error
+	fallback.Close()
+	primary.Close()
+	_, err = apiRequest(http.MethodGet, cfg.AttestationBaseURLs(), "/attestations/0x01", &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "all attestation endpoints failed")
+}
+
+func TestCircleRequestMetrics(t *testing.T) {
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/reattest/0/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"messages":[]}`))
+	}))
+	defer server.Close()
+
+	var resp types.AttestationResponseV2
+	_, err := apiRequest(http.MethodGet, []string{server.URL}, buildV2MessagesPath(0, "0x01"), &resp)
+	require.NoError(t, err)
+	_, err = apiRequest(http.MethodPost, []string{server.URL}, "/v2/reattest/0/1", &resp)
+	require.Error(t, err)
+
+	require.Equal(t, 2, testutil.CollectAndCount(m.CircleRequestDuration))
+	require.Zero(t, testutil.ToFloat64(m.CircleRequestErrors.WithLabelValues("attestation-v2", "200")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.CircleRequestErrors.WithLabelValues("reattest", "404")))
+}
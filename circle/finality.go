@@ -0,0 +1,46 @@
+package circle
+
+import (
+	"fmt"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// MeetsRequiredFinality returns true if an attestation executed at the executed finality threshold can be
+// broadcast on msg's route
+func MeetsRequiredFinality(msg *types.MessageState, executed uint32) bool {
+	return msg.RequiredFinalityThreshold == 0 || executed >= msg.RequiredFinalityThreshold
+}
+
+// HandleRequiredFinality requests a re-attestation of a message whose attestation was executed below the finality
+// threshold its route requires, so Circle attests it again once the burn reaches that finality. Requests back off
+// like expiring attestation re-attestations. The result is applied with ApplyReattestResult.
+func HandleRequiredFinality(
+	cfg types.CircleSettings,
+	msg *types.MessageState,
+	executed uint32,
+	logger log.Logger,
+) (*ReattestResult, error) {
+	result := &ReattestResult{}
+	if MeetsRequiredFinality(msg, executed) {
+		return result, nil
+	}
+
+	if next := msg.LastReattestTime.Add(reattestBackoff(msg)); time.Now().Before(next) {
+		logger.Debug("Waiting for re-attestation backoff", "nonce", msg.Nonce, "attempts", msg.ReattestCount, "next_attempt", next)
+		return result, nil
+	}
+
+	logger.Info(fmt.Sprintf("Attestation for nonce %d executed at finality %d, route requires %d, requesting re-attestation",
+		msg.Nonce, executed, msg.RequiredFinalityThreshold))
+
+	result.ShouldReattest = true
+	if _, err := RequestReattestation(cfg.AttestationBaseURLs(), logger, msg.SourceDomain, msg.Nonce); err != nil {
+		result.RemoveFromQueue = true
+		return result, fmt.Errorf("re-attestation failed for nonce %d: %w", msg.Nonce, err)
+	}
+	return result, nil
+}
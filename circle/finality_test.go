@@ -0,0 +1,60 @@
+package circle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestMeetsRequiredFinality(t *testing.T) {
+	msg := &types.MessageState{}
+	require.True(t, MeetsRequiredFinality(msg, 0))
+	require.True(t, MeetsRequiredFinality(msg, 1000))
+
+	msg.RequiredFinalityThreshold = types.FinalityThresholdFinalized
+	require.False(t, MeetsRequiredFinality(msg, 0))
+	require.False(t, MeetsRequiredFinality(msg, 1000))
+	require.True(t, MeetsRequiredFinality(msg, 2000))
+}
+
+// TestHandleRequiredFinality verifies a re-attestation is requested for attestations below the route's required
+// finality, and not again until the backoff elapsed
+func TestHandleRequiredFinality(t *testing.T) {
+	var reattests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/reattest/0/7", r.URL.Path)
+		reattests.Add(1)
+		_, _ = w.Write([]byte(`{"attestation":"0x01","status":"complete"}`))
+	}))
+	defer server.Close()
+
+	cfg := types.CircleSettings{AttestationBaseURL: server.URL}
+	msg := &types.MessageState{Nonce: 7, RequiredFinalityThreshold: types.FinalityThresholdFinalized}
+
+	result, err := HandleRequiredFinality(cfg, msg, 2000, log.NewNopLogger())
+	require.NoError(t, err)
+	require.False(t, result.ShouldReattest)
+	require.Zero(t, reattests.Load())
+
+	result, err = HandleRequiredFinality(cfg, msg, 1000, log.NewNopLogger())
+	require.NoError(t, err)
+	require.True(t, result.ShouldReattest)
+	require.False(t, result.RemoveFromQueue)
+	require.EqualValues(t, 1, reattests.Load())
+
+	// backing off
+	msg.ReattestCount = 1
+	msg.LastReattestTime = time.Now()
+	result, err = HandleRequiredFinality(cfg, msg, 1000, log.NewNopLogger())
+	require.NoError(t, err)
+	require.False(t, result.ShouldReattest)
+	require.EqualValues(t, 1, reattests.Load())
+}
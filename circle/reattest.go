@@ -8,9 +8,16 @@ import (
 
 	"cosmossdk.io/log"
 
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
+// re-attestations of a message back off exponentially from reattestBaseBackoff up to reattestMaxBackoff
+const (
+	reattestBaseBackoff = 30 * time.Second
+	reattestMaxBackoff  = 10 * time.Minute
+)
+
 // ReattestResult contains the outcome of re-attestation attempt
 type ReattestResult struct {
 	ShouldReattest     bool
@@ -18,17 +25,20 @@ type ReattestResult struct {
 	NewExpirationBlock uint64
 	ExhaustedRetries   bool
 	RemoveFromQueue    bool
+	Expired            bool // the attestation expired before it was handled, i.e. its expiration block has passed
+	// set while an expired attestation waits for the re-attestation backoff, the message must be held from
+	// broadcast until then
+	BackoffWait time.Duration
 }
 
 // RequestReattestation requests a new attestation with a higher finality threshold
-func RequestReattestation(baseURL string, logger log.Logger, sourceDomain types.Domain, nonce uint64) (*types.AttestationResponse, error) {
-	baseURL = normalizeBaseURL(baseURL)
-	url := fmt.Sprintf("%s/v2/reattest/%d/%d", baseURL, sourceDomain, nonce)
+func RequestReattestation(baseURLs []string, logger log.Logger, sourceDomain types.Domain, nonce uint64) (*types.AttestationResponse, error) {
+	path := fmt.Sprintf("/v2/reattest/%d/%d", sourceDomain, nonce)
 
 	logger.Info(fmt.Sprintf("Requesting re-attestation for domain %d nonce %d", sourceDomain, nonce))
 
 	var reattestResp types.ReattestResponse
-	if err := httpRequest(http.MethodPost, url, &reattestResp); err != nil {
+	if _, err := apiRequest(http.MethodPost, baseURLs, path, &reattestResp); err != nil {
 		return nil, err
 	}
 
@@ -51,11 +61,62 @@ func ParseExpirationBlock(expirationBlock string) uint64 {
 	return block
 }
 
-// HandleExpiringAttestation checks if Fast Transfer attestation is expiring and handles re-attestation
+// ParseFinalityThreshold converts finality threshold string to uint32, returns 0 on error
+func ParseFinalityThreshold(finalityThreshold string) uint32 {
+	if finalityThreshold == "" {
+		return 0
+	}
+	threshold, err := strconv.ParseUint(finalityThreshold, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(threshold)
+}
+
+// ExpiresIn estimates the time until the destination chain reaches expirationBlock from its current block and
+// average block time. Returns false if the block time isn't known yet.
+func ExpiresIn(currentBlock, expirationBlock uint64, blockTime time.Duration) (time.Duration, bool) {
+	if blockTime <= 0 {
+		return 0, false
+	}
+	if currentBlock >= expirationBlock {
+		return 0, true
+	}
+	return time.Duration(expirationBlock-currentBlock) * blockTime, true
+}
+
+// reattestBackoff returns how long to wait after the last re-attestation of msg before requesting another one
+func reattestBackoff(msg *types.MessageState) time.Duration {
+	if msg.ReattestCount == 0 {
+		return 0
+	}
+
+	backoff := reattestBaseBackoff
+	for i := uint(1); i < msg.ReattestCount && backoff < reattestMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, reattestMaxBackoff)
+}
+
+// expirationBufferBlocks returns the number of blocks before expiry to re-attest a message to destDomain at.
+// expiration-buffer-seconds is converted to blocks with the destination chain's block time,
+// expiration-buffer-blocks is used while the block time isn't known.
+func expirationBufferBlocks(cfg types.CircleSettings, destDomain types.Domain, blockTime time.Duration) uint64 {
+	if cfg.ExpirationBufferSeconds > 0 {
+		if blocks, ok := types.BlocksIn(time.Duration(cfg.ExpirationBufferSeconds)*time.Second, blockTime); ok {
+			return blocks
+		}
+	}
+	return uint64(cfg.ExpirationBufferBlocks.For(destDomain))
+}
+
+// HandleExpiringAttestation checks if Fast Transfer attestation is expiring and handles re-attestation.
+// blockTime is the destination chain's average block time, 0 if not known.
 func HandleExpiringAttestation(
 	msg *types.MessageState,
 	cfg types.CircleSettings,
 	currentBlock uint64,
+	blockTime time.Duration,
 	logger log.Logger,
 ) (*ReattestResult, error) {
 	result := &ReattestResult{}
@@ -66,12 +127,13 @@ func HandleExpiringAttestation(
 	}
 
 	// Check if attestation is expiring soon
-	bufferBlocks := uint64(cfg.ExpirationBufferBlocks)
+	bufferBlocks := expirationBufferBlocks(cfg, msg.DestDomain, blockTime)
 	if currentBlock+bufferBlocks < msg.ExpirationBlock {
 		return result, nil
 	}
 
 	result.ShouldReattest = true
+	result.Expired = msg.ExpirationBlock < currentBlock
 
 	// Check if retries exhausted
 	maxRetries := cfg.ReattestMaxRetries
@@ -83,11 +145,24 @@ func HandleExpiringAttestation(
 		return result, fmt.Errorf("max re-attestation attempts reached for nonce %d (attempts: %d)", msg.Nonce, msg.ReattestCount)
 	}
 
+	// don't spam the re-attestation endpoint while the last attempt's backoff hasn't elapsed
+	if next := msg.LastReattestTime.Add(reattestBackoff(msg)); time.Now().Before(next) {
+		logger.Debug("Waiting for re-attestation backoff", "nonce", msg.Nonce, "attempts", msg.ReattestCount, "next_attempt", next)
+		// an attestation that's only expiring soon can still be broadcast, an expired one would be rejected
+		if result.Expired {
+			return &ReattestResult{Expired: true, BackoffWait: time.Until(next)}, nil
+		}
+		return &ReattestResult{}, nil
+	}
+
+	if expiresIn, ok := ExpiresIn(currentBlock, msg.ExpirationBlock, blockTime); ok {
+		logger = logger.With("expires_in", expiresIn.Round(time.Second).String())
+	}
 	logger.Info(fmt.Sprintf("Fast Transfer attestation expiring soon for nonce %d (current: %d, expires: %d), requesting re-attestation",
 		msg.Nonce, currentBlock, msg.ExpirationBlock))
 
 	// Request re-attestation
-	newAttestation, err := RequestReattestation(cfg.AttestationBaseURL, logger, msg.SourceDomain, msg.Nonce)
+	newAttestation, err := RequestReattestation(cfg.AttestationBaseURLs(), logger, msg.SourceDomain, msg.Nonce)
 	if err != nil {
 		result.RemoveFromQueue = true
 		return result, fmt.Errorf("re-attestation failed for nonce %d: %w", msg.Nonce, err)
@@ -96,7 +171,7 @@ func HandleExpiringAttestation(
 	result.NewAttestation = newAttestation.Attestation
 
 	// Fetch updated expiration block
-	if updatedMsg, err := GetAttestationV2Message(cfg.AttestationBaseURL, logger, msg.SourceTxHash, msg.SourceDomain); err != nil {
+	if updatedMsg, err := GetAttestationV2Message(cfg.AttestationBaseURLs(), logger, msg.SourceTxHash, msg.SourceDomain); err != nil {
 		logger.Info("Failed to fetch updated expiration after re-attestation", "nonce", msg.Nonce, "error", err)
 	} else if updatedMsg != nil {
 		result.NewExpirationBlock = ParseExpirationBlock(updatedMsg.ExpirationBlock)
@@ -106,19 +181,53 @@ func HandleExpiringAttestation(
 	return result, nil
 }
 
-// ApplyReattestResult applies the re-attestation result to message state with proper locking
-func ApplyReattestResult(state *types.StateMap, msg *types.MessageState, result *ReattestResult) {
+// ApplyReattestResult applies the re-attestation result to message state with proper locking and counts the
+// attempt. metrics may be nil.
+func ApplyReattestResult(state *types.StateMap, msg *types.MessageState, result *ReattestResult, metrics *relayer.PromMetrics) {
 	if !result.ShouldReattest {
 		return
 	}
 
+	if metrics != nil {
+		srcDomain := fmt.Sprint(msg.SourceDomain)
+		// exhausted retries don't request a re-attestation
+		if !result.ExhaustedRetries {
+			metrics.IncReattest(srcDomain)
+			if result.RemoveFromQueue {
+				metrics.IncReattestFailures(srcDomain)
+			}
+		}
+	}
+
+	// the re-attestation replaces the cached attestation
+	ForgetAttestation(msg)
+
 	state.Mu.Lock()
 	defer state.Mu.Unlock()
 
+	// an attestation that expired or can't be re-attested again is a Fast Transfer that missed its window, counted
+	// once per message however often it expires again
+	if (result.Expired || result.ExhaustedRetries) && !msg.FastTransferExpired {
+		msg.FastTransferExpired = true
+		if metrics != nil {
+			metrics.IncFastTransferExpired(fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+		}
+	}
+
 	msg.ReattestCount++
 	msg.LastReattestTime = time.Now()
 
 	if result.ExhaustedRetries {
+		// a pending message leaves the pending gauges
+		if metrics != nil {
+			if msg.Status == types.Pending {
+				metrics.DecPending(fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+			}
+			if msg.StuckPending {
+				metrics.DecStuckPending(fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+			}
+		}
+		msg.StuckPending = false
 		msg.Status = types.Failed
 		msg.Updated = time.Now()
 		return
@@ -5,11 +5,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 
 	"cosmossdk.io/log"
 
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
@@ -29,13 +32,13 @@ func TestHandleExpiringAttestation_NotExpiring(t *testing.T) {
 
 	cfg := types.CircleSettings{
 		AttestationBaseURL:     "https://iris-api.circle.com",
-		ExpirationBufferBlocks: 100,
+		ExpirationBufferBlocks: types.ExpirationBuffer{Default: 100},
 		ReattestMaxRetries:     3,
 	}
 
 	currentBlock := uint64(800) // Before expiration
 
-	result, err := HandleExpiringAttestation(msg, cfg, currentBlock, testLogger)
+	result, err := HandleExpiringAttestation(msg, cfg, currentBlock, 0, testLogger)
 	require.NoError(t, err)
 	require.False(t, result.ShouldReattest)
 	require.False(t, result.ExhaustedRetries)
@@ -52,18 +55,149 @@ func TestHandleExpiringAttestation_ExhaustedRetries(t *testing.T) {
 
 	cfg := types.CircleSettings{
 		AttestationBaseURL:     "https://iris-api.circle.com",
-		ExpirationBufferBlocks: 100,
+		ExpirationBufferBlocks: types.ExpirationBuffer{Default: 100},
 		ReattestMaxRetries:     3,
 	}
 
 	currentBlock := uint64(950) // Within expiration buffer
 
-	result, err := HandleExpiringAttestation(msg, cfg, currentBlock, testLogger)
+	result, err := HandleExpiringAttestation(msg, cfg, currentBlock, 0, testLogger)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "max re-attestation attempts reached")
 	require.True(t, result.ShouldReattest)
 	require.True(t, result.ExhaustedRetries)
 	require.False(t, result.RemoveFromQueue)
+	require.False(t, result.Expired)
+
+	// the expiration block already passed
+	result, _ = HandleExpiringAttestation(msg, cfg, 1001, 0, testLogger)
+	require.True(t, result.ExhaustedRetries)
+	require.True(t, result.Expired)
+}
+
+// TestHandleExpiringAttestation_BufferSeconds verifies expiration-buffer-seconds is converted to blocks once the
+// destination block time is known
+func TestHandleExpiringAttestation_BufferSeconds(t *testing.T) {
+	msg := &types.MessageState{
+		Nonce:           123,
+		ExpirationBlock: 1000,
+		ReattestCount:   3,
+	}
+
+	cfg := types.CircleSettings{
+		ExpirationBufferBlocks:  types.ExpirationBuffer{Default: 10},
+		ExpirationBufferSeconds: 600,
+		ReattestMaxRetries:      3,
+	}
+
+	// block time unknown, falls back to the 10 block buffer
+	result, err := HandleExpiringAttestation(msg, cfg, 950, 0, testLogger)
+	require.NoError(t, err)
+	require.False(t, result.ShouldReattest)
+
+	// 600s at 12s blocks is a 50 block buffer
+	result, err = HandleExpiringAttestation(msg, cfg, 950, 12*time.Second, testLogger)
+	require.Error(t, err)
+	require.True(t, result.ShouldReattest)
+}
+
+// TestHandleExpiringAttestation_PerDomainBuffer verifies the buffer is looked up by destination domain, falling
+// back to the default for other domains
+func TestHandleExpiringAttestation_PerDomainBuffer(t *testing.T) {
+	cfg := types.CircleSettings{
+		ExpirationBufferBlocks: types.ExpirationBuffer{
+			Default:   10,
+			PerDomain: map[types.Domain]uint{3: 100},
+		},
+		ReattestMaxRetries: 3,
+	}
+
+	// 950 + 100 reaches the expiration block of a message to domain 3
+	msg := &types.MessageState{Nonce: 123, DestDomain: 3, ExpirationBlock: 1000, ReattestCount: 3}
+	result, err := HandleExpiringAttestation(msg, cfg, 950, 0, testLogger)
+	require.Error(t, err)
+	require.True(t, result.ShouldReattest)
+
+	// 950 + 10 does not for any other domain
+	msg = &types.MessageState{Nonce: 123, DestDomain: 0, ExpirationBlock: 1000, ReattestCount: 3}
+	result, err = HandleExpiringAttestation(msg, cfg, 950, 0, testLogger)
+	require.NoError(t, err)
+	require.False(t, result.ShouldReattest)
+}
+
+// TestHandleExpiringAttestation_Backoff verifies no re-attestation is requested until the backoff since the last
+// attempt has elapsed
+func TestHandleExpiringAttestation_Backoff(t *testing.T) {
+	msg := &types.MessageState{
+		Nonce:            123,
+		ExpirationBlock:  1000,
+		ReattestCount:    2,
+		LastReattestTime: time.Now().Add(-45 * time.Second),
+	}
+
+	cfg := types.CircleSettings{
+		ExpirationBufferBlocks: types.ExpirationBuffer{Default: 100},
+		ReattestMaxRetries:     3,
+	}
+
+	result, err := HandleExpiringAttestation(msg, cfg, 950, 0, testLogger)
+	require.NoError(t, err)
+	require.False(t, result.ShouldReattest)
+	require.False(t, result.RemoveFromQueue)
+	require.Zero(t, result.BackoffWait)
+
+	// an expired attestation is held from broadcast until the backoff elapsed
+	result, err = HandleExpiringAttestation(msg, cfg, 1001, 0, testLogger)
+	require.NoError(t, err)
+	require.False(t, result.ShouldReattest)
+	require.True(t, result.Expired)
+	require.InDelta(t, 15*time.Second, result.BackoffWait, float64(time.Second))
+}
+
+func TestReattestBackoff(t *testing.T) {
+	require.Zero(t, reattestBackoff(&types.MessageState{}))
+	require.Equal(t, 30*time.Second, reattestBackoff(&types.MessageState{ReattestCount: 1}))
+	require.Equal(t, 60*time.Second, reattestBackoff(&types.MessageState{ReattestCount: 2}))
+	require.Equal(t, 4*time.Minute, reattestBackoff(&types.MessageState{ReattestCount: 4}))
+	require.Equal(t, 10*time.Minute, reattestBackoff(&types.MessageState{ReattestCount: 10}))
+}
+
+// TestApplyReattestResult_Metrics verifies attempts and failed attempts are counted, exhausted retries are not, and
+// expired attestations and exhausted retries are counted once per message as expired Fast Transfers
+func TestApplyReattestResult_Metrics(t *testing.T) {
+	state := types.NewStateMap()
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	msg := &types.MessageState{Nonce: 123, SourceDomain: 6, DestDomain: 4, Status: types.Attested}
+
+	ApplyReattestResult(state, msg, &ReattestResult{ShouldReattest: true, NewAttestation: "new-attestation"}, m)
+	ApplyReattestResult(state, msg, &ReattestResult{ShouldReattest: true, RemoveFromQueue: true}, m)
+	ApplyReattestResult(state, msg, &ReattestResult{ShouldReattest: true, ExhaustedRetries: true}, m)
+	ApplyReattestResult(state, msg, &ReattestResult{}, m)
+	ApplyReattestResult(state, msg, &ReattestResult{ShouldReattest: true, Expired: true, NewAttestation: "new-attestation"}, m)
+
+	require.Equal(t, 3.0, testutil.ToFloat64(m.ReattestTotal.WithLabelValues("6")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.ReattestFailures.WithLabelValues("6")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.FastTransferExpired.WithLabelValues("6", "4")))
+	require.True(t, msg.FastTransferExpired)
+
+	// another message expiring while it waits for the backoff again is counted once too
+	other := &types.MessageState{Nonce: 124, SourceDomain: 6, DestDomain: 4, Status: types.Attested}
+	ApplyReattestResult(state, other, &ReattestResult{ShouldReattest: true, Expired: true, NewAttestation: "new-attestation"}, m)
+	ApplyReattestResult(state, other, &ReattestResult{ShouldReattest: true, Expired: true, NewAttestation: "new-attestation"}, m)
+	require.Equal(t, 2.0, testutil.ToFloat64(m.FastTransferExpired.WithLabelValues("6", "4")))
+}
+
+func TestExpiresIn(t *testing.T) {
+	expiresIn, ok := ExpiresIn(900, 1000, 2*time.Second)
+	require.True(t, ok)
+	require.Equal(t, 200*time.Second, expiresIn)
+
+	expiresIn, ok = ExpiresIn(1001, 1000, 2*time.Second)
+	require.True(t, ok)
+	require.Zero(t, expiresIn)
+
+	_, ok = ExpiresIn(900, 1000, 0)
+	require.False(t, ok)
 }
 
 // TestParseExpirationBlock verifies expiration block parsing
@@ -101,7 +235,7 @@ func TestApplyReattestResult_ExhaustedRetries(t *testing.T) {
 		ExhaustedRetries: true,
 	}
 
-	ApplyReattestResult(state, msg, result)
+	ApplyReattestResult(state, msg, result, nil)
 
 	require.Equal(t, types.Failed, msg.Status)
 	require.Equal(t, uint(3), msg.ReattestCount)
@@ -127,7 +261,7 @@ func TestApplyReattestResult_SuccessfulReattest(t *testing.T) {
 	}
 
 	beforeTime := time.Now()
-	ApplyReattestResult(state, msg, result)
+	ApplyReattestResult(state, msg, result, nil)
 	afterTime := time.Now()
 
 	require.Equal(t, types.Attested, msg.Status)
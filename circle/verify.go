@@ -0,0 +1,114 @@
+package circle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// ErrUnknownAttester is returned when an attestation is signed by an address outside the attester set, e.g. after
+// Circle rotated its attesters and the configured set is out of date
+var ErrUnknownAttester = errors.New("not an enabled attester")
+
+// ParseAttesters converts hex encoded attester addresses into EVM addresses
+func ParseAttesters(addresses []string) ([]common.Address, error) {
+	attesters := make([]common.Address, 0, len(addresses))
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid attester address: %s", address)
+		}
+		attesters = append(attesters, common.HexToAddress(address))
+	}
+	return attesters, nil
+}
+
+// ValidateAttesterSet checks the attester addresses parse, are distinct and can meet the threshold
+func ValidateAttesterSet(addresses []string, threshold int) error {
+	attesters, err := ParseAttesters(addresses)
+	if err != nil {
+		return err
+	}
+	if len(attesters) == 0 {
+		return fmt.Errorf("attester-addresses are required to verify attestations")
+	}
+	seen := make(map[common.Address]bool, len(attesters))
+	for _, attester := range attesters {
+		if seen[attester] {
+			return fmt.Errorf("duplicate attester address: %s", attester.Hex())
+		}
+		seen[attester] = true
+	}
+	if threshold <= 0 || threshold > len(attesters) {
+		return fmt.Errorf("attester-threshold must be between 1 and the %d attester addresses, got %d", len(attesters), threshold)
+	}
+	return nil
+}
+
+// VerifyAttestation checks that an attestation was produced by Circle's attester set for the given message.
+// An attestation is the concatenation of 65-byte ECDSA signatures over keccak256(message), ordered by
+// increasing signer address. At least `threshold` signatures must recover to distinct enabled attesters.
+func VerifyAttestation(messageBytes []byte, attestationHex string, attesters []common.Address, threshold int) error {
+	if threshold <= 0 {
+		return fmt.Errorf("invalid attester threshold: %d", threshold)
+	}
+
+	attestation, err := types.DecodeAttestation(attestationHex)
+	if err != nil {
+		return fmt.Errorf("unable to decode attestation: %w", err)
+	}
+
+	if len(attestation) == 0 || len(attestation)%types.AttestationSignatureLength != 0 {
+		return fmt.Errorf("invalid attestation length: %d", len(attestation))
+	}
+
+	digest := crypto.Keccak256(messageBytes)
+
+	var lastSigner common.Address
+	valid := 0
+	for i := 0; i < len(attestation); i += types.AttestationSignatureLength {
+		sig := make([]byte, types.AttestationSignatureLength)
+		copy(sig, attestation[i:i+types.AttestationSignatureLength])
+
+		// attestations use Ethereum's 27/28 recovery id, go-ethereum expects 0/1
+		if sig[64] >= 27 {
+			sig[64] -= 27
+		}
+
+		pubKey, err := crypto.SigToPub(digest, sig)
+		if err != nil {
+			return fmt.Errorf("unable to recover signer for signature %d: %w", i/types.AttestationSignatureLength, err)
+		}
+		signer := crypto.PubkeyToAddress(*pubKey)
+
+		// signers must be in increasing order, which also rules out duplicate signatures
+		if i > 0 && bytes.Compare(signer.Bytes(), lastSigner.Bytes()) <= 0 {
+			return fmt.Errorf("invalid signature order or duplicate signer: %s", signer.Hex())
+		}
+		lastSigner = signer
+
+		if !isAttester(signer, attesters) {
+			return fmt.Errorf("signer %s is %w", signer.Hex(), ErrUnknownAttester)
+		}
+		valid++
+	}
+
+	if valid < threshold {
+		return fmt.Errorf("attestation has %d valid signatures, threshold is %d", valid, threshold)
+	}
+
+	return nil
+}
+
+func isAttester(signer common.Address, attesters []common.Address) bool {
+	for _, attester := range attesters {
+		if attester == signer {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package circle
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+type testAttester struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func newTestAttesters(t *testing.T, n int) []testAttester {
+	t.Helper()
+	attesters := make([]testAttester, n)
+	for i := range attesters {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		attesters[i] = testAttester{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+	}
+	sort.Slice(attesters, func(i, j int) bool {
+		return bytes.Compare(attesters[i].address.Bytes(), attesters[j].address.Bytes()) < 0
+	})
+	return attesters
+}
+
+func signAttestation(t *testing.T, message []byte, signers []testAttester) string {
+	t.Helper()
+	digest := crypto.Keccak256(message)
+	var attestation []byte
+	for _, signer := range signers {
+		sig, err := crypto.Sign(digest, signer.key)
+		require.NoError(t, err)
+		sig[64] += 27
+		attestation = append(attestation, sig...)
+	}
+	return "0x" + hex.EncodeToString(attestation)
+}
+
+func addresses(attesters []testAttester) []common.Address {
+	out := make([]common.Address, len(attesters))
+	for i, a := range attesters {
+		out[i] = a.address
+	}
+	return out
+}
+
+// TestVerifyAttestation_Valid verifies a correctly signed attestation passes
+func TestVerifyAttestation_Valid(t *testing.T) {
+	message := []byte("cctp message")
+	attesters := newTestAttesters(t, 2)
+
+	attestation := signAttestation(t, message, attesters)
+	require.NoError(t, VerifyAttestation(message, attestation, addresses(attesters), 2))
+
+	// unprefixed hex
+	require.NoError(t, VerifyAttestation(message, attestation[2:], addresses(attesters), 2))
+}
+
+// TestVerifyAttestation_WrongMessage verifies an attestation for a different message is rejected
+func TestVerifyAttestation_WrongMessage(t *testing.T) {
+	attesters := newTestAttesters(t, 1)
+
+	attestation := signAttestation(t, []byte("cctp message"), attesters)
+	require.Error(t, VerifyAttestation([]byte("other message"), attestation, addresses(attesters), 1))
+}
+
+// TestVerifyAttestation_BelowThreshold verifies the threshold is enforced
+func TestVerifyAttestation_BelowThreshold(t *testing.T) {
+	message := []byte("cctp message")
+	attesters := newTestAttesters(t, 2)
+
+	attestation := signAttestation(t, message, attesters[:1])
+	err := VerifyAttestation(message, attestation, addresses(attesters), 2)
+	require.ErrorContains(t, err, "threshold")
+	require.NotErrorIs(t, err, ErrUnknownAttester)
+}
+
+// TestVerifyAttestation_UnknownSigner verifies signatures from outside the attester set are rejected
+func TestVerifyAttestation_UnknownSigner(t *testing.T) {
+	message := []byte("cctp message")
+	attesters := newTestAttesters(t, 2)
+
+	attestation := signAttestation(t, message, attesters[1:])
+	err := VerifyAttestation(message, attestation, addresses(attesters[:1]), 1)
+	require.ErrorContains(t, err, "not an enabled attester")
+	require.ErrorIs(t, err, ErrUnknownAttester)
+}
+
+// TestVerifyAttestation_Order verifies signatures must be ordered by signer address
+func TestVerifyAttestation_Order(t *testing.T) {
+	message := []byte("cctp message")
+	attesters := newTestAttesters(t, 2)
+
+	attestation := signAttestation(t, message, []testAttester{attesters[1], attesters[0]})
+	err := VerifyAttestation(message, attestation, addresses(attesters), 2)
+	require.ErrorContains(t, err, "order")
+}
+
+// TestVerifyAttestation_InvalidInput verifies malformed attestations are rejected
+func TestVerifyAttestation_InvalidInput(t *testing.T) {
+	attesters := newTestAttesters(t, 1)
+
+	require.Error(t, VerifyAttestation([]byte("msg"), "0xzz", addresses(attesters), 1))
+	require.Error(t, VerifyAttestation([]byte("msg"), "0x1234", addresses(attesters), 1))
+	require.Error(t, VerifyAttestation([]byte("msg"), "", addresses(attesters), 1))
+}
+
+func TestValidateAttesterSet(t *testing.T) {
+	a1 := "0x" + strings.Repeat("11", 20)
+	a2 := "0x" + strings.Repeat("22", 20)
+
+	require.NoError(t, ValidateAttesterSet([]string{a1, a2}, 2))
+	require.NoError(t, ValidateAttesterSet([]string{a1}, 1))
+
+	require.ErrorContains(t, ValidateAttesterSet(nil, 1), "attester-addresses are required")
+	require.ErrorContains(t, ValidateAttesterSet([]string{"0xnotanaddress"}, 1), "invalid attester address")
+	require.ErrorContains(t, ValidateAttesterSet([]string{a1, strings.ToUpper(a1[:2]) + a1[2:]}, 1), "duplicate attester address")
+	require.ErrorContains(t, ValidateAttesterSet([]string{a1}, 0), "attester-threshold")
+	require.ErrorContains(t, ValidateAttesterSet([]string{a1, a2}, 3), "attester-threshold")
+}
@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
+	"github.com/strangelove-ventures/noble-cctp-relayer/filters"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func newPauseTestAPI(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/status", getStatus)
+	admin := router.Group("/", requireAuthToken(token))
+	admin.POST("/pause", pauseRelaying(log.NewNopLogger()))
+	admin.POST("/resume", resumeRelaying(log.NewNopLogger()))
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// apiCall sends a request with the bearer token, if set, and decodes a 200 response into out, if not nil
+func apiCall(t *testing.T, method, url, token string, out any) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestPauseAPI(t *testing.T) {
+	t.Cleanup(func() { Pause.Resume(nil) })
+	server := newPauseTestAPI(t, "secret")
+
+	code := apiCall(t, http.MethodPost, server.URL+"/pause", "", nil)
+	require.Equal(t, http.StatusUnauthorized, code)
+	code = apiCall(t, http.MethodPost, server.URL+"/pause", "wrong", nil)
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.False(t, Pause.IsPaused(0, 4))
+
+	var status types.PauseStatus
+	code = apiCall(t, http.MethodPost, server.URL+"/pause?route=0-4", "secret", &status)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, types.PauseStatus{PausedRoutes: []string{"0-4"}}, status)
+	require.True(t, Pause.IsPaused(0, 4))
+	require.False(t, Pause.IsPaused(4, 0))
+
+	code = apiCall(t, http.MethodPost, server.URL+"/pause?route=ethereum", "secret", nil)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code = apiCall(t, http.MethodPost, server.URL+"/pause", "secret", &status)
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, status.Paused)
+
+	code = apiCall(t, http.MethodGet, server.URL+"/status", "", &status)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, types.PauseStatus{Paused: true, PausedRoutes: []string{"0-4"}}, status)
+
+	code = apiCall(t, http.MethodPost, server.URL+"/resume", "secret", &status)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, types.PauseStatus{PausedRoutes: []string{}}, status)
+	require.False(t, Pause.IsPaused(0, 4))
+}
+
+// TestPauseAPIWithoutToken verifies the pause endpoints are disabled when no auth token is configured
+func TestPauseAPIWithoutToken(t *testing.T) {
+	server := newPauseTestAPI(t, "")
+
+	code := apiCall(t, http.MethodPost, server.URL+"/pause", "", nil)
+	require.Equal(t, http.StatusForbidden, code)
+	require.False(t, Pause.IsPaused(0, 4))
+
+	code = apiCall(t, http.MethodGet, server.URL+"/status", "", nil)
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestRequeueAPI(t *testing.T) {
+	processingQueue := make(chan *types.TxState, 10)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/requeue/:txHash", requireAuthToken("secret"), requeueTx(log.NewNopLogger(), processingQueue))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	tx := &types.TxState{TxHash: "0xrequeue", RetryAttempt: 3, Msgs: []*types.MessageState{
+		{SourceTxHash: "0xrequeue", Nonce: 1, Status: types.Failed, Attestation: "0x01", ReattestCount: 3},
+		{SourceTxHash: "0xrequeue", Nonce: 2, Status: types.Failed},
+		{SourceTxHash: "0xrequeue", Nonce: 3, Status: types.Complete, Attestation: "0x02"},
+	}}
+	State.Store(tx.TxHash, tx)
+	State.Store("0xcomplete", &types.TxState{TxHash: "0xcomplete", Msgs: []*types.MessageState{{Status: types.Complete}}})
+	t.Cleanup(func() {
+		State.Delete("0xrequeue")
+		State.Delete("0xcomplete")
+	})
+
+	code := apiCall(t, http.MethodPost, server.URL+"/requeue/0xrequeue", "", nil)
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.Equal(t, types.Failed, tx.Msgs[0].Status)
+
+	code = apiCall(t, http.MethodPost, server.URL+"/requeue/0xunknown", "secret", nil)
+	require.Equal(t, http.StatusNotFound, code)
+
+	code = apiCall(t, http.MethodPost, server.URL+"/requeue/0xcomplete", "secret", nil)
+	require.Equal(t, http.StatusConflict, code)
+
+	code = apiCall(t, http.MethodPost, server.URL+"/requeue/0xrequeue", "secret", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, types.Attested, tx.Msgs[0].Status)
+	require.Zero(t, tx.Msgs[0].ReattestCount)
+	require.Equal(t, types.Created, tx.Msgs[1].Status)
+	require.Equal(t, types.Complete, tx.Msgs[2].Status)
+	require.Zero(t, tx.RetryAttempt)
+
+	require.Len(t, processingQueue, 1)
+	require.Equal(t, tx, <-processingQueue)
+}
+
+func TestVersionAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/version", getVersion)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	Version, Commit, Dirty, BuildDate = "1.2.3", "abc123", "0", "2024-01-02T03:04:05Z"
+	t.Cleanup(func() { Version, Commit, Dirty, BuildDate = "", "", "", "" })
+
+	var info versionInfo
+	code := apiCall(t, http.MethodGet, server.URL+"/version", "", &info)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "1.2.3", info.Version)
+	require.Equal(t, "abc123", info.Commit)
+	require.Equal(t, "2024-01-02T03:04:05Z", info.BuildDate)
+	require.NotEmpty(t, info.Go)
+}
+
+func TestDeadLetterAPI(t *testing.T) {
+	processingQueue := make(chan *types.TxState, 10)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/deadletter", getDeadLetters)
+	router.POST("/requeue/:txHash", requireAuthToken("secret"), requeueTx(log.NewNopLogger(), processingQueue))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	tx := &types.TxState{TxHash: "0xdead", RetryAttempt: 10, Msgs: []*types.MessageState{
+		{SourceTxHash: "0xdead", Nonce: 1, Status: types.Pending},
+		{SourceTxHash: "0xdead", Nonce: 2, Status: types.Complete},
+	}}
+	State.Store(tx.TxHash, tx)
+	t.Cleanup(func() {
+		State.Delete(tx.TxHash)
+		DeadLetters.Remove(tx.TxHash)
+	})
+
+	deadLetterTx(tx, tx.Msgs, errors.New("attestation is still processing"), nil)
+	require.Equal(t, types.Pending, tx.Msgs[0].Status)
+
+	var letters []types.DeadLetter
+	code := apiCall(t, http.MethodGet, server.URL+"/deadletter", "", &letters)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, letters, 1)
+	require.Equal(t, "0xdead", letters[0].TxHash)
+	require.Equal(t, "attestation is still processing", letters[0].LastError)
+	require.Equal(t, 10, letters[0].RetryAttempts)
+
+	code = apiCall(t, http.MethodPost, server.URL+"/requeue/0xdead", "secret", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, types.Pending, tx.Msgs[0].Status)
+	require.Zero(t, tx.RetryAttempt)
+	require.Len(t, processingQueue, 1)
+
+	// only dead-lettered or failed txs can be requeued
+	code = apiCall(t, http.MethodPost, server.URL+"/requeue/0xdead", "secret", nil)
+	require.Equal(t, http.StatusConflict, code)
+
+	code = apiCall(t, http.MethodGet, server.URL+"/deadletter", "", &letters)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, letters)
+}
+
+func TestFiltersAPI(t *testing.T) {
+	registry := types.NewFilterRegistry(log.NewNopLogger(), nil)
+	registry.Register(filters.NewMintRecipientFilter())
+	FilterRegistry = registry
+	t.Cleanup(func() { FilterRegistry = nil })
+
+	cfg := &types.Config{Filters: []types.FilterConfig{
+		{Name: "depositor-whitelist", Enabled: false},
+	}}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/filters", getFilters(func() *types.Config { return cfg }))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	var statuses []types.FilterStatus
+	code := apiCall(t, http.MethodGet, server.URL+"/filters", "", &statuses)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []types.FilterStatus{
+		{Name: "mint-recipient", Enabled: true},
+		{Name: "depositor-whitelist"},
+	}, statuses)
+}
+
+func TestCORSAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(cors([]string{"https://dashboard.example.com"}))
+	router.GET("/version", getVersion)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	request := func(method, origin string) *http.Response {
+		req, err := http.NewRequest(method, server.URL+"/version", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	resp := request(http.MethodGet, "https://dashboard.example.com")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "https://dashboard.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+
+	resp = request(http.MethodOptions, "https://dashboard.example.com")
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), "Authorization")
+
+	// other origins don't get CORS headers
+	resp = request(http.MethodGet, "https://evil.example.com")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestStateExportImportAPI(t *testing.T) {
+	processingQueue := make(chan *types.TxState, 10)
+	metrics := relayer.NewPromMetrics(prometheus.NewRegistry())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	admin := router.Group("/", requireAuthToken("secret"))
+	admin.GET("/state/export", exportState)
+	admin.POST("/state/import", importState(log.NewNopLogger(), processingQueue, metrics))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	State.Store("0xexported", &types.TxState{TxHash: "0xexported", Msgs: []*types.MessageState{{SourceTxHash: "0xexported", Status: types.Complete}}})
+	t.Cleanup(func() {
+		State.Delete("0xexported")
+		State.Delete("0xpending")
+		State.Delete("0xdone")
+	})
+
+	code := apiCall(t, http.MethodGet, server.URL+"/state/export", "", nil)
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	var exported []*types.TxState
+	code = apiCall(t, http.MethodGet, server.URL+"/state/export", "secret", &exported)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, exported, 1)
+	require.Equal(t, "0xexported", exported[0].TxHash)
+
+	body, err := json.Marshal([]*types.TxState{
+		exported[0],
+		{TxHash: "0xpending", Msgs: []*types.MessageState{{SourceTxHash: "0xpending", Status: types.Pending, DestDomain: 4, StuckPending: true}}},
+		{TxHash: "0xdone", Msgs: []*types.MessageState{{SourceTxHash: "0xdone", Status: types.Filtered}}},
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/state/import", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result map[string]int
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Equal(t, map[string]int{"imported": 2, "skipped": 1, "requeued": 1}, result)
+
+	tx, ok := State.Load("0xdone")
+	require.True(t, ok)
+	require.Equal(t, types.Filtered, tx.Msgs[0].Status)
+	require.Len(t, processingQueue, 1)
+	require.Equal(t, "0xpending", (<-processingQueue).TxHash)
+
+	// the imported pending message is counted, so leaving pending doesn't drive the gauges negative
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.AttestationPending.WithLabelValues("0", "4")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.StuckPending.WithLabelValues("0", "4")))
+}
+
+func TestReadyAPI(t *testing.T) {
+	t.Cleanup(func() { allowanceMonitor = nil })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ready", getReady)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	// ready without an allowance monitor
+	var r readiness
+	require.Equal(t, http.StatusOK, apiCall(t, http.MethodGet, server.URL+"/ready", "", &r))
+	require.True(t, r.Ready)
+
+	allowanceMonitor = circle.NewAllowanceMonitor(types.CircleSettings{AllowanceReadyTimeout: 60}, log.NewNopLogger(), []types.Domain{0}, nil)
+	require.Equal(t, http.StatusServiceUnavailable, apiCall(t, http.MethodGet, server.URL+"/ready", "", nil))
+
+	allowanceMonitor.State().Set(0, &types.FastTransferAllowance{})
+	require.Equal(t, http.StatusOK, apiCall(t, http.MethodGet, server.URL+"/ready", "", &r))
+	require.Equal(t, map[types.Domain]bool{0: true}, r.Allowances)
+}
+
+// chainStub reports fixed blocks, calling any other Chain method panics
+type chainStub struct {
+	types.Chain
+	name            string
+	latest, flushed uint64
+}
+
+func (c chainStub) Name() string             { return c.name }
+func (c chainStub) LatestBlock() uint64      { return c.latest }
+func (c chainStub) LastFlushedBlock() uint64 { return c.flushed }
+func (c chainStub) BlockTime() time.Duration { return 2 * time.Second }
+
+func TestChainsAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/chains", getChains(map[types.Domain]types.Chain{
+		4: chainStub{name: "noble", latest: 120, flushed: 100},
+		0: chainStub{name: "ethereum", latest: 50, flushed: 60},
+	}))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	var chains []chainStatus
+	require.Equal(t, http.StatusOK, apiCall(t, http.MethodGet, server.URL+"/chains", "", &chains))
+	require.Equal(t, []chainStatus{
+		{Name: "ethereum", Domain: 0, LatestBlock: 50, LastFlushedBlock: 60, BlockTimeSeconds: 2},
+		{Name: "noble", Domain: 4, LatestBlock: 120, LastFlushedBlock: 100, Lag: 20, BlockTimeSeconds: 2},
+	}, chains)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
 
@@ -10,11 +11,10 @@ import (
 
 	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
 	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/solana"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
-const nobleChainName = "noble"
-
 // appState is the modifiable state of the application.
 type AppState struct {
 	Config *types.Config
@@ -25,13 +25,28 @@ type AppState struct {
 
 	LogLevel string
 
+	// SkipReachabilityChecks skips connecting to each chain during pre-flight checks
+	SkipReachabilityChecks bool
+
 	Logger log.Logger
+
+	// snapshot of Config with the sections applied by the last reload, nil before the first reload
+	reloaded atomic.Pointer[types.Config]
 }
 
 func NewAppState() *AppState {
 	return &AppState{}
 }
 
+// CurrentConfig returns the running config, including the sections applied by the last reload. The returned
+// config is shared and must not be modified.
+func (a *AppState) CurrentConfig() *types.Config {
+	if cfg := a.reloaded.Load(); cfg != nil {
+		return cfg
+	}
+	return a.Config
+}
+
 // InitAppState checks if a logger and config are present. If not, it adds them to the AppState
 func (a *AppState) InitAppState() {
 	if a.Logger == nil {
@@ -39,6 +54,7 @@ func (a *AppState) InitAppState() {
 	}
 	if a.Config == nil {
 		a.loadConfigFile()
+		a.runPreflightChecks()
 	}
 }
 
@@ -87,39 +103,47 @@ func (a *AppState) loadConfigFile() {
 func (a *AppState) validateConfig() error {
 	// validate chains
 	for name, cfg := range a.Config.Chains {
-		// check if chain is noble
-		if name == nobleChainName {
-			// validate noble chain
-			cc := cfg.(*noble.ChainConfig)
-			err := a.validateChain(
+		_, chainType := cfg.DomainType()
+
+		var err error
+		switch cc := cfg.(type) {
+		case *noble.ChainConfig:
+			err = a.validateChain(
 				name,
 				cc.ChainID,
-				"",
+				chainType,
 				cc.RPC,
 				"",
 				cc.BroadcastRetries,
 				cc.BroadcastRetryInterval,
 				cc.MinMintAmount,
 			)
-			if err != nil {
-				return err
-			}
-		} else {
-			// validate eth based chains
-			cc := cfg.(*ethereum.ChainConfig)
-			err := a.validateChain(
+		case *solana.ChainConfig:
+			// solana has no chain id
+			err = a.validateChain(
+				name,
+				name,
+				chainType,
+				cc.RPC,
+				cc.WS,
+				cc.BroadcastRetries,
+				cc.BroadcastRetryInterval,
+				cc.MinMintAmount,
+			)
+		case *ethereum.ChainConfig:
+			err = a.validateChain(
 				name,
 				fmt.Sprintf("%d", cc.ChainID),
-				fmt.Sprintf("%d", cc.Domain),
+				chainType,
 				cc.RPC,
 				cc.WS,
 				cc.BroadcastRetries,
 				cc.BroadcastRetryInterval,
 				cc.MinMintAmount,
 			)
-			if err != nil {
-				return err
-			}
+		}
+		if err != nil {
+			return err
 		}
 	}
 
@@ -146,7 +170,7 @@ func (a *AppState) validateConfig() error {
 func (a *AppState) validateChain(
 	name string,
 	chainID string,
-	domain string,
+	chainType types.ChainType,
 	rpcURL string,
 	wsURL string,
 	broadcastRetries int,
@@ -161,17 +185,12 @@ func (a *AppState) validateChain(
 		return fmt.Errorf("chainID must be set in the config (chain: %s) (chainID: %s)", name, chainID)
 	}
 
-	// domain is hardcoded to 4 for noble chain
-	if domain == "" && name != nobleChainName {
-		return fmt.Errorf("domain must be set in the config (chain: %s) (domain: %s)", name, domain)
-	}
-
 	if rpcURL == "" {
 		return fmt.Errorf("rpcURL must be set in the config (chain: %s) (rpcURL: %s)", name, rpcURL)
 	}
 
-	// we do not use a websocket for noble
-	if wsURL == "" && name != nobleChainName {
+	// websockets are only used by evm chains
+	if wsURL == "" && chainType == types.ChainTypeEVM {
 		return fmt.Errorf("wsURL must be set in the config (chain: %s) (wsURL: %s)", name, wsURL)
 	}
 
@@ -183,9 +202,9 @@ func (a *AppState) validateChain(
 		return fmt.Errorf("broadcastRetryInterval must be greater than zero in the config (chain: %s) (broadcastRetryInterval: %d)", name, broadcastRetryInterval)
 	}
 
-	// noble has free minting
-	if minMintAmount == 0 && name != nobleChainName {
-		return fmt.Errorf("ETH-based chains must have a minMintAmount greater than zero in the config (chain: %s) (minMintAmount: %d)", name, minMintAmount)
+	// cosmos chains have free minting
+	if minMintAmount == 0 && chainType != types.ChainTypeCosmos {
+		return fmt.Errorf("non-cosmos chains must have a minMintAmount greater than zero in the config (chain: %s) (minMintAmount: %d)", name, minMintAmount)
 	}
 
 	return nil
@@ -201,5 +220,13 @@ func (a *AppState) validateCircleConfig() error {
 		return fmt.Errorf("FetchRetryInterval must be greater than zero in the config")
 	}
 
+	if a.Config.Circle.PendingPollInterval < 0 {
+		return fmt.Errorf("PendingPollInterval must not be negative in the config")
+	}
+
+	if a.Config.Circle.BroadcastRequeueWait < 0 {
+		return fmt.Errorf("BroadcastRequeueWait must not be negative in the config")
+	}
+
 	return nil
 }
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/notify"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestCheckAttestedMessages verifies a message whose bytes don't match the message Circle returned its attestation
+// for is failed instead of broadcast
+func TestCheckAttestedMessages(t *testing.T) {
+	msgBytes := []byte("message sent bytes")
+	otherBytes := []byte("bytes of another message")
+	lookupID := hex.EncodeToString(crypto.Keccak256(msgBytes))
+
+	matching := &types.MessageState{IrisLookupID: lookupID, MsgSentBytes: msgBytes, AttestedMessage: msgBytes, Status: types.Attested, Attestation: "0x01"}
+	// the attestation of another message of the same source tx
+	mismatched := &types.MessageState{IrisLookupID: lookupID, MsgSentBytes: msgBytes, AttestedMessage: otherBytes, Status: types.Attested, Attestation: "0x01"}
+
+	msgs := checkAttestedMessages(log.NewNopLogger(), []*types.MessageState{matching, mismatched}, nil)
+	require.Equal(t, []*types.MessageState{matching}, msgs)
+	require.Equal(t, types.Attested, matching.Status)
+	require.Equal(t, types.Failed, mismatched.Status)
+	require.Contains(t, mismatched.FailureReason, "attestation does not match message")
+	require.Contains(t, mismatched.FailureReason, "0x"+lookupID)
+}
+
+func signTestAttestation(t *testing.T, msgBytes []byte, key *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	sig, err := crypto.Sign(crypto.Keccak256(msgBytes), key)
+	require.NoError(t, err)
+	sig[64] += 27
+	return "0x" + hex.EncodeToString(sig)
+}
+
+// TestVerifyAttestations verifies a malformed attestation is failed with the reason instead of broadcast, while an
+// attestation signed by an attester outside the set is held and alerted on once
+func TestVerifyAttestations(t *testing.T) {
+	notifier := &recordingNotifier{}
+	Notifier = notifier
+	t.Cleanup(func() { Notifier = nil })
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	rotated, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	settings := types.CircleSettings{
+		AttesterAddresses: []string{crypto.PubkeyToAddress(key.PublicKey).Hex()},
+		AttesterThreshold: 1,
+	}
+	msgBytes := []byte("message sent bytes")
+
+	unsigned := &types.MessageState{MsgSentBytes: msgBytes, Status: types.Attested, Attestation: "0x01"}
+	verified, held := verifyAttestations(context.Background(), settings, log.NewNopLogger(), []*types.MessageState{unsigned}, nil)
+	require.Empty(t, verified)
+	require.False(t, held)
+	require.Equal(t, types.Failed, unsigned.Status)
+	require.Contains(t, unsigned.FailureReason, "attestation verification failed")
+
+	signed := &types.MessageState{MsgSentBytes: msgBytes, Status: types.Attested, Attestation: signTestAttestation(t, msgBytes, key)}
+	unknown := &types.MessageState{MsgSentBytes: msgBytes, Status: types.Attested, Attestation: signTestAttestation(t, msgBytes, rotated), Nonce: 7}
+	for i := 0; i < 2; i++ {
+		verified, held = verifyAttestations(context.Background(), settings, log.NewNopLogger(), []*types.MessageState{signed, unknown}, nil)
+		require.Equal(t, []*types.MessageState{signed}, verified)
+		require.True(t, held)
+	}
+	require.Equal(t, types.Attested, unknown.Status, "the message is held, not failed")
+	require.Contains(t, unknown.FailureReason, "unknown attester")
+	require.Len(t, notifier.events, 1)
+	require.Equal(t, notify.EventUnknownAttester, notifier.events[0].Type)
+	require.Equal(t, uint64(7), notifier.events[0].Nonce)
+
+	// the hold reason is cleared once the attester set is updated
+	settings.AttesterAddresses = append(settings.AttesterAddresses, crypto.PubkeyToAddress(rotated.PublicKey).Hex())
+	verified, held = verifyAttestations(context.Background(), settings, log.NewNopLogger(), []*types.MessageState{unknown}, nil)
+	require.Equal(t, []*types.MessageState{unknown}, verified)
+	require.False(t, held)
+	require.Empty(t, unknown.FailureReason)
+}
+
+// TestUnknownAttestationStatus verifies an unexpected attestation status is counted by its status and returns the
+// error the tx is requeued and eventually dead-lettered with
+func TestUnknownAttestationStatus(t *testing.T) {
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	msg := &types.MessageState{IrisLookupID: "abcd", SourceDomain: 0, DestDomain: 4, Status: types.Pending}
+
+	err := unknownAttestationStatus(log.NewNopLogger(), msg, "rejected", m)
+	require.ErrorContains(t, err, `unknown status "rejected"`)
+	require.ErrorContains(t, err, "0xabcd")
+	require.Equal(t, types.Pending, msg.Status, "the message is requeued, not failed")
+	require.Equal(t, 1.0, testutil.ToFloat64(m.AttestationUnknown.WithLabelValues("rejected", "0", "4")))
+
+	// long statuses are truncated in the label
+	long := strings.Repeat("x", 100)
+	_ = unknownAttestationStatus(log.NewNopLogger(), msg, long, m)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.AttestationUnknown.WithLabelValues(long[:maxUnknownStatusLength], "0", "4")))
+}
+
+// TestPrefetchAttestations verifies the attestations of a tx with several messages are checked concurrently, bounded
+// by attestationCheckConcurrency
+func TestPrefetchAttestations(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			highest := maxInFlight.Load()
+			if n <= highest || maxInFlight.CompareAndSwap(highest, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprint(w, `{"attestation":"0x01","status":"complete"}`)
+	}))
+	defer server.Close()
+
+	cfg := types.CircleSettings{AttestationBaseURL: server.URL, APIVersion: "v1"}
+	var msgs []*types.MessageState
+	for i := 0; i < 8; i++ {
+		msgs = append(msgs, &types.MessageState{IrisLookupID: fmt.Sprintf("%064x", 0xaa00+i), SourceTxHash: "0x01", Status: types.Created})
+	}
+	// attested messages aren't checked again
+	msgs = append(msgs, &types.MessageState{IrisLookupID: fmt.Sprintf("%064x", 0xab00), Status: types.Attested})
+	msgCtxs := make([]context.Context, len(msgs))
+	for i := range msgs {
+		msgCtxs[i] = context.Background()
+	}
+	// neither are messages dropped before the check, e.g. held by a filter
+	msgs = append(msgs, &types.MessageState{IrisLookupID: fmt.Sprintf("%064x", 0xac00), SourceTxHash: "0x01", Status: types.Created})
+	msgCtxs = append(msgCtxs, nil)
+
+	prefetched := prefetchAttestations(cfg, log.NewNopLogger(), msgs, msgCtxs)
+	require.Len(t, prefetched, 8)
+	for i := 0; i < 8; i++ {
+		require.NoError(t, prefetched[i].err)
+		require.Equal(t, "complete", prefetched[i].response.Status)
+	}
+	require.Greater(t, maxInFlight.Load(), int32(1))
+	require.LessOrEqual(t, maxInFlight.Load(), int32(attestationCheckConcurrency))
+
+	// a single check is made while processing the message
+	require.Nil(t, prefetchAttestations(cfg, log.NewNopLogger(), msgs[:1], msgCtxs[:1]))
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
 
 	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
 	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
@@ -22,7 +24,8 @@ func configShowCmd(a *AppState) *cobra.Command {
 		Aliases: []string{"sc"},
 		Short:   "Prints current configuration. By default it prints in yaml",
 		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
-			a.InitAppState()
+			// printing the config does not need private keys or reachable endpoints
+			a.loadConfigFile()
 		},
 		Example: strings.TrimSpace(fmt.Sprintf(`
 $ %s show-config --config %s
@@ -61,18 +64,42 @@ func ParseConfig(file string) (*types.Config, error) {
 		return nil, fmt.Errorf("failed to read file %w", err)
 	}
 
+	data, err = expandEnv(data)
+	if err != nil {
+		return nil, err
+	}
+
 	var cfg types.ConfigWrapper
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshalling config: %w", err)
 	}
 
 	c := types.Config{
-		EnabledRoutes:        cfg.EnabledRoutes,
-		Circle:               cfg.Circle,
-		Filters:              cfg.Filters,
-		ProcessorWorkerCount: cfg.ProcessorWorkerCount,
-		API:                  cfg.API,
-		Chains:               make(map[string]types.ChainConfig),
+		EnabledRoutes:           cfg.EnabledRoutes,
+		Circle:                  cfg.Circle,
+		Filters:                 cfg.Filters,
+		Notifications:           cfg.Notifications,
+		Tracing:                 cfg.Tracing,
+		Events:                  cfg.Events,
+		MinMintAmounts:          cfg.MinMintAmounts,
+		BroadcastConcurrency:    cfg.BroadcastConcurrency,
+		BroadcastRateLimits:     cfg.BroadcastRateLimits,
+		FilterOrder:             cfg.FilterOrder,
+		FilterEvaluateAll:       cfg.FilterEvaluateAll,
+		RouteFinalityThresholds: cfg.RouteFinalityThresholds,
+		DestinationCallerOnly:   cfg.DestinationCallerOnly,
+		ProcessorWorkerCount:    cfg.ProcessorWorkerCount,
+		ShutdownDrainTimeout:    cfg.ShutdownDrainTimeout,
+		EnqueueTimeout:          cfg.EnqueueTimeout,
+		StateRetention:          cfg.StateRetention,
+		StateSweepInterval:      cfg.StateSweepInterval,
+		StateArchiveSize:        cfg.StateArchiveSize,
+		UsedNonceCacheSize:      cfg.UsedNonceCacheSize,
+		SequenceFile:            cfg.SequenceFile,
+		CheckpointFile:          cfg.CheckpointFile,
+		TokenExponents:          cfg.TokenExponents,
+		API:                     cfg.API,
+		Chains:                  make(map[string]types.ChainConfig),
 	}
 
 	for name, chain := range cfg.Chains {
@@ -81,26 +108,111 @@ func ParseConfig(file string) (*types.Config, error) {
 			return nil, err
 		}
 
-		switch name {
-		case "noble":
+		chainType, err := configChainType(name, chain)
+		if err != nil {
+			return nil, err
+		}
+
+		switch chainType {
+		case types.ChainTypeCosmos:
 			var cc noble.ChainConfig
 			if err := yaml.Unmarshal(yamlbz, &cc); err != nil {
 				return nil, err
 			}
+			cc.ChainType = chainType
 			c.Chains[name] = &cc
-		case "solana":
+		case types.ChainTypeSolana:
 			var cc solana.ChainConfig
 			if err := yaml.Unmarshal(yamlbz, &cc); err != nil {
 				return nil, err
 			}
+			cc.ChainType = chainType
 			c.Chains[name] = &cc
 		default:
 			var cc ethereum.ChainConfig
 			if err := yaml.Unmarshal(yamlbz, &cc); err != nil {
 				return nil, err
 			}
+			cc.ChainType = chainType
 			c.Chains[name] = &cc
 		}
 	}
 	return &c, err
 }
+
+// envReference matches a ${VAR} environment variable reference in the config file
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces every ${VAR} in the values of the config file with the value of the environment variable VAR.
+// Values are expanded after the file is parsed, so a value can't change the structure of the config and references
+// in comments and keys are left alone. A reference to an unset variable is an error, an empty variable expands to an
+// empty string.
+func expandEnv(data []byte) ([]byte, error) {
+	var doc yaml3.Node
+	if err := yaml3.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error unmarshalling config: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return data, nil
+	}
+
+	var unset []string
+	expandEnvNode(&doc, &unset)
+	if len(unset) > 0 {
+		return nil, fmt.Errorf("config references unset environment variables: %s", strings.Join(unset, ", "))
+	}
+	return yaml3.Marshal(&doc)
+}
+
+// expandEnvNode expands the environment variable references in the scalar values under node, recording the unset
+// variables in unset
+func expandEnvNode(node *yaml3.Node, unset *[]string) {
+	switch node.Kind {
+	case yaml3.DocumentNode, yaml3.SequenceNode:
+		for _, child := range node.Content {
+			expandEnvNode(child, unset)
+		}
+	case yaml3.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			expandEnvNode(node.Content[i], unset)
+		}
+	case yaml3.ScalarNode:
+		if !envReference.MatchString(node.Value) {
+			return
+		}
+		node.Value = envReference.ReplaceAllStringFunc(node.Value, func(reference string) string {
+			name := envReference.FindStringSubmatch(reference)[1]
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				*unset = append(*unset, name)
+			}
+			return value
+		})
+		// an unquoted value is typed by what it expands to, e.g. a number, a quoted value stays a string
+		if node.Style == 0 {
+			node.Tag = ""
+		}
+	}
+}
+
+// configChainType returns the chain type of a chain config. Configs without a chain-type are
+// typed by name: noble is cosmos, solana is solana and any other chain is evm.
+func configChainType(name string, chain map[string]any) (types.ChainType, error) {
+	raw, _ := chain["chain-type"].(string)
+	chainType, err := types.ParseChainType(raw)
+	if err != nil {
+		return "", fmt.Errorf("chain %s: %w", name, err)
+	}
+	if chainType != "" {
+		return chainType, nil
+	}
+
+	switch name {
+	case "noble":
+		return types.ChainTypeCosmos, nil
+	case "solana":
+		return types.ChainTypeSolana, nil
+	default:
+		return types.ChainTypeEVM, nil
+	}
+}
@@ -1,6 +1,8 @@
 package cmd_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +10,7 @@ import (
 	"github.com/strangelove-ventures/noble-cctp-relayer/cmd"
 	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
 	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
 func TestConfig(t *testing.T) {
@@ -38,3 +41,80 @@ func TestBlockQueueChannelSize(t *testing.T) {
 
 	require.Equal(t, expected, n.BlockQueueChannelSize)
 }
+
+func TestConfigChainTypes(t *testing.T) {
+	file, err := cmd.ParseConfig("../config/sample-config.yaml")
+	require.NoError(t, err, "Error parsing config")
+
+	domainTypes := file.DomainTypes()
+	require.Equal(t, types.ChainTypeCosmos, domainTypes[4])
+	require.Equal(t, types.ChainTypeEVM, domainTypes[0])
+	require.Equal(t, types.ChainTypeEVM, domainTypes[2])
+	require.Equal(t, types.ChainTypeSolana, domainTypes[5])
+}
+
+func TestConfigChainTypeOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+chains:
+  noble-testnet:
+    chain-type: COSMOS
+    domain: 104
+  custom:
+    chain-type: bogus
+`), 0o600))
+
+	_, err := cmd.ParseConfig(path)
+	require.ErrorContains(t, err, "invalid chain type")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+chains:
+  noble-testnet:
+    chain-type: COSMOS
+    domain: 104
+`), 0o600))
+
+	file, err := cmd.ParseConfig(path)
+	require.NoError(t, err)
+
+	nobleCfg, ok := file.Chains["noble-testnet"].(*noble.ChainConfig)
+	require.True(t, ok)
+	domain, chainType := nobleCfg.DomainType()
+	require.Equal(t, types.Domain(104), domain)
+	require.Equal(t, types.ChainTypeCosmos, chainType)
+}
+
+func TestConfigEnvExpansion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+chains:
+  ethereum:
+    rpc: https://eth-mainnet.g.alchemy.com/v2/${TEST_ALCHEMY_KEY}
+    minter-private-key: "${TEST_ETH_PRIV_KEY}"
+    metrics-denom: ${TEST_DENOM} # ${TEST_UNSET_IN_COMMENT}
+circle:
+  attestation-base-url: ${TEST_ATTESTATION_URL}
+  fetch-retries: ${TEST_FETCH_RETRIES}
+`), 0o600))
+
+	t.Setenv("TEST_ALCHEMY_KEY", "secret")
+	t.Setenv("TEST_ETH_PRIV_KEY", "0x01")
+	// values are expanded after parsing, YAML special characters don't need quoting
+	t.Setenv("TEST_DENOM", "usdc: #cctp")
+	t.Setenv("TEST_FETCH_RETRIES", "12")
+
+	_, err := cmd.ParseConfig(path)
+	require.ErrorContains(t, err, "unset environment variables: TEST_ATTESTATION_URL")
+
+	t.Setenv("TEST_ATTESTATION_URL", "https://iris-api.circle.com")
+	file, err := cmd.ParseConfig(path)
+	require.NoError(t, err)
+
+	ethCfg, ok := file.Chains["ethereum"].(*ethereum.ChainConfig)
+	require.True(t, ok)
+	require.Equal(t, "https://eth-mainnet.g.alchemy.com/v2/secret", ethCfg.RPC)
+	require.Equal(t, "0x01", ethCfg.MinterPrivateKey)
+	require.Equal(t, "usdc: #cctp", ethCfg.MetricsDenom)
+	require.Equal(t, "https://iris-api.circle.com", file.Circle.AttestationBaseURL)
+	require.Equal(t, 12, file.Circle.FetchRetries)
+}
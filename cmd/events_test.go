@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/events"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+type recordingPublisher struct {
+	events []events.Event
+}
+
+func (r *recordingPublisher) Publish(event events.Event) { r.events = append(r.events, event) }
+
+func (r *recordingPublisher) Close() error { return nil }
+
+func TestPublishTransitions(t *testing.T) {
+	publisher := &recordingPublisher{}
+	Publisher = publisher
+	t.Cleanup(func() { Publisher = events.NopPublisher{} })
+
+	msgs := []*types.MessageState{
+		{IrisLookupID: "relayed", Status: types.Complete, Attestation: "0x01", DestTxHash: "0xdest", Nonce: 1},
+		{IrisLookupID: "unchanged", Status: types.Pending, Nonce: 2},
+		{IrisLookupID: "pending", Status: types.Pending, Nonce: 3},
+		{IrisLookupID: "filtered", Status: types.Filtered, FilterReason: "min-amount", Nonce: 4},
+		{IrisLookupID: "already-minted", Status: types.AlreadyMinted, Attestation: "0x02", Nonce: 5},
+	}
+	publishTransitions(msgs, []string{types.Created, types.Pending, types.Created, types.Created, types.Pending})
+
+	require.Len(t, publisher.events, 5)
+
+	// relayed in one pass, the attested stage is published before completion
+	require.Equal(t, types.Attested, publisher.events[0].Status)
+	require.Equal(t, types.Created, publisher.events[0].PreviousStatus)
+	require.Empty(t, publisher.events[0].DestTxHash)
+	require.Equal(t, types.Complete, publisher.events[1].Status)
+	require.Equal(t, types.Attested, publisher.events[1].PreviousStatus)
+	require.Equal(t, "0xdest", publisher.events[1].DestTxHash)
+
+	require.Equal(t, types.Filtered, publisher.events[2].Status)
+	require.Equal(t, "min-amount", publisher.events[2].Reason)
+
+	// minted by someone else, reported separately from our completed relays
+	require.Equal(t, types.Attested, publisher.events[3].Status)
+	require.Equal(t, types.AlreadyMinted, publisher.events[4].Status)
+	require.Equal(t, types.Attested, publisher.events[4].PreviousStatus)
+}
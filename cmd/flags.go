@@ -7,14 +7,20 @@ import (
 )
 
 const (
-	flagConfigPath     = "config"
-	flagVerbose        = "verbose"
-	flagLogLevel       = "log-level"
-	flagJSON           = "json"
-	flagMetricsAddress = "metrics-address"
-	flagMetricsPort    = "metrics-port"
-	flagFlushInterval  = "flush-interval"
-	flagFlushOnlyMode  = "flush-only-mode"
+	flagConfigPath       = "config"
+	flagVerbose          = "verbose"
+	flagLogLevel         = "log-level"
+	flagJSON             = "json"
+	flagMetricsAddress   = "metrics-address"
+	flagMetricsPort      = "metrics-port"
+	flagFlushInterval    = "flush-interval"
+	flagFlushOnlyMode    = "flush-only-mode"
+	flagSourceDomain     = "source-domain"
+	flagTxHash           = "tx-hash"
+	flagAPIURL           = "api-url"
+	flagDomain           = "domain"
+	flagSkipReachability = "skip-reachability-checks"
+	flagDryRun           = "dry-run"
 )
 
 func addAppPersistantFlags(cmd *cobra.Command, a *AppState) *cobra.Command {
@@ -25,6 +31,8 @@ func addAppPersistantFlags(cmd *cobra.Command, a *AppState) *cobra.Command {
 	cmd.PersistentFlags().Int16P(flagMetricsPort, "p", 2112, "customize Prometheus metrics port")
 	cmd.PersistentFlags().DurationP(flagFlushInterval, "i", 0, "how frequently should a flush routine be run")
 	cmd.PersistentFlags().BoolP(flagFlushOnlyMode, "f", false, "only run the background flush routine (acts as a redundant relayer)")
+	cmd.PersistentFlags().Bool(flagDryRun, false, "run the full pipeline but log the mints that would be broadcast instead of broadcasting them")
+	cmd.PersistentFlags().BoolVar(&a.SkipReachabilityChecks, flagSkipReachability, false, "skip checking that each chain's RPC/WS endpoints are reachable before starting")
 	return cmd
 }
 
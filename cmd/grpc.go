@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net"
+	"os"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cosmossdk.io/log"
+
+	relayerv1 "github.com/strangelove-ventures/noble-cctp-relayer/api/relayer/v1"
+	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var _ relayerv1.RelayerServiceServer = (*grpcServer)(nil)
+
+// grpcServer serves the gRPC API from the same state as the REST API
+type grpcServer struct {
+	relayerv1.UnimplementedRelayerServiceServer
+
+	logger          log.Logger
+	authToken       string
+	processingQueue chan *types.TxState
+	allowances      *circle.AllowanceMonitor // nil if Fast Transfer allowance monitoring is disabled
+}
+
+// startGRPC serves the gRPC API on the api listen host at api.grpc-port, over TLS if the api tls cert and key are set
+func startGRPC(a *AppState, processingQueue chan *types.TxState, allowances *circle.AllowanceMonitor) {
+	logger := a.Logger
+	cfg := a.Config
+
+	var opts []grpc.ServerOption
+	if cfg.API.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.API.TLSCertFile, cfg.API.TLSKeyFile)
+		if err != nil {
+			logger.Error("Unable to load gRPC API TLS key pair: " + err.Error())
+			os.Exit(1)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	listener, err := net.Listen("tcp", cfg.GRPCListenAddress())
+	if err != nil {
+		logger.Error("Unable to start gRPC API server: " + err.Error())
+		os.Exit(1)
+	}
+
+	server := grpc.NewServer(opts...)
+	relayerv1.RegisterRelayerServiceServer(server, &grpcServer{
+		logger:          logger,
+		authToken:       cfg.API.AuthToken,
+		processingQueue: processingQueue,
+		allowances:      allowances,
+	})
+	if err := server.Serve(listener); err != nil {
+		logger.Error("Unable to start gRPC API server: " + err.Error())
+		os.Exit(1)
+	}
+}
+
+func (s *grpcServer) GetTx(_ context.Context, req *relayerv1.GetTxRequest) (*relayerv1.GetTxResponse, error) {
+	tx, ok := State.Load(req.TxHash)
+	if !ok {
+		tx, ok = Archive.Load(req.TxHash)
+	}
+	if !ok || len(tx.Msgs) == 0 {
+		return nil, status.Error(codes.NotFound, "message not found")
+	}
+
+	State.Mu.Lock()
+	defer State.Mu.Unlock()
+	if req.Domain != nil && tx.Msgs[0].SourceDomain != types.Domain(*req.Domain) {
+		return nil, status.Error(codes.NotFound, "message not found")
+	}
+	return &relayerv1.GetTxResponse{Messages: toProtoMessages(tx.Msgs)}, nil
+}
+
+func (s *grpcServer) ListMessages(_ context.Context, req *relayerv1.ListMessagesRequest) (*relayerv1.ListMessagesResponse, error) {
+	var msgs []*types.MessageState
+	State.Range(func(_ string, tx *types.TxState) bool {
+		for _, msg := range tx.Msgs {
+			if req.Status != "" && msg.Status != req.Status {
+				continue
+			}
+			if req.Domain != nil && msg.SourceDomain != types.Domain(*req.Domain) {
+				continue
+			}
+			msgs = append(msgs, msg)
+		}
+		return true
+	})
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Created.Before(msgs[j].Created) })
+
+	State.Mu.Lock()
+	defer State.Mu.Unlock()
+	return &relayerv1.ListMessagesResponse{Messages: toProtoMessages(msgs)}, nil
+}
+
+func (s *grpcServer) GetAllowance(_ context.Context, req *relayerv1.GetAllowanceRequest) (*relayerv1.GetAllowanceResponse, error) {
+	if s.allowances == nil {
+		return nil, status.Error(codes.FailedPrecondition, "fast transfer allowance monitoring is disabled")
+	}
+
+	allowance := s.allowances.State().Get(types.Domain(req.Domain))
+	if allowance == nil {
+		return nil, status.Errorf(codes.NotFound, "no allowance fetched for domain %d", req.Domain)
+	}
+	return &relayerv1.GetAllowanceResponse{
+		SourceDomain: req.Domain,
+		Token:        allowance.Token,
+		Allowance:    allowance.Allowance.String(),
+		MaxAllowance: allowance.MaxAllowance.String(),
+	}, nil
+}
+
+func (s *grpcServer) Requeue(ctx context.Context, req *relayerv1.RequeueRequest) (*relayerv1.RequeueResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, requeued, deadLettered, err := requeueFailedTx(s.processingQueue, req.TxHash)
+	switch {
+	case errors.Is(err, errTxNotFound):
+		return nil, status.Error(codes.NotFound, "tx not found")
+	case errors.Is(err, errNoFailedMsgs):
+		return nil, status.Error(codes.FailedPrecondition, "tx has no failed messages")
+	}
+	s.logger.Info("Failed tx requeued through the gRPC API", "tx", req.TxHash, "msgs", requeued, "dead_lettered", deadLettered)
+
+	State.Mu.Lock()
+	defer State.Mu.Unlock()
+	return &relayerv1.RequeueResponse{Messages: toProtoMessages(tx.Msgs)}, nil
+}
+
+// authorize rejects requests without the configured bearer token in the authorization metadata, like
+// requireAuthToken. Without a token the endpoints are disabled.
+func (s *grpcServer) authorize(ctx context.Context) error {
+	if s.authToken == "" {
+		return status.Error(codes.PermissionDenied, "set api.auth-token to enable this endpoint")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if subtle.ConstantTimeCompare([]byte(value), []byte("Bearer "+s.authToken)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid auth token")
+}
+
+// toProtoMessages converts the messages to their gRPC representation, must be called with the state locked
+func toProtoMessages(msgs []*types.MessageState) []*relayerv1.Message {
+	converted := make([]*relayerv1.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		converted = append(converted, &relayerv1.Message{
+			IrisLookupId:              msg.IrisLookupID,
+			Status:                    msg.Status,
+			FilteredBy:                msg.FilteredBy,
+			FilterReason:              msg.FilterReason,
+			FailureReason:             msg.FailureReason,
+			Attestation:               msg.Attestation,
+			SourceDomain:              uint32(msg.SourceDomain),
+			DestDomain:                uint32(msg.DestDomain),
+			SourceTxHash:              msg.SourceTxHash,
+			DestTxHash:                msg.DestTxHash,
+			MsgSentBytes:              msg.MsgSentBytes,
+			MsgBody:                   msg.MsgBody,
+			DestinationCaller:         msg.DestinationCaller,
+			Channel:                   msg.Channel,
+			Created:                   timestamppb.New(msg.Created),
+			Updated:                   timestamppb.New(msg.Updated),
+			Nonce:                     msg.Nonce,
+			CctpVersion:               msg.CctpVersion,
+			ExpirationBlock:           msg.ExpirationBlock,
+			FinalityThreshold:         msg.FinalityThreshold,
+			ReattestCount:             uint32(msg.ReattestCount),
+			RequiredFinalityThreshold: msg.RequiredFinalityThreshold,
+			StuckPending:              msg.StuckPending,
+			DestConfirmed:             msg.DestConfirmed,
+			DestError:                 msg.DestError,
+			DestBlock:                 msg.DestBlock,
+			Amount:                    msg.Amount,
+			AmountFormatted:           msg.AmountFormatted,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"cosmossdk.io/log"
+
+	relayerv1 "github.com/strangelove-ventures/noble-cctp-relayer/api/relayer/v1"
+	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func newTestGRPCClient(t *testing.T, s *grpcServer) relayerv1.RelayerServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	relayerv1.RegisterRelayerServiceServer(server, s)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return relayerv1.NewRelayerServiceClient(conn)
+}
+
+func TestGRPCAPI(t *testing.T) {
+	processingQueue := make(chan *types.TxState, 10)
+	allowances := circle.NewAllowanceMonitor(types.CircleSettings{}, log.NewNopLogger(), nil, nil)
+	allowances.State().Set(0, &types.FastTransferAllowance{SourceDomain: "0", Token: "USDC", Allowance: json.Number("100"), MaxAllowance: json.Number("1000")})
+	client := newTestGRPCClient(t, &grpcServer{
+		logger:          log.NewNopLogger(),
+		authToken:       "secret",
+		processingQueue: processingQueue,
+		allowances:      allowances,
+	})
+	ctx := context.Background()
+
+	now := time.Now()
+	State.Store("0xgrpc", &types.TxState{TxHash: "0xgrpc", Msgs: []*types.MessageState{
+		{SourceTxHash: "0xgrpc", SourceDomain: 0, DestDomain: 4, Nonce: 1, Status: types.Failed, Created: now},
+		{SourceTxHash: "0xgrpc", SourceDomain: 0, DestDomain: 4, Nonce: 2, Status: types.Complete, Created: now.Add(time.Second)},
+	}})
+	t.Cleanup(func() { State.Delete("0xgrpc") })
+
+	tx, err := client.GetTx(ctx, &relayerv1.GetTxRequest{TxHash: "0xgrpc"})
+	require.NoError(t, err)
+	require.Len(t, tx.Messages, 2)
+	require.Equal(t, uint64(1), tx.Messages[0].Nonce)
+	require.Equal(t, uint32(4), tx.Messages[0].DestDomain)
+
+	wrongDomain := uint32(5)
+	_, err = client.GetTx(ctx, &relayerv1.GetTxRequest{TxHash: "0xgrpc", Domain: &wrongDomain})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	msgs, err := client.ListMessages(ctx, &relayerv1.ListMessagesRequest{Status: types.Complete})
+	require.NoError(t, err)
+	require.Len(t, msgs.Messages, 1)
+	require.Equal(t, uint64(2), msgs.Messages[0].Nonce)
+
+	allowance, err := client.GetAllowance(ctx, &relayerv1.GetAllowanceRequest{Domain: 0})
+	require.NoError(t, err)
+	require.Equal(t, "100", allowance.Allowance)
+	require.Equal(t, "1000", allowance.MaxAllowance)
+	_, err = client.GetAllowance(ctx, &relayerv1.GetAllowanceRequest{Domain: 6})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Requeue(ctx, &relayerv1.RequeueRequest{TxHash: "0xgrpc"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	requeued, err := client.Requeue(authCtx, &relayerv1.RequeueRequest{TxHash: "0xgrpc"})
+	require.NoError(t, err)
+	require.Equal(t, types.Created, requeued.Messages[0].Status)
+	require.Len(t, processingQueue, 1)
+
+	_, err = client.Requeue(authCtx, &relayerv1.RequeueRequest{TxHash: "0xgrpc"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -2,10 +2,17 @@ package cmd
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,8 +21,11 @@ import (
 	"cosmossdk.io/log"
 
 	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
+	"github.com/strangelove-ventures/noble-cctp-relayer/events"
 	"github.com/strangelove-ventures/noble-cctp-relayer/filters"
+	"github.com/strangelove-ventures/noble-cctp-relayer/notify"
 	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/tracing"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
@@ -30,6 +40,36 @@ var sequenceMap = types.NewSequenceMap()
 // FilterRegistry holds all registered message filters
 var FilterRegistry *types.FilterRegistry
 
+// Notifier pushes relay lifecycle events to external systems, nil if notifications are disabled
+var Notifier notify.Notifier
+
+// Publisher publishes relay lifecycle events to a message bus
+var Publisher events.Publisher = events.NopPublisher{}
+
+// criticalMonitor tracks conditions that page on-call, nil if notifications are disabled
+var criticalMonitor *notify.CriticalMonitor
+
+// Pause records the routes broadcasting is paused on through the API
+var Pause = types.NewPauseState()
+
+// DeadLetters holds the txs that exhausted their retries until they are requeued through the API
+var DeadLetters = types.NewDeadLetterStore()
+
+// broadcastLimiter bounds the concurrent broadcasts to each destination domain across processor workers
+var broadcastLimiter = types.NewBroadcastLimiter(nil)
+
+// broadcastRateLimiter caps the broadcast throughput globally and to each destination domain
+var broadcastRateLimiter = types.NewBroadcastRateLimiter(types.BroadcastRateLimits{})
+
+// dryRun replaces broadcasts with logging the mints that would have been broadcast
+var dryRun bool
+
+// allowanceMonitor tracks the Fast Transfer allowance of each domain, nil if monitoring is disabled
+var allowanceMonitor *circle.AllowanceMonitor
+
+// inFlight holds the iris lookup ids of messages currently being handled by a processor worker
+var inFlight = types.NewInFlightSet()
+
 func Start(a *AppState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "start",
@@ -52,6 +92,14 @@ func Start(a *AppState) *cobra.Command {
 				return fmt.Errorf("invalid flush only flag error=%w", err)
 			}
 
+			dryRun, err = cmd.Flags().GetBool(flagDryRun)
+			if err != nil {
+				return fmt.Errorf("invalid dry run flag error=%w", err)
+			}
+			if dryRun {
+				logger.Info("Dry run: attested mints are logged and marked simulated-complete instead of broadcast")
+			}
+
 			if flushInterval == 0 {
 				if flushOnly {
 					return fmt.Errorf("flush only mode requires a flush interval")
@@ -60,14 +108,9 @@ func Start(a *AppState) *cobra.Command {
 				}
 			}
 
-			// start API on normal relayer only
-			go startAPI(a)
-
 			// messageState processing queue
 			var processingQueue = make(chan *types.TxState, 10000)
 
-			registeredDomains := make(map[types.Domain]types.Chain)
-
 			port, err := cmd.Flags().GetInt16(flagMetricsPort)
 			if err != nil {
 				return fmt.Errorf("invalid port error=%w", err)
@@ -79,47 +122,53 @@ func Start(a *AppState) *cobra.Command {
 			}
 
 			metrics := relayer.InitPromMetrics(address, port)
+			circle.SetMetrics(metrics)
+			circle.ConfigureCircuitBreaker(cfg.Circle)
+			circle.ConfigureAttestationCache(cfg.Circle)
+			types.ConfigureEnqueue(cfg.EnqueueTimeout, metrics)
+			go types.RetryHeld(cmd.Context(), logger)
+			broadcastLimiter = types.NewBroadcastLimiter(cfg.BroadcastConcurrency)
+			broadcastRateLimiter = types.NewBroadcastRateLimiter(cfg.BroadcastRateLimits)
+
+			registeredDomains, err := initializeChains(cmd.Context(), a, metrics)
+			if err != nil {
+				return err
+			}
 
-			for name, cfg := range cfg.Chains {
-				c, err := cfg.Chain(name)
+			sequenceDomains := cosmosDomains(cfg)
+			if cfg.SequenceFile != "" {
+				raised, err := sequenceMap.Reconcile(cfg.SequenceFile, sequenceDomains)
 				if err != nil {
-					return fmt.Errorf("error creating chain error=%w", err)
+					return err
 				}
+				logger.Info("Reconciled persisted minter account sequences", "file", cfg.SequenceFile, "raised", raised)
+				go persistSequences(cmd.Context(), logger, cfg.SequenceFile, sequenceDomains)
+			}
 
-				logger = logger.With("name", c.Name(), "domain", c.Domain())
-
-				if err := c.InitializeClients(cmd.Context(), logger); err != nil {
-					return fmt.Errorf("error initializing client error=%w", err)
+			// a dry run doesn't relay the blocks it scans, don't let a later run resume past them
+			if cfg.CheckpointFile != "" && !dryRun {
+				checkpoints, err := types.LoadCheckpoints(cfg.CheckpointFile)
+				if err != nil {
+					return err
 				}
-
-				go c.TrackLatestBlockHeight(cmd.Context(), logger, metrics)
-
-				// wait until height is available
-				maxRetries := 45
-				for i := 0; i < maxRetries; i++ {
-					if c.LatestBlock() == 0 {
-						time.Sleep(1 * time.Second)
-					} else {
-						break
-					}
-					if i == maxRetries-1 {
-						return fmt.Errorf("unable to get height")
+				for domain, c := range registeredDomains {
+					resumer, ok := c.(types.Resumer)
+					if !ok || checkpoints[domain] == 0 {
+						continue
 					}
+					resumer.Resume(checkpoints[domain])
+					logger.Info("Backfilling from persisted checkpoint", "chain", c.Name(), "block", checkpoints[domain])
 				}
+				go persistCheckpoints(cmd.Context(), logger, cfg.CheckpointFile, registeredDomains)
+			}
 
-				if err := c.InitializeBroadcaster(cmd.Context(), logger, sequenceMap); err != nil {
-					return fmt.Errorf("error initializing broadcaster error=%w", err)
-				}
+			// start API on normal relayer only
+			go startAPI(a, registeredDomains, processingQueue, metrics)
 
-				go c.StartListener(cmd.Context(), logger, processingQueue, flushOnly, flushInterval)
+			for _, c := range registeredDomains {
+				go c.StartListener(cmd.Context(), logger.With("name", c.Name(), "domain", c.Domain()), processingQueue, flushOnly, flushInterval, metrics)
 
 				go c.WalletBalanceMetric(cmd.Context(), a.Logger, metrics)
-
-				if _, ok := registeredDomains[c.Domain()]; ok {
-					return fmt.Errorf("duplicate domain found domain=%d name=%s", c.Domain(), c.Name())
-				}
-
-				registeredDomains[c.Domain()] = c
 			}
 
 			// Start Fast Transfer allowance monitor (v2 only)
@@ -127,20 +176,83 @@ func Start(a *AppState) *cobra.Command {
 			for domain := range registeredDomains {
 				domains = append(domains, domain)
 			}
-			circle.StartAllowanceMonitor(cmd.Context(), cfg.Circle, logger, domains, metrics)
+			allowanceMonitor = circle.StartAllowanceMonitor(cmd.Context(), cfg.Circle, logger, domains, metrics)
+			if cfg.API.GRPCPort != 0 {
+				go startGRPC(a, processingQueue, allowanceMonitor)
+			}
+
+			if cfg.Circle.VerifiesAttestations() {
+				if err := circle.ValidateAttesterSet(cfg.Circle.AttesterAddresses, cfg.Circle.AttesterThreshold); err != nil {
+					return fmt.Errorf("invalid attestation verification config: %w", err)
+				}
+			}
 
-			if err := initializeFilters(cmd.Context(), cfg, logger, registeredDomains); err != nil {
+			if err := initializeFilters(cmd.Context(), cfg, logger, registeredDomains, metrics); err != nil {
 				return fmt.Errorf("failed to initialize filters: %w", err)
 			}
+			go a.watchConfigReload(cmd.Context(), registeredDomains)
+
+			archiveSize := defaultStateArchiveSize
+			if cfg.StateArchiveSize > 0 {
+				archiveSize = int(cfg.StateArchiveSize)
+			}
+			Archive = types.NewStateArchive(archiveSize)
+			usedNonceCacheSize := types.DefaultUsedNonceCacheSize
+			if cfg.UsedNonceCacheSize > 0 {
+				usedNonceCacheSize = int(cfg.UsedNonceCacheSize)
+			}
+			types.UsedNonces = types.NewUsedNonceCache(usedNonceCacheSize)
+			go startStateSweeper(cmd.Context(), cfg, logger, metrics)
+
+			Notifier = notify.New(cfg.Notifications, logger)
+			tracer := tracing.Init(cmd.Context(), cfg.Tracing, logger)
+			publisher, err := events.New(cfg.Events, logger)
+			if err != nil {
+				return fmt.Errorf("failed to initialize event publisher: %w", err)
+			}
+			Publisher = publisher
+			notify.StartBalanceMonitor(cmd.Context(), cfg.Notifications, Notifier, registeredDomains, logger)
+			criticalMonitor = notify.StartCriticalMonitor(cmd.Context(), cfg.Notifications.Critical, Notifier, logger)
+
+			// processors outlive the command context so they can drain the queue on shutdown
+			processorCtx, cancelProcessors := context.WithCancel(context.Background())
+			defer cancelProcessors()
 
 			// spin up Processor worker pool
 			for i := 0; i < int(cfg.ProcessorWorkerCount); i++ {
-				go StartProcessor(cmd.Context(), a, registeredDomains, processingQueue, sequenceMap, metrics)
+				go StartProcessor(processorCtx, a, registeredDomains, processingQueue, sequenceMap, metrics)
 			}
 
 			// wait for context to be done
 			<-cmd.Context().Done()
 
+			// listeners stop on the cancelled command context, let processors finish the queued work
+			drainTimeout := defaultShutdownDrainTimeout
+			if cfg.ShutdownDrainTimeout > 0 {
+				drainTimeout = time.Duration(cfg.ShutdownDrainTimeout) * time.Second
+			}
+			logger.Info("Draining processing queue", "outstanding_txs", outstandingTxs(processingQueue), "timeout", drainTimeout)
+			startDrain()
+			drained, abandoned := drainQueues(processingQueue, drainTimeout)
+			logger.Info("Processing queue drained", "drained_txs", drained, "abandoned_txs", abandoned)
+			cancelProcessors()
+			tracer.Shutdown(context.Background())
+			if err := Publisher.Close(); err != nil {
+				logger.Error("Error closing event publisher", "error", err)
+			}
+
+			if cfg.SequenceFile != "" {
+				if err := sequenceMap.Save(cfg.SequenceFile, sequenceDomains); err != nil {
+					logger.Error("Error saving minter account sequences", "error", err)
+				}
+			}
+
+			if cfg.CheckpointFile != "" && !dryRun {
+				if err := types.SaveCheckpoints(cfg.CheckpointFile, registeredDomains); err != nil {
+					logger.Error("Error saving chain checkpoints", "error", err)
+				}
+			}
+
 			// close clients & output latest block heights
 			for _, c := range registeredDomains {
 				logger.Info(fmt.Sprintf("%s: latest-block: %d last-flushed-block: %d", c.Name(), c.LatestBlock(), c.LastFlushedBlock()))
@@ -176,7 +288,18 @@ func StartProcessor(
 	cfg := a.Config
 
 	for {
-		dequeuedTx := <-processingQueue
+		dequeuedTx, ok := nextTx(ctx, processingQueue)
+		if !ok {
+			return
+		}
+		activeTxs.Add(1)
+
+		// txs swept into the archive were already relayed, skip them if re-emitted by a flush
+		if _, archived := Archive.Load(dequeuedTx.TxHash); archived {
+			logger.Debug("Tx is archived, skipping", "tx", dequeuedTx.TxHash)
+			activeTxs.Add(-1)
+			continue
+		}
 
 		// if this is the first time seeing this message, add it to the State
 		tx, ok := State.Load(dequeuedTx.TxHash)
@@ -185,32 +308,128 @@ func StartProcessor(
 			tx, _ = State.Load(dequeuedTx.TxHash)
 			for _, msg := range tx.Msgs {
 				msg.Status = types.Created
+				if _, amount, formatted, ok := burnAmount(cfg, msg); ok {
+					msg.Amount, msg.AmountFormatted = amount, formatted
+				}
+				Publisher.Publish(events.NewEvent(msg, ""))
 				if metrics != nil {
 					metrics.IncAttestation("observed", fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
 				}
 			}
 		}
 
-		var broadcastMsgs = make(map[types.Domain][]*types.MessageState)
-		var requeue bool
+		// skip messages already being handled by another worker (e.g. re-emitted by a flush)
+		var msgs []*types.MessageState
+		for _, msg := range tx.Msgs {
+			if !inFlight.TryAcquire(msg.IrisLookupID) {
+				types.MessageLogger(logger, msg).Debug("Message is already being processed by another worker, skipping", "tx", msg.SourceTxHash)
+				continue
+			}
+			msgs = append(msgs, msg)
 
-		apiVersion, apiErr := cfg.Circle.GetAPIVersion()
-		if apiErr != nil {
-			logger.Debug("Failed to get API version", "error", apiErr)
+			if !types.IsTerminal(msg.Status) {
+				tracing.StartTransfer(msg.IrisLookupID, msg.Created,
+					"source_domain", msg.SourceDomain, "dest_domain", msg.DestDomain, "source_tx", msg.SourceTxHash, "nonce", msg.Nonce)
+			}
+		}
+		if len(msgs) == 0 {
+			activeTxs.Add(-1)
+			continue
 		}
 
-		for _, msg := range tx.Msgs {
+		// statuses at dequeue, used to notify on transitions to a terminal status
+		prevStatuses := make([]string, len(msgs))
+		prevStatusOf := make(map[string]string, len(msgs))
+		for i, msg := range msgs {
+			prevStatuses[i] = msg.Status
+			prevStatusOf[msg.IrisLookupID] = msg.Status
+		}
+		// iris lookup ids of messages whose mints are confirmed in the background, finished by confirmMints
+		confirming := make(map[string]bool)
+		// iris lookup ids of messages that failed after exhausting re-attestation retries
+		reattestExhausted := make(map[string]bool)
+
+		var broadcastMsgs = make(map[types.Domain][]*types.MessageState)
+		var requeue bool
+		// set if an attestation check was short-circuited by the circle api circuit breaker
+		var circuitOpen bool
+		// set if an attested message was held because its route is paused
+		var paused bool
+		// set if an attested Fast Transfer was held until the allowance of its source domain is known
+		var awaitingAllowance bool
+		// set if an attested message was held because an attester outside the configured set signed it
+		var unknownAttester bool
+		// longest wait for a broadcast held by the rate limits, the tx is requeued without using up retries
+		var rateLimitWait time.Duration
+		// longest wait for the re-attestation backoff of an expired attestation, the tx is requeued without using
+		// up retries
+		var reattestWait time.Duration
+		// why the tx was last requeued, recorded if it is dead-lettered
+		var lastErr error
+		// why the tx is requeued, errors take precedence over failed broadcasts over pending attestations
+		requeueReason := types.RequeuePending
+
+		// invalidate reorged burns and run the filters before checking attestations, so messages that are dropped,
+		// held or filtered never cost an attestation request. msgCtxs holds the context of each message processed
+		// further, nil if it was dropped or held.
+		msgCtxs := make([]context.Context, len(msgs))
+		for i, msg := range msgs {
 			srcDomain := fmt.Sprint(msg.SourceDomain)
 			destDomain := fmt.Sprint(msg.DestDomain)
+			msgLogger := types.MessageLogger(logger, msg)
+			msgCtx := types.ContextWithLogger(tracing.TransferContext(ctx, msg.IrisLookupID), msgLogger)
+
+			// never mint against a burn that was removed by a source chain reorg
+			if src, ok := registeredDomains[msg.SourceDomain].(types.ReorgAware); ok {
+				if src.IsReorgedTx(msg.SourceTxHash) {
+					if !types.IsTerminal(msg.Status) || msg.Status == types.Filtered {
+						msgLogger.Error("Source tx was removed by a reorg, invalidating message", "tx", msg.SourceTxHash)
+						State.Mu.Lock()
+						leavePending(msg, metrics)
+						msg.Status = types.Failed
+						msg.FailureReason = "source tx was removed by a reorg"
+						msg.SourceReorged = true
+						msg.Updated = time.Now()
+						State.Mu.Unlock()
+						if metrics != nil {
+							metrics.IncAttestation("failed", srcDomain, destDomain)
+						}
+					}
+					continue
+				}
+
+				// the source tx was included in the canonical chain again, relay it from the start
+				if msg.SourceReorged {
+					msgLogger.Info("Source tx was observed again after a reorg, relaying message", "tx", msg.SourceTxHash)
+					State.Mu.Lock()
+					msg.Status = types.Created
+					msg.FailureReason = ""
+					msg.SourceReorged = false
+					msg.Attestation = ""
+					msg.AttestedMessage = nil
+					msg.Updated = time.Now()
+					State.Mu.Unlock()
+					circle.ForgetAttestation(msg)
+				}
+			}
 
 			// Run all filters through the filter registry
 			shouldFilter := false
-			var filterReason string
+			var filteredBy, filterReason string
 
 			if FilterRegistry != nil {
-				filtered, reason := FilterRegistry.Filter(ctx, msg)
+				filtered, name, reason, err := FilterRegistry.Filter(msgCtx, msg)
+				if err != nil {
+					// the filter can't decide yet, neither filter nor relay the message until it can
+					msgLogger.Error("Message held by filter, requeueing", "tx", msg.SourceTxHash, "filter", name, "error", err)
+					lastErr = err
+					requeueReason = max(requeueReason, types.RequeueError)
+					requeue = true
+					continue
+				}
 				if filtered {
 					shouldFilter = true
+					filteredBy = name
 					filterReason = reason
 				}
 			}
@@ -219,28 +438,63 @@ func StartProcessor(
 			if shouldFilter {
 				State.Mu.Lock()
 				prevStatus := msg.Status
+				leavePending(msg, metrics)
 				msg.Status = types.Filtered
+				msg.FilteredBy = filteredBy
+				msg.FilterReason = filterReason
 				State.Mu.Unlock()
 				// Only increment metric on first transition to filtered
 				if metrics != nil && prevStatus != types.Filtered {
 					metrics.IncAttestation("filtered", srcDomain, destDomain)
 				}
 				if filterReason != "" {
-					logger.Info("Message filtered", "tx", msg.SourceTxHash, "reason", filterReason)
+					msgLogger.Info("Message filtered", "tx", msg.SourceTxHash, "filter", filteredBy, "reason", filterReason)
 				}
 			}
 
+			msgCtxs[i] = msgCtx
+		}
+
+		// check the attestations of a tx with several burns concurrently instead of a request per message in turn
+		prefetched := prefetchAttestations(cfg.Circle, logger, msgs, msgCtxs)
+
+		for i, msg := range msgs {
+			msgCtx := msgCtxs[i]
+			if msgCtx == nil {
+				continue
+			}
+
+			apiVersion, apiErr := cfg.Circle.GetSourceAPIVersion(msg.SourceDomain)
+			if apiErr != nil {
+				logger.Debug("Failed to get API version", "error", apiErr)
+			}
+
+			srcDomain := fmt.Sprint(msg.SourceDomain)
+			destDomain := fmt.Sprint(msg.DestDomain)
+			msgLogger := types.LoggerFromContext(msgCtx, logger)
+
 			// if the message is burned or pending, check for an attestation
 			if msg.Status == types.Created || msg.Status == types.Pending {
-				response := circle.CheckAttestation(cfg.Circle, logger, msg.IrisLookupID, msg.SourceTxHash, msg.SourceDomain, msg.DestDomain)
+				response, err := prefetched[i].response, prefetched[i].err
+				if _, ok := prefetched[i]; !ok {
+					response, err = circle.CheckAttestation(msgCtx, cfg.Circle, msgLogger, msg.IrisLookupID, msg.SourceTxHash, msg.SourceDomain, msg.DestDomain)
+				}
 
 				switch {
+				case errors.Is(err, circle.ErrCircuitOpen):
+					circuitOpen = true
+					requeue = true
+					continue
 				case response == nil:
-					logger.Debug("Attestation is still processing for 0x" + msg.IrisLookupID + ".  Retrying...")
+					msgLogger.Debug("Attestation is still processing for 0x" + msg.IrisLookupID + ".  Retrying...")
+					lastErr = err
+					if lastErr == nil {
+						lastErr = fmt.Errorf("attestation for 0x%s is still processing", msg.IrisLookupID)
+					}
 					requeue = true
 					continue
 				case msg.Status == types.Created && response.Status == "pending_confirmations":
-					logger.Debug("Attestation is created but still pending confirmations for 0x" + msg.IrisLookupID + ".  Retrying...")
+					msgLogger.Debug("Attestation is created but still pending confirmations for 0x" + msg.IrisLookupID + ".  Retrying...")
 					State.Mu.Lock()
 					msg.Status = types.Pending
 					msg.Updated = time.Now()
@@ -249,69 +503,127 @@ func StartProcessor(
 						metrics.IncAttestation("pending", srcDomain, destDomain)
 						metrics.IncPending(srcDomain, destDomain)
 					}
+					lastErr = fmt.Errorf("attestation for 0x%s is pending confirmations", msg.IrisLookupID)
 					requeue = true
 					continue
 				case response.Status == "pending_confirmations":
-					logger.Debug("Attestation is still pending for 0x" + msg.IrisLookupID + ".  Retrying...")
+					msgLogger.Debug("Attestation is still pending for 0x" + msg.IrisLookupID + ".  Retrying...")
+					reportStuckPending(ctx, cfg.Circle, msg, msgLogger, metrics)
+					lastErr = fmt.Errorf("attestation for 0x%s is pending confirmations", msg.IrisLookupID)
 					requeue = true
 					continue
 				case response.Status == "complete":
-					logger.Debug("Attestation is complete for 0x" + msg.IrisLookupID + ".")
+					msgLogger.Debug("Attestation is complete for 0x" + msg.IrisLookupID + ".")
+
+					// Fetch message details for Fast Transfer expiration tracking
+					var msgResp *types.MessageResponseV2
+					if apiVersion == types.APIVersionV2 {
+						msgResp, err = circle.GetAttestationV2Message(
+							cfg.Circle.AttestationBaseURLs(), msgLogger, msg.SourceTxHash, msg.SourceDomain)
+						if err != nil {
+							msgLogger.Debug("Failed to fetch v2 message details", "error", err, "txHash", msg.SourceTxHash)
+						}
+					}
+
+					// hold attestations below the finality the route requires until Circle re-attests them
+					if required := cfg.RequiredFinalityThreshold(msg.SourceDomain, msg.DestDomain); required > 0 && apiVersion == types.APIVersionV2 {
+						State.Mu.Lock()
+						msg.RequiredFinalityThreshold = required
+						State.Mu.Unlock()
+
+						var executed uint32
+						if msgResp != nil {
+							executed = circle.ParseFinalityThreshold(msgResp.FinalityThresholdExecuted)
+						}
+						if !circle.MeetsRequiredFinality(msg, executed) {
+							// check the attestation again until Circle re-attests it at the required finality
+							circle.ForgetAttestation(msg)
+							// without the message details the executed finality is unknown, check again later
+							if msgResp != nil {
+								result, err := circle.HandleRequiredFinality(cfg.Circle, msg, executed, msgLogger)
+								if err != nil {
+									msgLogger.Error("Re-attestation for required finality failed", "error", err)
+								}
+								circle.ApplyReattestResult(State, msg, result, metrics)
+							}
+							msgLogger.Debug("Attestation is below the route's required finality for 0x"+msg.IrisLookupID+".  Retrying...",
+								"executed", executed, "required", required)
+							lastErr = fmt.Errorf("attestation for 0x%s executed at finality %d, route requires %d", msg.IrisLookupID, executed, required)
+							requeue = true
+							continue
+						}
+					}
+
+					// checked against the message bytes before broadcasting, see checkAttestedMessages
+					attestedMessage, err := hex.DecodeString(strings.TrimPrefix(response.Message, "0x"))
+					if err != nil {
+						msgLogger.Debug("Unable to decode attested message", "error", err)
+					}
 
 					// Update state under lock
 					State.Mu.Lock()
-					prevStatus := msg.Status
+					leavePending(msg, metrics)
 					msg.Status = types.Attested
 					msg.Attestation = response.Attestation
+					msg.AttestedMessage = attestedMessage
 					msg.Updated = time.Now()
+					if msgResp != nil {
+						msg.CctpVersion = msgResp.CctpVersion
+						msg.ExpirationBlock = circle.ParseExpirationBlock(msgResp.ExpirationBlock)
+						msg.FinalityThreshold = circle.ParseFinalityThreshold(msgResp.FinalityThresholdExecuted)
+					}
 					State.Mu.Unlock()
 					if metrics != nil {
 						metrics.IncAttestation("complete", srcDomain, destDomain)
-						if prevStatus == types.Pending {
-							metrics.DecPending(srcDomain, destDomain)
-						}
-					}
-
-					// Fetch message details for Fast Transfer expiration tracking
-					if apiVersion == types.APIVersionV2 {
-						msgResp, err := circle.GetAttestationV2Message(
-							cfg.Circle.AttestationBaseURL, logger, msg.SourceTxHash, msg.SourceDomain)
-						if err != nil {
-							logger.Debug("Failed to fetch v2 message details", "error", err, "txHash", msg.SourceTxHash)
-						} else if msgResp != nil {
-							State.Mu.Lock()
-							msg.CctpVersion = msgResp.CctpVersion
-							msg.ExpirationBlock = circle.ParseExpirationBlock(msgResp.ExpirationBlock)
-							State.Mu.Unlock()
+						if !msg.Created.IsZero() {
+							metrics.ObserveAttestationWait(srcDomain, destDomain, msg.Updated.Sub(msg.Created))
 						}
 					}
 
 					broadcastMsgs[msg.DestDomain] = append(broadcastMsgs[msg.DestDomain], msg)
 				default:
-					logger.Error("Attestation failed for unknown reason for 0x" + msg.IrisLookupID + ".  Status: " + response.Status)
-					if metrics != nil {
-						metrics.IncAttestation("failed", srcDomain, destDomain)
-					}
+					lastErr = unknownAttestationStatus(msgLogger, msg, response.Status, metrics)
+					requeueReason = max(requeueReason, types.RequeueError)
+					requeue = true
+					continue
 				}
 			}
 
+			// attested messages requeued before being broadcast, e.g. while their route was paused
+			if msg.Status == types.Attested && !slices.Contains(broadcastMsgs[msg.DestDomain], msg) {
+				broadcastMsgs[msg.DestDomain] = append(broadcastMsgs[msg.DestDomain], msg)
+			}
+
 			// Handle expired Fast Transfer attestations (v2 only)
 			if apiVersion == types.APIVersionV2 && msg.Status == types.Attested && msg.ExpirationBlock > 0 {
 				if destChain, ok := registeredDomains[msg.DestDomain]; ok {
-					result, err := circle.HandleExpiringAttestation(msg, cfg.Circle, destChain.LatestBlock(), logger)
+					_, span := tracing.Start(msgCtx, "reattest", "expiration_block", msg.ExpirationBlock)
+					result, err := circle.HandleExpiringAttestation(msg, cfg.Circle, destChain.LatestBlock(), destChain.BlockTime(), msgLogger)
 					if err != nil {
-						logger.Error("Re-attestation handling failed", "nonce", msg.Nonce, "error", err)
+						msgLogger.Error("Re-attestation handling failed", "error", err)
 					}
+					span.RecordError(err)
+					span.End()
 
-					circle.ApplyReattestResult(State, msg, result)
+					circle.ApplyReattestResult(State, msg, result, metrics)
 
 					if result.RemoveFromQueue {
 						circle.RemoveMessageFromQueue(broadcastMsgs, msg)
+						lastErr = err
+						requeueReason = max(requeueReason, types.RequeueError)
 						requeue = true
 						continue
 					}
 
 					if result.ExhaustedRetries {
+						reattestExhausted[msg.IrisLookupID] = true
+						continue
+					}
+
+					// the expired attestation would be rejected, hold the message until it can be re-attested
+					if result.BackoffWait > 0 {
+						circle.RemoveMessageFromQueue(broadcastMsgs, msg)
+						reattestWait = max(reattestWait, result.BackoffWait)
 						continue
 					}
 				}
@@ -326,101 +638,637 @@ func StartProcessor(
 				continue
 			}
 
-			if err := chain.Broadcast(ctx, logger, msgs, sequenceMap, metrics); err != nil {
-				logger.Error("Unable to mint one or more transfers", "error(s)", err, "total_transfers", len(msgs), "name", chain.Name(), "domain", domain)
-				requeue = true
+			// hold messages on paused routes until they are resumed
+			msgs = slices.DeleteFunc(msgs, func(msg *types.MessageState) bool {
+				if !Pause.IsPaused(msg.SourceDomain, domain) {
+					return false
+				}
+				types.MessageLogger(logger, msg).Debug("Route is paused, requeueing attested message",
+					"source_domain", msg.SourceDomain, "dest_domain", domain)
+				paused = true
+				return true
+			})
+			if len(msgs) == 0 {
 				continue
 			}
 
-			State.Mu.Lock()
-			for _, msg := range msgs {
-				msg.Status = types.Complete
+			// hold Fast Transfers right after startup until the allowance monitor fetched their source's allowance
+			msgs = slices.DeleteFunc(msgs, func(msg *types.MessageState) bool {
+				if !msg.IsFastTransfer() || !allowanceMonitor.HoldFastTransfer(msg.SourceDomain) {
+					return false
+				}
+				types.MessageLogger(logger, msg).Debug("Fast Transfer allowance is not known yet, requeueing attested message",
+					"source_domain", msg.SourceDomain)
+				awaitingAllowance = true
+				return true
+			})
+			if len(msgs) == 0 {
+				continue
+			}
+
+			// messages whose nonce is cached as used were minted already, skip the used nonce query
+			msgs = slices.DeleteFunc(msgs, func(msg *types.MessageState) bool {
+				if !types.UsedNonces.IsUsed(msg.SourceDomain, msg.Nonce) {
+					return false
+				}
+				types.MessageLogger(logger, msg).Debug("Nonce is cached as used, skipping message", "nonce", msg.Nonce)
+				State.Mu.Lock()
+				msg.Status = types.AlreadyMinted
 				msg.Updated = time.Now()
+				State.Mu.Unlock()
+				if metrics != nil {
+					metrics.IncUsedNonceCacheHits(fmt.Sprint(msg.SourceDomain), fmt.Sprint(domain))
+					metrics.IncAttestation(types.AlreadyMinted, fmt.Sprint(msg.SourceDomain), fmt.Sprint(domain))
+				}
+				return true
+			})
+			if len(msgs) == 0 {
+				continue
+			}
+
+			msgs = checkAttestedMessages(logger, msgs, metrics)
+			if len(msgs) == 0 {
+				continue
+			}
+
+			if cfg.Circle.VerifiesAttestations() {
+				var held bool
+				msgs, held = verifyAttestations(ctx, cfg.Circle, logger, msgs, metrics)
+				unknownAttester = unknownAttester || held
+				if len(msgs) == 0 {
+					continue
+				}
+			}
+
+			// wait for a broadcast slot on the destination, requeue if shutting down
+			if err := broadcastLimiter.Acquire(ctx, domain); err != nil {
+				requeue = true
+				continue
+			}
+
+			// take a rate limit token per mint, the mints over the destination's limit are held and requeued
+			allowed, wait := broadcastRateLimiter.Take(domain, len(msgs))
+			if metrics != nil {
+				metrics.SetBroadcastRateLimitWait(chain.Name(), fmt.Sprint(domain), wait)
+			}
+			if allowed < len(msgs) {
+				logger.Debug("Broadcast rate limited, requeueing attested messages", "name", chain.Name(), "domain", domain,
+					"held", len(msgs)-allowed, "wait", wait)
+				rateLimitWait = max(rateLimitWait, wait)
+				msgs = msgs[:allowed]
+			}
+			if len(msgs) == 0 {
+				broadcastLimiter.Release(domain)
+				continue
+			}
+			if metrics != nil {
+				metrics.SetBroadcastsInFlight(chain.Name(), fmt.Sprint(domain), broadcastLimiter.InFlight(domain))
+			}
+
+			spans := make([]*tracing.Span, len(msgs))
+			for i, msg := range msgs {
+				_, spans[i] = tracing.Start(tracing.TransferContext(ctx, msg.IrisLookupID), "broadcast",
+					"chain", chain.Name(), "dest_domain", domain, "batch_size", len(msgs))
 			}
-			State.Mu.Unlock()
 
+			var err error
+			if dryRun {
+				simulateBroadcast(logger, chain, msgs)
+			} else {
+				err = chain.Broadcast(ctx, logger, msgs, sequenceMap, metrics)
+			}
+			broadcastLimiter.Release(domain)
 			if metrics != nil {
+				metrics.SetBroadcastsInFlight(chain.Name(), fmt.Sprint(domain), broadcastLimiter.InFlight(domain))
+			}
+			for i, span := range spans {
+				span.SetAttributes("dest_tx", msgs[i].DestTxHash)
+				span.RecordError(err)
+				span.End()
+			}
+			if criticalMonitor != nil {
+				criticalMonitor.BroadcastResult(ctx, chain.Name(), domain, err)
+			}
+			if err != nil {
+				logger.Error("Unable to mint one or more transfers", "error(s)", err, "total_transfers", len(msgs), "name", chain.Name(), "domain", domain)
+				lastErr = err
+				requeueReason = max(requeueReason, types.RequeueBroadcast)
+				requeue = true
+				continue
+			}
+
+			// wait for the mints to be confirmed in the background, outside of the broadcast slot and without holding
+			// up the worker
+			if confirmer, ok := chain.(types.MintConfirmer); ok && !dryRun {
+				batchPrevStatuses := make([]string, len(msgs))
+				for i, msg := range msgs {
+					confirming[msg.IrisLookupID] = true
+					batchPrevStatuses[i] = prevStatusOf[msg.IrisLookupID]
+				}
+				// the confirmation is outstanding work the shutdown drain waits for
+				activeTxs.Add(1)
+				go confirmMints(ctx, cfg, logger, confirmer, chain, tx, msgs, batchPrevStatuses, processingQueue, metrics)
+				continue
+			}
+
+			msgs = dropFailedMints(domain, msgs, metrics)
+
+			if dryRun {
+				State.Mu.Lock()
 				for _, msg := range msgs {
-					srcDomain := fmt.Sprint(msg.SourceDomain)
-					destDomain := fmt.Sprint(domain)
-					metrics.IncAttestation("minted", srcDomain, destDomain)
+					msg.Status = types.SimulatedComplete
+					msg.Updated = time.Now()
+				}
+				State.Mu.Unlock()
+				if metrics != nil {
+					for _, msg := range msgs {
+						metrics.IncAttestation(types.SimulatedComplete, fmt.Sprint(msg.SourceDomain), fmt.Sprint(domain))
+					}
 				}
+				continue
 			}
+
+			completeMints(cfg, domain, msgs, metrics)
 		}
 
-		// requeue txs, ensure not to exceed retry limit
-		if requeue {
-			if dequeuedTx.RetryAttempt < cfg.Circle.FetchRetries {
+		// messages whose mints are being confirmed are finished by confirmMints
+		if len(confirming) > 0 {
+			var finished []*types.MessageState
+			var finishedPrevStatuses []string
+			for i, msg := range msgs {
+				if !confirming[msg.IrisLookupID] {
+					finished = append(finished, msg)
+					finishedPrevStatuses = append(finishedPrevStatuses, prevStatuses[i])
+				}
+			}
+			msgs, prevStatuses = finished, finishedPrevStatuses
+		}
+		finishMsgs(ctx, msgs, prevStatuses, reattestExhausted)
+
+		// requeue txs, ensure not to exceed retry limit. Txs that can't be broadcast yet are held, they are left in
+		// the state instead of being requeued once the shutdown drain started.
+		if requeue || paused || awaitingAllowance || unknownAttester || rateLimitWait > 0 || reattestWait > 0 {
+			// set if the tx was left in the state on shutdown instead of being requeued
+			var left bool
+			// while the circle api is down, wait for the circuit breaker instead of using up retries
+			if circuitOpen {
+				retryAfter := max(circle.CircuitRetryAfter(), time.Duration(cfg.Circle.FetchRetryInterval)*time.Second)
+				logger.Debug("Circle API circuit breaker is open, requeueing tx", "tx", dequeuedTx.TxHash, "retry_after", retryAfter)
+				left = !holdAfter(ctx, processingQueue, tx, retryAfter)
+			} else if requeue && requeueReason == types.RequeuePending && dequeuedTx.PendingWait < cfg.Circle.PendingBudget() {
+				// pending polls use up the pending budget by their interval, not a retry each
+				interval := cfg.Circle.RequeueInterval(requeueReason)
+				dequeuedTx.PendingWait += interval
+				left = !holdAfter(ctx, processingQueue, tx, interval)
+			} else if requeue && requeueReason != types.RequeuePending && dequeuedTx.RetryAttempt < cfg.Circle.FetchRetries {
 				dequeuedTx.RetryAttempt++
-				time.Sleep(time.Duration(cfg.Circle.FetchRetryInterval) * time.Second)
-				processingQueue <- tx
+				requeueAfter(ctx, processingQueue, tx, cfg.Circle.RequeueInterval(requeueReason))
+			} else if paused || awaitingAllowance || unknownAttester {
+				// messages on paused routes, Fast Transfers awaiting their allowance and attestations signed by an
+				// unknown attester are held without using up retries
+				left = !holdAfter(ctx, processingQueue, tx, time.Duration(cfg.Circle.FetchRetryInterval)*time.Second)
+			} else if rateLimitWait > 0 {
+				// rate limited broadcasts are retried once the limit allows them without using up retries
+				left = !holdAfter(ctx, processingQueue, tx, rateLimitWait)
+			} else if reattestWait > 0 {
+				// expired attestations waiting for their re-attestation backoff don't use up retries either
+				left = !holdAfter(ctx, processingQueue, tx, reattestWait)
 			} else {
-				logger.Error("Retry limit exceeded for tx", "limit", cfg.Circle.FetchRetries, "tx", dequeuedTx.TxHash)
+				logger.Error("Retry limit exceeded for tx, moving it to the dead-letter store", "limit", cfg.Circle.FetchRetries, "tx", dequeuedTx.TxHash, "error", lastErr)
+				deadLetterTx(tx, msgs, lastErr, metrics)
+				for _, msg := range msgs {
+					tracing.EndTransfer(msg.IrisLookupID, "retry limit exceeded")
+				}
+			}
+			if left {
+				logger.Info("Shutting down, leaving tx that can't be broadcast yet in the state", "tx", dequeuedTx.TxHash)
 			}
 		}
+
+		activeTxs.Add(-1)
 	}
 }
 
-// initializeFilters creates and initializes the filter registry with configured filters
-func initializeFilters(ctx context.Context, cfg *types.Config, logger log.Logger, registeredDomains map[types.Domain]types.Chain) error {
-	FilterRegistry = types.NewFilterRegistry(logger)
+// dropFailedMints returns msgs without the messages the chain rejected before broadcasting, e.g. with an invalid
+// mint recipient, or whose mint reverted. They stay failed.
+func dropFailedMints(domain types.Domain, msgs []*types.MessageState, metrics *relayer.PromMetrics) []*types.MessageState {
+	return slices.DeleteFunc(msgs, func(msg *types.MessageState) bool {
+		State.Mu.Lock()
+		failed := msg.Status == types.Failed
+		State.Mu.Unlock()
+		if !failed {
+			return false
+		}
+		if metrics != nil {
+			metrics.IncAttestation("failed", fmt.Sprint(msg.SourceDomain), fmt.Sprint(domain))
+		}
+		return true
+	})
+}
 
-	// Register base filters as plugins
-	routeFilter := filters.NewRouteFilter()
-	if err := routeFilter.Initialize(ctx, map[string]interface{}{
-		"enabled_routes": cfg.EnabledRoutes,
-	}, logger); err != nil {
-		return fmt.Errorf("failed to initialize route filter: %w", err)
+// completeMints marks the messages minted on the domain complete and records their nonces as used
+func completeMints(cfg *types.Config, domain types.Domain, msgs []*types.MessageState, metrics *relayer.PromMetrics) {
+	State.Mu.Lock()
+	for _, msg := range msgs {
+		// messages minted by someone else keep their status so they aren't reported as our relays
+		if msg.Status != types.AlreadyMinted {
+			msg.Status = types.Complete
+		}
+		msg.Updated = time.Now()
 	}
-	FilterRegistry.Register(routeFilter)
+	State.Mu.Unlock()
 
-	destCallerFilter := filters.NewDestinationCallerFilter()
-	if err := destCallerFilter.Initialize(ctx, map[string]interface{}{
-		"registered_domains":      registeredDomains,
-		"destination_caller_only": cfg.DestinationCallerOnly,
-	}, logger); err != nil {
-		return fmt.Errorf("failed to initialize destination-caller filter: %w", err)
+	for _, msg := range msgs {
+		types.UsedNonces.Add(msg.SourceDomain, msg.Nonce, domain)
 	}
-	FilterRegistry.Register(destCallerFilter)
 
-	lowTransferFilter := filters.NewLowTransferFilter()
-	if err := lowTransferFilter.Initialize(ctx, map[string]interface{}{
-		"chains": cfg.Chains,
-	}, logger); err != nil {
-		return fmt.Errorf("failed to initialize low-transfer filter: %w", err)
+	if metrics != nil {
+		for _, msg := range msgs {
+			srcDomain := fmt.Sprint(msg.SourceDomain)
+			destDomain := fmt.Sprint(domain)
+			if msg.Status == types.AlreadyMinted {
+				metrics.IncAttestation(types.AlreadyMinted, srcDomain, destDomain)
+				continue
+			}
+			metrics.IncAttestation("minted", srcDomain, destDomain)
+			if !msg.Created.IsZero() {
+				metrics.ObserveRelayLatency(srcDomain, destDomain, msg.Updated.Sub(msg.Created))
+			}
+			metrics.SetLastSuccessfulRelay(srcDomain, destDomain, msg.Updated)
+			if token, amount, ok := mintedAmount(cfg, msg); ok {
+				metrics.AddMintedAmount(srcDomain, destDomain, token, amount)
+			}
+		}
 	}
-	FilterRegistry.Register(lowTransferFilter)
+}
 
-	// Register user-configured filters from config
-	for _, filterCfg := range cfg.Filters {
-		if !filterCfg.Enabled {
-			logger.Debug("Skipping disabled filter", "name", filterCfg.Name)
-			continue
-		}
+// confirmMints waits in the background for the mints of msgs broadcast to the chain to be confirmed, then completes
+// the messages and releases them to other workers. Reverted mints are failed. Mints that aren't confirmed in time are
+// released and their tx is requeued, on shutdown the messages are released as they are.
+func confirmMints(
+	ctx context.Context,
+	cfg *types.Config,
+	logger log.Logger,
+	confirmer types.MintConfirmer,
+	chain types.Chain,
+	tx *types.TxState,
+	msgs []*types.MessageState,
+	prevStatuses []string,
+	processingQueue chan *types.TxState,
+	metrics *relayer.PromMetrics,
+) {
+	defer activeTxs.Add(-1)
+
+	domain := chain.Domain()
+	err := confirmer.WaitForMints(ctx, logger, msgs)
+	switch {
+	case ctx.Err() != nil:
+	case err != nil:
+		logger.Error("Unable to confirm one or more mints, requeueing", "error", err, "total_transfers", len(msgs), "name", chain.Name(), "domain", domain)
+	default:
+		completeMints(cfg, domain, dropFailedMints(domain, slices.Clone(msgs), metrics), metrics)
+	}
 
-		var filter types.MessageFilter
-		switch filterCfg.Name {
-		case "depositor-whitelist":
-			filter = filters.NewDepositorWhitelistFilter()
-		default:
-			logger.Info("Unknown filter type, skipping", "name", filterCfg.Name)
-			continue
-		}
+	finishMsgs(ctx, msgs, prevStatuses, nil)
+	if err != nil && ctx.Err() == nil {
+		enqueueTx(processingQueue, tx)
+	}
+}
 
-		if err := filter.Initialize(ctx, filterCfg.Config, logger); err != nil {
-			return fmt.Errorf("failed to initialize filter %s: %w", filterCfg.Name, err)
-		}
+// finishMsgs notifies and publishes the status transitions of the messages a worker is done with and releases them
+// to other workers
+func finishMsgs(ctx context.Context, msgs []*types.MessageState, prevStatuses []string, reattestExhausted map[string]bool) {
+	notifyTransitions(ctx, msgs, prevStatuses, reattestExhausted)
+	publishTransitions(msgs, prevStatuses)
 
-		FilterRegistry.Register(filter)
-		logger.Info("Registered custom filter", "name", filterCfg.Name)
+	for _, msg := range msgs {
+		inFlight.Release(msg.IrisLookupID)
+
+		if types.IsTerminal(msg.Status) {
+			tracing.EndTransfer(msg.IrisLookupID, msg.Status)
+		}
 	}
+}
 
-	return nil
+// burnAmount returns the burn token (hex), the raw burn amount of the message and the amount scaled by the token
+// decimals. Returns false for messages that aren't burn messages.
+func burnAmount(cfg *types.Config, msg *types.MessageState) (string, string, string, bool) {
+	bm, err := new(types.BurnMessage).Parse(msg.MsgBody)
+	if err != nil {
+		return "", "", "", false
+	}
+	return hex.EncodeToString(bm.BurnToken), bm.Amount.String(), cfg.FormatBurnAmount(bm.BurnToken, bm.Amount), true
 }
 
-func startAPI(a *AppState) {
-	logger := a.Logger
-	cfg := a.Config
+// mintedAmount returns the burn token (hex) and the burn amount of the message scaled by the token decimals.
+// Returns false for messages that aren't burn messages.
+func mintedAmount(cfg *types.Config, msg *types.MessageState) (string, float64, bool) {
+	token, _, formatted, ok := burnAmount(cfg, msg)
+	if !ok {
+		return "", 0, false
+	}
+	amount, err := strconv.ParseFloat(formatted, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return token, amount, true
+}
+
+// simulateBroadcast logs the mints a dry run would have broadcast to the chain
+func simulateBroadcast(logger log.Logger, chain types.Chain, msgs []*types.MessageState) {
+	for _, msg := range msgs {
+		types.MessageLogger(logger, msg).Info("Dry run: not broadcasting mint", "name", chain.Name(), "domain", chain.Domain(),
+			"source_domain", msg.SourceDomain, "source_tx", msg.SourceTxHash, "nonce", msg.Nonce, "amount", msg.AmountFormatted)
+	}
+}
+
+// notifyTransitions notifies on messages that reached complete, already-minted or failed while being processed
+func notifyTransitions(ctx context.Context, msgs []*types.MessageState, prevStatuses []string, reattestExhausted map[string]bool) {
+	if Notifier == nil {
+		return
+	}
+
+	for i, msg := range msgs {
+		State.Mu.Lock()
+		status := msg.Status
+		event := notify.NewEvent(msg)
+		State.Mu.Unlock()
+
+		if status == prevStatuses[i] || (status != types.Complete && status != types.AlreadyMinted && status != types.Failed) {
+			continue
+		}
+		if status == types.Failed && reattestExhausted[msg.IrisLookupID] {
+			event.Type = notify.EventReattestExhausted
+		}
+		Notifier.Notify(ctx, event)
+	}
+}
+
+// publishTransitions publishes an event for every message whose status changed while being processed. Messages
+// attested and broadcast in one pass also get an attested event, so consumers see every stage.
+func publishTransitions(msgs []*types.MessageState, prevStatuses []string) {
+	for i, msg := range msgs {
+		State.Mu.Lock()
+		status := msg.Status
+		prev := prevStatuses[i]
+		if status == prev || status == types.Pending {
+			State.Mu.Unlock()
+			continue
+		}
+		var published []events.Event
+		if (prev == types.Created || prev == types.Pending) && (status == types.Complete || status == types.AlreadyMinted || status == types.Failed) && len(msg.Attestation) > 0 {
+			attested := events.NewEvent(msg, prev)
+			attested.Status = types.Attested
+			attested.DestTxHash = ""
+			attested.Reason = ""
+			published = append(published, attested)
+			prev = types.Attested
+		}
+		published = append(published, events.NewEvent(msg, prev))
+		State.Mu.Unlock()
+
+		for _, event := range published {
+			Publisher.Publish(event)
+		}
+	}
+}
+
+// initializeChains connects to every configured chain, waits for its latest height and initializes its broadcaster.
+// Returns the chains keyed by domain.
+func initializeChains(ctx context.Context, a *AppState, metrics *relayer.PromMetrics) (map[types.Domain]types.Chain, error) {
+	registeredDomains := make(map[types.Domain]types.Chain)
+
+	for name, cfg := range a.Config.Chains {
+		c, err := cfg.Chain(name)
+		if err != nil {
+			return nil, fmt.Errorf("error creating chain error=%w", err)
+		}
+
+		logger := a.Logger.With("name", c.Name(), "domain", c.Domain())
+
+		if err := c.InitializeClients(ctx, logger); err != nil {
+			return nil, fmt.Errorf("error initializing client error=%w", err)
+		}
+
+		go c.TrackLatestBlockHeight(ctx, logger, metrics)
+
+		// wait until height is available
+		maxRetries := 45
+		for i := 0; i < maxRetries; i++ {
+			if c.LatestBlock() == 0 {
+				time.Sleep(1 * time.Second)
+			} else {
+				break
+			}
+			if i == maxRetries-1 {
+				return nil, fmt.Errorf("unable to get height")
+			}
+		}
+
+		if locker, ok := c.(types.StateLocker); ok {
+			locker.UseStateLock(&State.Mu)
+		}
+
+		if err := c.InitializeBroadcaster(ctx, logger, sequenceMap); err != nil {
+			return nil, fmt.Errorf("error initializing broadcaster error=%w", err)
+		}
+
+		if _, ok := registeredDomains[c.Domain()]; ok {
+			return nil, fmt.Errorf("duplicate domain found domain=%d name=%s", c.Domain(), c.Name())
+		}
+
+		registeredDomains[c.Domain()] = c
+	}
+
+	return registeredDomains, nil
+}
+
+// deadLetterTx moves tx to the dead-letter store, where it stays until it is requeued. The statuses of its msgs are
+// left as they are to show where each one got stuck.
+func deadLetterTx(tx *types.TxState, msgs []*types.MessageState, lastErr error, metrics *relayer.PromMetrics) {
+	DeadLetters.Add(tx, lastErr)
+
+	if metrics == nil {
+		return
+	}
+	for _, msg := range msgs {
+		if !types.IsTerminal(msg.Status) {
+			metrics.IncDeadLetters(fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+		}
+	}
+}
+
+// cosmosDomains returns the domains of the configured cosmos chains, whose minter account sequences are persisted
+func cosmosDomains(cfg *types.Config) []types.Domain {
+	var domains []types.Domain
+	for domain, chainType := range cfg.DomainTypes() {
+		if chainType == types.ChainTypeCosmos {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// sequencePersistInterval is how often persistSequences saves the minter account sequences
+const sequencePersistInterval = 5 * time.Second
+
+// persistSequences saves the minter account sequences of domains to path every few seconds until ctx is done
+func persistSequences(ctx context.Context, logger log.Logger, path string, domains []types.Domain) {
+	ticker := time.NewTicker(sequencePersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := sequenceMap.Save(path, domains); err != nil {
+				logger.Error("Error saving minter account sequences", "file", path, "error", err)
+			}
+		}
+	}
+}
+
+// checkpointPersistInterval is how often persistCheckpoints saves the last flushed block of each chain
+const checkpointPersistInterval = 30 * time.Second
+
+// persistCheckpoints saves the last flushed block of each chain to path until ctx is done
+func persistCheckpoints(ctx context.Context, logger log.Logger, path string, chains map[types.Domain]types.Chain) {
+	ticker := time.NewTicker(checkpointPersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := types.SaveCheckpoints(path, chains); err != nil {
+				logger.Error("Error saving chain checkpoints", "file", path, "error", err)
+			}
+		}
+	}
+}
+
+// initializeFilters creates and initializes the filter registry with configured filters
+func initializeFilters(
+	ctx context.Context,
+	cfg *types.Config,
+	logger log.Logger,
+	registeredDomains map[types.Domain]types.Chain,
+	metrics *relayer.PromMetrics,
+) error {
+	FilterRegistry = types.NewFilterRegistry(logger, metrics)
+	FilterRegistry.SetEvaluateAll(cfg.FilterEvaluateAll)
+
+	filters, err := buildFilters(ctx, cfg, logger, registeredDomains)
+	if err != nil {
+		return err
+	}
+	for _, filter := range filters {
+		FilterRegistry.Register(filter)
+	}
+	return nil
+}
+
+// buildFilters initializes the base filters and the enabled filters of cfg. The filters initialized before one
+// fails are closed again.
+func buildFilters(
+	ctx context.Context,
+	cfg *types.Config,
+	logger log.Logger,
+	registeredDomains map[types.Domain]types.Chain,
+) (filterList []types.MessageFilter, err error) {
+	defer func() {
+		if err != nil {
+			for _, filter := range filterList {
+				if closeErr := filter.Close(); closeErr != nil {
+					logger.Error("Error closing filter", "filter", filter.Name(), "error", closeErr)
+				}
+			}
+		}
+	}()
+
+	// Register base filters as plugins
+	routeFilter := filters.NewRouteFilter()
+	if err := routeFilter.Initialize(ctx, map[string]interface{}{
+		"enabled_routes": cfg.EnabledRoutes,
+	}, logger); err != nil {
+		return filterList, fmt.Errorf("failed to initialize route filter: %w", err)
+	}
+	filterList = append(filterList, routeFilter)
+
+	destCallerFilter := filters.NewDestinationCallerFilter()
+	if err := destCallerFilter.Initialize(ctx, map[string]interface{}{
+		"registered_domains":      registeredDomains,
+		"destination_caller_only": cfg.DestinationCallerOnly,
+	}, logger); err != nil {
+		return filterList, fmt.Errorf("failed to initialize destination-caller filter: %w", err)
+	}
+	filterList = append(filterList, destCallerFilter)
+
+	lowTransferFilter := filters.NewLowTransferFilter()
+	if err := lowTransferFilter.Initialize(ctx, map[string]interface{}{
+		"chains":      cfg.Chains,
+		"min_amounts": cfg.MinMintAmounts,
+		"config":      cfg,
+	}, logger); err != nil {
+		return filterList, fmt.Errorf("failed to initialize low-transfer filter: %w", err)
+	}
+	filterList = append(filterList, lowTransferFilter)
+
+	// Register user-configured filters from config
+	domainTypes := cfg.DomainTypes()
+	for _, filterCfg := range cfg.Filters {
+		if !filterCfg.Enabled {
+			logger.Debug("Skipping disabled filter", "name", filterCfg.Name)
+			continue
+		}
+
+		var filter types.MessageFilter
+		switch filterCfg.Name {
+		case "depositor-whitelist":
+			filter = filters.NewDepositorWhitelistFilter()
+		case "sanctions":
+			filter = filters.NewSanctionsFilter()
+		case "max-age":
+			filter = filters.NewMaxAgeFilter()
+		case "mint-recipient":
+			filter = filters.NewMintRecipientFilter()
+		default:
+			logger.Info("Unknown filter type, skipping", "name", filterCfg.Name)
+			continue
+		}
+
+		// expose the chain type of every configured domain and the running chains to the filter
+		filterConfig := make(map[string]interface{}, len(filterCfg.Config)+2)
+		for k, v := range filterCfg.Config {
+			filterConfig[k] = v
+		}
+		filterConfig["domain_types"] = domainTypes
+		filterConfig["registered_domains"] = registeredDomains
+
+		if err := filter.Initialize(ctx, filterConfig, logger); err != nil {
+			return filterList, fmt.Errorf("failed to initialize filter %s: %w", filterCfg.Name, err)
+		}
+		if filterCfg.DryRun {
+			filter = types.NewDryRunFilter(filter)
+		}
+
+		filterList = append(filterList, filter)
+		logger.Info("Registered custom filter", "name", filterCfg.Name, "dry_run", filterCfg.DryRun)
+	}
+
+	for _, name := range cfg.FilterOrder {
+		if !slices.ContainsFunc(filterList, func(filter types.MessageFilter) bool { return filter.Name() == name }) {
+			logger.Info("Filter in filter-order is not registered, ignoring", "name", name)
+		}
+	}
+	return types.OrderFilters(filterList, cfg.FilterOrder), nil
+}
+
+func startAPI(a *AppState, registeredDomains map[types.Domain]types.Chain, processingQueue chan *types.TxState, metrics *relayer.PromMetrics) {
+	logger := a.Logger
+	cfg := a.Config
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
 
@@ -430,8 +1278,29 @@ func startAPI(a *AppState) {
 		os.Exit(1)
 	}
 
+	router.Use(cors(cfg.API.AllowedOrigins))
+
 	router.GET("/tx/:txHash", getTxByHash)
-	err = router.Run("localhost:8000")
+	router.GET("/messages", getMessages)
+	router.GET("/chains", getChains(registeredDomains))
+	router.GET("/status", getStatus)
+	router.GET("/ready", getReady)
+	router.GET("/version", getVersion)
+	router.GET("/deadletter", getDeadLetters)
+	router.GET("/filters", getFilters(a.CurrentConfig))
+
+	admin := router.Group("/", requireAuthToken(cfg.API.AuthToken))
+	admin.POST("/pause", pauseRelaying(logger))
+	admin.POST("/resume", resumeRelaying(logger))
+	admin.POST("/requeue/:txHash", requeueTx(logger, processingQueue))
+	admin.GET("/state/export", exportState)
+	admin.POST("/state/import", importState(logger, processingQueue, metrics))
+
+	if cfg.API.TLSCertFile != "" {
+		err = router.RunTLS(cfg.APIListenAddress(), cfg.API.TLSCertFile, cfg.API.TLSKeyFile)
+	} else {
+		err = router.Run(cfg.APIListenAddress())
+	}
 	if err != nil {
 		logger.Error("Unable to start API server: " + err.Error())
 		os.Exit(1)
@@ -447,10 +1316,571 @@ func getTxByHash(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "unable to parse domain"})
 	}
 
-	if tx, ok := State.Load(txHash); ok && domain == "" || (domain != "" && tx.Msgs[0].SourceDomain == types.Domain(uint32(domainInt))) {
+	tx, ok := State.Load(txHash)
+	if !ok {
+		tx, ok = Archive.Load(txHash)
+	}
+	if ok && (domain == "" || (len(tx.Msgs) > 0 && tx.Msgs[0].SourceDomain == types.Domain(uint32(domainInt)))) {
 		c.JSON(http.StatusOK, tx.Msgs)
 		return
 	}
 
 	c.JSON(http.StatusNotFound, gin.H{"message": "message not found"})
 }
+
+// getMessages returns all messages in the state, optionally filtered by status and source domain
+func getMessages(c *gin.Context) {
+	status := c.Query("status")
+
+	domain := c.Query("domain")
+	domainInt, err := strconv.ParseUint(domain, 10, 32)
+	if domain != "" && err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "unable to parse domain"})
+		return
+	}
+
+	msgs := []*types.MessageState{}
+	State.Range(func(_ string, tx *types.TxState) bool {
+		for _, msg := range tx.Msgs {
+			if status != "" && msg.Status != status {
+				continue
+			}
+			if domain != "" && msg.SourceDomain != types.Domain(domainInt) {
+				continue
+			}
+			msgs = append(msgs, msg)
+		}
+		return true
+	})
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Created.Before(msgs[j].Created) })
+
+	c.JSON(http.StatusOK, msgs)
+}
+
+// chainStatus is the /chains view of a registered chain
+type chainStatus struct {
+	Name             string       `json:"name"`
+	Domain           types.Domain `json:"domain"`
+	LatestBlock      uint64       `json:"latest_block"`
+	LastFlushedBlock uint64       `json:"last_flushed_block"`
+	Lag              uint64       `json:"lag"`                // blocks between the latest and the last flushed block
+	BlockTimeSeconds float64      `json:"block_time_seconds"` // rolling average, 0 until known
+}
+
+// getChains returns the latest and last flushed block and the average block time of each registered chain, used to
+// check the listeners keep up and to estimate when Fast Transfer attestations expire
+func getChains(registeredDomains map[types.Domain]types.Chain) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		chains := make([]chainStatus, 0, len(registeredDomains))
+		for domain, chain := range registeredDomains {
+			latest, flushed := chain.LatestBlock(), chain.LastFlushedBlock()
+			var lag uint64
+			if latest > flushed {
+				lag = latest - flushed
+			}
+			chains = append(chains, chainStatus{
+				Name:             chain.Name(),
+				Domain:           domain,
+				LatestBlock:      latest,
+				LastFlushedBlock: flushed,
+				Lag:              lag,
+				BlockTimeSeconds: chain.BlockTime().Seconds(),
+			})
+		}
+		sort.Slice(chains, func(i, j int) bool { return chains[i].Domain < chains[j].Domain })
+
+		c.JSON(http.StatusOK, chains)
+	}
+}
+
+// cors adds the CORS headers to requests from the allowed origins and answers their preflight requests.
+// Without allowed origins cross-origin requests are left to the browser's same-origin policy.
+func cors(allowedOrigins []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || !slices.ContainsFunc(allowedOrigins, func(o string) bool { return o == "*" || o == origin }) {
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		c.Header("Vary", "Origin")
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+// requireAuthToken rejects requests without the configured bearer token. Without a token the endpoints are disabled.
+func requireAuthToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "set api.auth-token to enable this endpoint"})
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte("Bearer "+token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid auth token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// routeQuery parses the optional route query param, nil if it isn't set
+func routeQuery(c *gin.Context) (*types.Route, error) {
+	raw := c.Query("route")
+	if raw == "" {
+		return nil, nil
+	}
+	route, err := types.ParseRoute(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &route, nil
+}
+
+// pauseRelaying stops broadcasting on the route query param, or on every route. Attested messages are held
+// until relaying is resumed.
+func pauseRelaying(logger log.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, err := routeQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		Pause.Pause(route)
+		if route != nil {
+			logger.Info("Relaying paused through the API", "route", route.String())
+		} else {
+			logger.Info("Relaying paused through the API on every route")
+		}
+		c.JSON(http.StatusOK, Pause.Status())
+	}
+}
+
+// resumeRelaying resumes broadcasting on the route query param, or on every route
+func resumeRelaying(logger log.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, err := routeQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		Pause.Resume(route)
+		if route != nil {
+			logger.Info("Relaying resumed through the API", "route", route.String())
+		} else {
+			logger.Info("Relaying resumed through the API on every route")
+		}
+		c.JSON(http.StatusOK, Pause.Status())
+	}
+}
+
+// requeueTx resets the failed messages of a tx and queues it for processing again. Messages that were attested are
+// broadcast again, the others restart from the attestation check.
+func requeueTx(logger log.Logger, processingQueue chan *types.TxState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		txHash := c.Param("txHash")
+		tx, requeued, deadLettered, err := requeueFailedTx(processingQueue, txHash)
+		switch {
+		case errors.Is(err, errTxNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": "tx not found"})
+			return
+		case errors.Is(err, errNoFailedMsgs):
+			c.JSON(http.StatusConflict, gin.H{"message": "tx has no failed messages"})
+			return
+		}
+
+		logger.Info("Failed tx requeued through the API", "tx", txHash, "msgs", requeued, "dead_lettered", deadLettered,
+			"client_ip", c.ClientIP(), "user_agent", c.Request.UserAgent(), "requested_at", time.Now().UTC().Format(time.RFC3339))
+
+		State.Mu.Lock()
+		defer State.Mu.Unlock()
+		c.JSON(http.StatusOK, tx.Msgs)
+	}
+}
+
+var (
+	errTxNotFound   = errors.New("tx not found")
+	errNoFailedMsgs = errors.New("tx has no failed messages")
+)
+
+// requeueFailedTx resets the failed messages of the tx, or takes it out of the dead-letter store with its retries
+// reset, and queues it again. Returns the tx, the number of messages reset and whether it was dead-lettered.
+func requeueFailedTx(processingQueue chan *types.TxState, txHash string) (*types.TxState, int, bool, error) {
+	tx, ok := State.Load(txHash)
+	if !ok {
+		if tx, ok = Archive.Load(txHash); !ok {
+			return nil, 0, false, errTxNotFound
+		}
+	}
+
+	deadLettered := DeadLetters.Remove(txHash)
+	requeued := resetFailedMsgs(tx)
+	if requeued == 0 && !deadLettered {
+		return nil, 0, false, errNoFailedMsgs
+	}
+	// failed txs swept into the archive, before or while they were reset, are moved back to the state to be
+	// retried. Once reset they aren't terminal and won't be swept again.
+	if _, ok := State.Load(txHash); !ok {
+		Archive.Remove(txHash)
+		State.Store(txHash, tx)
+	}
+	if deadLettered {
+		State.Mu.Lock()
+		tx.RetryAttempt = 0
+		tx.PendingWait = 0
+		State.Mu.Unlock()
+	}
+
+	enqueueTx(processingQueue, tx)
+	return tx, requeued, deadLettered, nil
+}
+
+// exportState returns a copy of every tx in the state
+func exportState(c *gin.Context) {
+	txs := State.Snapshot()
+	if txs == nil {
+		txs = []*types.TxState{}
+	}
+	c.JSON(http.StatusOK, txs)
+}
+
+// importState stores exported txs that aren't in the state yet and queues the ones with messages still to process.
+// Imported pending messages are counted in the pending gauges, they are decremented when the messages leave pending.
+func importState(logger log.Logger, processingQueue chan *types.TxState, metrics *relayer.PromMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var txs []*types.TxState
+		if err := c.ShouldBindJSON(&txs); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "unable to parse state: " + err.Error()})
+			return
+		}
+		for _, tx := range txs {
+			if tx == nil || tx.TxHash == "" || slices.Contains(tx.Msgs, nil) {
+				c.JSON(http.StatusBadRequest, gin.H{"message": "every tx needs a TxHash and non-null Msgs"})
+				return
+			}
+		}
+
+		var imported, skipped, requeued int
+		for _, tx := range txs {
+			if _, ok := State.Load(tx.TxHash); ok {
+				skipped++
+				continue
+			}
+			if _, ok := Archive.Load(tx.TxHash); ok {
+				skipped++
+				continue
+			}
+			State.Store(tx.TxHash, tx)
+			imported++
+
+			if metrics != nil {
+				for _, msg := range tx.Msgs {
+					if msg.Status != types.Pending {
+						continue
+					}
+					srcDomain := fmt.Sprint(msg.SourceDomain)
+					destDomain := fmt.Sprint(msg.DestDomain)
+					metrics.IncPending(srcDomain, destDomain)
+					if msg.StuckPending {
+						metrics.IncStuckPending(srcDomain, destDomain)
+					}
+				}
+			}
+
+			if slices.ContainsFunc(tx.Msgs, func(msg *types.MessageState) bool {
+				return !types.IsTerminal(msg.Status)
+			}) {
+				enqueueTx(processingQueue, tx)
+				requeued++
+			}
+		}
+
+		logger.Info("State imported through the API", "imported", imported, "skipped", skipped, "requeued", requeued,
+			"client_ip", c.ClientIP(), "user_agent", c.Request.UserAgent(), "requested_at", time.Now().UTC().Format(time.RFC3339))
+		c.JSON(http.StatusOK, gin.H{"imported": imported, "skipped": skipped, "requeued": requeued})
+	}
+}
+
+// resetFailedMsgs resets the failed messages of tx to attested if they have an attestation, created otherwise,
+// and gives the tx its fetch and re-attestation retries back. Returns the number of messages reset.
+func resetFailedMsgs(tx *types.TxState) int {
+	State.Mu.Lock()
+	defer State.Mu.Unlock()
+
+	var reset int
+	for _, msg := range tx.Msgs {
+		if msg.Status != types.Failed {
+			continue
+		}
+		msg.Status = types.Created
+		if msg.Attestation != "" {
+			msg.Status = types.Attested
+		}
+		msg.FailureReason = ""
+		msg.ReattestCount = 0
+		msg.LastReattestTime = time.Time{}
+		msg.Updated = time.Now()
+		reset++
+	}
+	if reset > 0 {
+		tx.RetryAttempt = 0
+		tx.PendingWait = 0
+	}
+	return reset
+}
+
+// getDeadLetters returns the txs that exhausted their retries, oldest first
+func getDeadLetters(c *gin.Context) {
+	letters := DeadLetters.List()
+
+	State.Mu.Lock()
+	defer State.Mu.Unlock()
+	c.JSON(http.StatusOK, letters)
+}
+
+// getFilters returns the registered filters in the order they are run with the number of messages each dropped,
+// followed by the filters disabled in the config
+func getFilters(currentConfig func() *types.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		statuses := []types.FilterStatus{}
+		if FilterRegistry != nil {
+			statuses = FilterRegistry.Statuses()
+		}
+		for _, filterCfg := range currentConfig().Filters {
+			if !filterCfg.Enabled {
+				statuses = append(statuses, types.FilterStatus{Name: filterCfg.Name, DryRun: filterCfg.DryRun})
+			}
+		}
+		c.JSON(http.StatusOK, statuses)
+	}
+}
+
+// getStatus returns whether broadcasting is paused
+func getStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, Pause.Status())
+}
+
+// readiness is the /ready view of whether Fast Transfers are broadcast
+type readiness struct {
+	Ready bool `json:"ready"`
+	// domain -> whether its Fast Transfer allowance was fetched, empty if the allowance monitor is disabled
+	Allowances map[types.Domain]bool `json:"allowances"`
+}
+
+// getReady returns 200 once no Fast Transfers are held waiting on the allowance monitor, 503 before
+func getReady(c *gin.Context) {
+	r := readiness{Ready: true, Allowances: map[types.Domain]bool{}}
+	if allowanceMonitor != nil {
+		r.Allowances = allowanceMonitor.Readiness()
+		for domain := range r.Allowances {
+			if allowanceMonitor.HoldFastTransfer(domain) {
+				r.Ready = false
+			}
+		}
+	}
+
+	code := http.StatusOK
+	if !r.Ready {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, r)
+}
+
+// attestationCheckConcurrency bounds the concurrent attestation checks of the messages of a tx
+const attestationCheckConcurrency = 4
+
+// attestationCheck is the result of an attestation check made ahead of processing a message
+type attestationCheck struct {
+	response *types.AttestationResponse
+	err      error
+}
+
+// prefetchAttestations checks the attestations of the messages waiting on one concurrently, at most
+// attestationCheckConcurrency at a time, and returns the checks by message index. msgCtxs holds the context of each
+// message, messages with a nil context were dropped and aren't checked. v2 attestations are fetched per source tx,
+// so only the first message of each source domain is checked, the others are answered from the attestation cache.
+// Nothing is prefetched for a single check, it is made while processing the message.
+func prefetchAttestations(cfg types.CircleSettings, logger log.Logger, msgs []*types.MessageState, msgCtxs []context.Context) map[int]attestationCheck {
+	type request struct {
+		index        int
+		irisLookupID string
+		sourceTxHash string
+		sourceDomain types.Domain
+		destDomain   types.Domain
+		ctx          context.Context
+	}
+
+	var requests []request
+	v2Domains := make(map[types.Domain]bool)
+	State.Mu.Lock()
+	for i, msg := range msgs {
+		if msgCtxs[i] == nil || (msg.Status != types.Created && msg.Status != types.Pending) {
+			continue
+		}
+		if version, _ := cfg.GetSourceAPIVersion(msg.SourceDomain); version == types.APIVersionV2 {
+			if v2Domains[msg.SourceDomain] {
+				continue
+			}
+			v2Domains[msg.SourceDomain] = true
+		}
+		requests = append(requests, request{
+			index:        i,
+			irisLookupID: msg.IrisLookupID,
+			sourceTxHash: msg.SourceTxHash,
+			sourceDomain: msg.SourceDomain,
+			destDomain:   msg.DestDomain,
+			ctx:          msgCtxs[i],
+		})
+	}
+	State.Mu.Unlock()
+	if len(requests) < 2 {
+		return nil
+	}
+
+	checks := make([]attestationCheck, len(requests))
+	sem := make(chan struct{}, attestationCheckConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range requests {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, req request) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			checks[i].response, checks[i].err = circle.CheckAttestation(req.ctx, cfg, types.LoggerFromContext(req.ctx, logger),
+				req.irisLookupID, req.sourceTxHash, req.sourceDomain, req.destDomain)
+		}(i, req)
+	}
+	wg.Wait()
+
+	prefetched := make(map[int]attestationCheck, len(requests))
+	for i, req := range requests {
+		prefetched[req.index] = checks[i]
+	}
+	return prefetched
+}
+
+// checkAttestedMessages returns the messages whose bytes match the message Circle returned their attestation for.
+// Mismatched messages are marked as failed and are not broadcast, the mint would revert.
+func checkAttestedMessages(logger log.Logger, msgs []*types.MessageState, metrics *relayer.PromMetrics) []*types.MessageState {
+	matched := make([]*types.MessageState, 0, len(msgs))
+	for _, msg := range msgs {
+		State.Mu.Lock()
+		err := msg.VerifyAttestedMessage()
+		if err != nil {
+			msg.Status = types.Failed
+			msg.FailureReason = "attestation does not match message: " + err.Error()
+			msg.Updated = time.Now()
+		}
+		State.Mu.Unlock()
+
+		if err != nil {
+			types.MessageLogger(logger, msg).Error("Attestation does not match the message, not broadcasting", "tx", msg.SourceTxHash, "error", err)
+			if metrics != nil {
+				metrics.IncAttestation("failed", fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+			}
+			continue
+		}
+		matched = append(matched, msg)
+	}
+	return matched
+}
+
+// maxUnknownStatusLength bounds the length of unknown attestation statuses used as a metric label
+const maxUnknownStatusLength = 32
+
+// unknownAttestationStatus records an attestation response whose status the relayer doesn't handle, e.g. one newly
+// introduced by Circle, and returns why the message is requeued. The tx is requeued like after an error and moved to
+// the dead-letter store once it runs out of fetch-retries.
+func unknownAttestationStatus(logger log.Logger, msg *types.MessageState, status string, metrics *relayer.PromMetrics) error {
+	logger.Error("Attestation has an unknown status, requeueing", "status", status)
+	if metrics != nil {
+		label := status
+		if len(label) > maxUnknownStatusLength {
+			label = label[:maxUnknownStatusLength]
+		}
+		metrics.IncAttestationUnknownStatus(label, fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+	}
+	return fmt.Errorf("attestation for 0x%s has unknown status %q", msg.IrisLookupID, status)
+}
+
+// unknownAttesterReason prefixes the failure reason of messages held because an attester outside the set signed them
+const unknownAttesterReason = "attestation signed by an unknown attester: "
+
+// verifyAttestations checks attestation signatures against the configured attester set and returns the messages
+// that passed. Messages with malformed attestations or fewer valid signatures than the threshold are marked as
+// failed and are not broadcast. Messages signed by an attester outside the set are held instead, as the set is likely
+// out of date after an attester rotation, held reports whether any were.
+func verifyAttestations(
+	ctx context.Context,
+	settings types.CircleSettings,
+	logger log.Logger,
+	msgs []*types.MessageState,
+	metrics *relayer.PromMetrics,
+) (verified []*types.MessageState, held bool) {
+	attesters, err := circle.ParseAttesters(settings.AttesterAddresses)
+	if err != nil {
+		logger.Error("Unable to parse attester addresses, skipping broadcast", "error", err)
+		return nil, false
+	}
+
+	verified = make([]*types.MessageState, 0, len(msgs))
+	for _, msg := range msgs {
+		err := circle.VerifyAttestation(msg.MsgSentBytes, msg.Attestation, attesters, settings.AttesterThreshold)
+		if errors.Is(err, circle.ErrUnknownAttester) {
+			holdUnknownAttester(ctx, logger, msg, err)
+			held = true
+			continue
+		}
+		if err != nil {
+			types.MessageLogger(logger, msg).Error("Attestation verification failed, not broadcasting", "tx", msg.SourceTxHash, "error", err)
+			State.Mu.Lock()
+			msg.Status = types.Failed
+			msg.FailureReason = "attestation verification failed: " + err.Error()
+			msg.Updated = time.Now()
+			State.Mu.Unlock()
+			if metrics != nil {
+				metrics.IncAttestation("failed", fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+			}
+			continue
+		}
+		State.Mu.Lock()
+		if strings.HasPrefix(msg.FailureReason, unknownAttesterReason) {
+			msg.FailureReason = ""
+		}
+		State.Mu.Unlock()
+		verified = append(verified, msg)
+	}
+	return verified, held
+}
+
+// holdUnknownAttester records why a message signed by an attester outside the set is held and alerts on it, once
+func holdUnknownAttester(ctx context.Context, logger log.Logger, msg *types.MessageState, err error) {
+	reason := unknownAttesterReason + err.Error()
+
+	State.Mu.Lock()
+	alerted := msg.FailureReason == reason
+	msg.FailureReason = reason
+	event := notify.NewEvent(msg)
+	State.Mu.Unlock()
+
+	if alerted {
+		return
+	}
+	types.MessageLogger(logger, msg).Error("Attestation signed by an attester outside attester-addresses, holding message until the attester set is updated",
+		"tx", msg.SourceTxHash, "error", err)
+	if Notifier != nil {
+		event.Type = notify.EventUnknownAttester
+		event.Error = err.Error()
+		Notifier.Notify(ctx, event)
+	}
+}
@@ -3,6 +3,7 @@ package cmd_test
 import (
 	"context"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -58,7 +59,7 @@ func TestProcessDisabledCctpRoute(t *testing.T) {
 	sequenceMap := types.NewSequenceMap()
 	processingQueue = make(chan *types.TxState, 10)
 
-	cmd.FilterRegistry = types.NewFilterRegistry(a.Logger)
+	cmd.FilterRegistry = types.NewFilterRegistry(a.Logger, nil)
 	cmd.FilterRegistry.Register(&routeFilterMock{
 		enabledRoutes: map[types.Domain][]types.Domain{0: {1, 2, 3, 4}},
 	})
@@ -87,6 +88,8 @@ func TestProcessDisabledCctpRoute(t *testing.T) {
 	actualState, ok := cmd.State.Load(expectedState.TxHash)
 	require.True(t, ok)
 	require.Equal(t, types.Filtered, actualState.Msgs[0].Status)
+	require.Equal(t, "route", actualState.Msgs[0].FilteredBy)
+	require.Equal(t, "dest not enabled", actualState.Msgs[0].FilterReason)
 }
 
 // created message -> different destination caller -> filtered
@@ -96,7 +99,7 @@ func TestProcessInvalidDestinationCaller(t *testing.T) {
 	sequenceMap := types.NewSequenceMap()
 	processingQueue = make(chan *types.TxState, 10)
 
-	cmd.FilterRegistry = types.NewFilterRegistry(a.Logger)
+	cmd.FilterRegistry = types.NewFilterRegistry(a.Logger, nil)
 	cmd.FilterRegistry.Register(&destCallerFilterMock{
 		registeredDomains: registeredDomains,
 	})
@@ -129,11 +132,57 @@ func TestProcessInvalidDestinationCaller(t *testing.T) {
 	require.Equal(t, types.Filtered, actualState.Msgs[0].Status)
 }
 
+// same tx enqueued twice rapidly -> message only processed once
+func TestProcessDuplicateTx(t *testing.T) {
+	a, registeredDomains := testutil.ConfigSetup(t)
+
+	sequenceMap := types.NewSequenceMap()
+	processingQueue = make(chan *types.TxState, 10)
+
+	filter := &countingFilterMock{delay: time.Second}
+	cmd.FilterRegistry = types.NewFilterRegistry(a.Logger, nil)
+	cmd.FilterRegistry.Register(filter)
+
+	go cmd.StartProcessor(context.TODO(), a, registeredDomains, processingQueue, sequenceMap, nil)
+	go cmd.StartProcessor(context.TODO(), a, registeredDomains, processingQueue, sequenceMap, nil)
+
+	newTx := func() *types.TxState {
+		return &types.TxState{
+			TxHash: "duplicate",
+			Msgs: []*types.MessageState{
+				{
+					SourceTxHash:      "duplicate",
+					IrisLookupID:      "b404f4155166a1fc7ffee145b5cac6d0f798333745289ab1db171344e226ef0c",
+					SourceDomain:      0,
+					DestDomain:        4,
+					DestinationCaller: make([]byte, 32),
+				},
+			},
+		}
+	}
+
+	processingQueue <- newTx()
+	processingQueue <- newTx()
+
+	// both txs are dequeued right away, the duplicate is skipped while the first is held by the filter
+	require.Eventually(t, func() bool {
+		actualState, ok := cmd.State.Load("duplicate")
+		if !ok || len(processingQueue) > 0 {
+			return false
+		}
+		cmd.State.Mu.Lock()
+		defer cmd.State.Mu.Unlock()
+		return actualState.Msgs[0].Status == types.Filtered
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.Equal(t, int32(1), filter.calls.Load())
+}
+
 func TestFilterDisabledCCTPRoutes(t *testing.T) {
 	logger := log.NewLogger(os.Stdout, log.LevelOption(zerolog.DebugLevel))
 	ctx := context.Background()
 
-	filterRegistry := types.NewFilterRegistry(logger)
+	filterRegistry := types.NewFilterRegistry(logger, nil)
 	filterRegistry.Register(&routeFilterMock{
 		enabledRoutes: map[types.Domain][]types.Domain{0: {1, 2}},
 	})
@@ -149,7 +198,7 @@ func TestFilterDisabledCCTPRoutes(t *testing.T) {
 
 	for _, tt := range tests {
 		msg := types.MessageState{SourceDomain: tt.src, DestDomain: tt.dst}
-		filtered, _ := filterRegistry.Filter(ctx, &msg)
+		filtered, _, _, _ := filterRegistry.Filter(ctx, &msg)
 		require.Equal(t, tt.want, filtered)
 	}
 }
@@ -196,3 +245,19 @@ func (f *destCallerFilterMock) Filter(ctx context.Context, msg *types.MessageSta
 	return true, "invalid destination caller", nil
 }
 func (f *destCallerFilterMock) Close() error { return nil }
+
+type countingFilterMock struct {
+	delay time.Duration
+	calls atomic.Int32
+}
+
+func (f *countingFilterMock) Name() string { return "counting" }
+func (f *countingFilterMock) Initialize(ctx context.Context, config map[string]interface{}, logger log.Logger) error {
+	return nil
+}
+func (f *countingFilterMock) Filter(ctx context.Context, msg *types.MessageState) (bool, string, error) {
+	f.calls.Add(1)
+	time.Sleep(f.delay)
+	return true, "counted", nil
+}
+func (f *countingFilterMock) Close() error { return nil }
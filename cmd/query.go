@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const defaultAPIURL = "http://localhost:8000"
+
+func queryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "query",
+		Aliases: []string{"q"},
+		Short:   "Query the message state of a running relayer",
+	}
+
+	cmd.PersistentFlags().String(flagAPIURL, defaultAPIURL, "base url of the relayer api")
+	cmd.PersistentFlags().String(flagDomain, "", "only return messages from this source domain")
+	cmd.PersistentFlags().Bool(flagJSON, false, "return in json format")
+
+	cmd.AddCommand(
+		queryTxCmd(),
+		queryPendingCmd(),
+	)
+
+	return cmd
+}
+
+func queryTxCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tx <hash>",
+		Short: "Prints the messages sent by a source tx",
+		Args:  cobra.ExactArgs(1),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query tx 0x0a1b...
+$ %s query tx 0x0a1b... --domain 0`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQuery(cmd, "/tx/"+url.PathEscape(args[0]), url.Values{})
+		},
+	}
+}
+
+func queryPendingCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pending",
+		Short: "Prints the messages waiting on an attestation",
+		Args:  cobra.NoArgs,
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query pending
+$ %s query pending --domain 4 --json`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQuery(cmd, "/messages", url.Values{"status": {types.Pending}})
+		},
+	}
+}
+
+// runQuery fetches messages from the relayer api and prints them
+func runQuery(cmd *cobra.Command, path string, query url.Values) error {
+	apiURL, err := cmd.Flags().GetString(flagAPIURL)
+	if err != nil {
+		return err
+	}
+	domain, err := cmd.Flags().GetString(flagDomain)
+	if err != nil {
+		return err
+	}
+	jsn, err := cmd.Flags().GetBool(flagJSON)
+	if err != nil {
+		return err
+	}
+
+	if domain != "" {
+		query.Set("domain", domain)
+	}
+
+	msgs, err := fetchMessages(apiURL, path, query)
+	if err != nil {
+		return err
+	}
+
+	if jsn {
+		out, err := json.MarshalIndent(msgs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+		return nil
+	}
+
+	return printMessages(cmd.OutOrStdout(), msgs)
+}
+
+// fetchMessages requests a list of message states from the relayer api
+func fetchMessages(apiURL, path string, query url.Values) ([]*types.MessageState, error) {
+	u := strings.TrimSuffix(apiURL, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach relayer api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("relayer api returned %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return nil, fmt.Errorf("relayer api returned %d", resp.StatusCode)
+	}
+
+	var msgs []*types.MessageState
+	if err := json.Unmarshal(body, &msgs); err != nil {
+		return nil, fmt.Errorf("unable to decode messages: %w", err)
+	}
+	return msgs, nil
+}
+
+// printMessages writes the messages as a table
+func printMessages(w io.Writer, msgs []*types.MessageState) error {
+	if len(msgs) == 0 {
+		_, err := fmt.Fprintln(w, "No messages found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SOURCE TX\tNONCE\tROUTE\tSTATUS\tATTESTED\tEXPIRATION BLOCK\tDEST TX\tUPDATED")
+	for _, msg := range msgs {
+		expiration := "-"
+		if msg.ExpirationBlock > 0 {
+			expiration = fmt.Sprint(msg.ExpirationBlock)
+		}
+		destTx := msg.DestTxHash
+		if destTx == "" {
+			destTx = "-"
+		}
+		attested := "no"
+		if msg.Attestation != "" {
+			attested = "yes"
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%d -> %d\t%s\t%s\t%s\t%s\t%s\n",
+			msg.SourceTxHash,
+			msg.Nonce,
+			msg.SourceDomain,
+			msg.DestDomain,
+			msg.Status,
+			attested,
+			expiration,
+			destTx,
+			msg.Updated.Format(time.RFC3339),
+		)
+	}
+	return tw.Flush()
+}
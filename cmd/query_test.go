@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func newTestAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/tx/:txHash", getTxByHash)
+	router.GET("/messages", getMessages)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestQueryMessages(t *testing.T) {
+	server := newTestAPI(t)
+
+	created := time.Now()
+	State.Store("0xquery1", &types.TxState{TxHash: "0xquery1", Msgs: []*types.MessageState{
+		{SourceTxHash: "0xquery1", Nonce: 1, SourceDomain: 0, DestDomain: 4, Status: types.Pending, Created: created},
+		{SourceTxHash: "0xquery1", Nonce: 2, SourceDomain: 0, DestDomain: 4, Status: types.Complete, Attestation: "0x01", Created: created},
+	}})
+	State.Store("0xquery2", &types.TxState{TxHash: "0xquery2", Msgs: []*types.MessageState{
+		{SourceTxHash: "0xquery2", Nonce: 3, SourceDomain: 4, DestDomain: 0, Status: types.Pending, Created: created.Add(time.Second)},
+	}})
+	t.Cleanup(func() {
+		State.Delete("0xquery1")
+		State.Delete("0xquery2")
+	})
+
+	msgs, err := fetchMessages(server.URL, "/tx/0xquery1", url.Values{})
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	msgs, err = fetchMessages(server.URL, "/messages", url.Values{"status": {types.Pending}})
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	require.Equal(t, uint64(1), msgs[0].Nonce)
+	require.Equal(t, uint64(3), msgs[1].Nonce)
+
+	msgs, err = fetchMessages(server.URL, "/messages", url.Values{"status": {types.Pending}, "domain": {"4"}})
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	require.Equal(t, uint64(3), msgs[0].Nonce)
+
+	_, err = fetchMessages(server.URL, "/messages", url.Values{"domain": {"ethereum"}})
+	require.ErrorContains(t, err, "unable to parse domain")
+
+	_, err = fetchMessages(server.URL, "/tx/0xmissing", url.Values{})
+	require.ErrorContains(t, err, "message not found")
+}
+
+func TestPrintMessages(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printMessages(&out, nil))
+	require.Equal(t, "No messages found\n", out.String())
+
+	out.Reset()
+	require.NoError(t, printMessages(&out, []*types.MessageState{
+		{SourceTxHash: "0xabc", Nonce: 7, SourceDomain: 0, DestDomain: 4, Status: types.Attested, Attestation: "0x01", ExpirationBlock: 1234},
+		{SourceTxHash: "0xdef", Nonce: 8, SourceDomain: 0, DestDomain: 4, Status: types.Complete, DestTxHash: "ABCD"},
+	}))
+	require.Contains(t, out.String(), "EXPIRATION BLOCK")
+	require.Regexp(t, `0xabc\s+7\s+0 -> 4\s+attested\s+yes\s+1234\s+-`, out.String())
+	require.Regexp(t, `0xdef\s+8\s+0 -> 4\s+complete\s+no\s+-\s+ABCD`, out.String())
+}
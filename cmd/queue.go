@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// drainPollInterval is how often drainQueues checks for outstanding txs
+	drainPollInterval = 100 * time.Millisecond
+
+	// defaultShutdownDrainTimeout is used when shutdown-drain-timeout is not set
+	defaultShutdownDrainTimeout = 30 * time.Second
+)
+
+// priorityQueue is drained by the processors before the standard processingQueue, see types.PriorityQueue
+var priorityQueue = types.PriorityQueue
+
+// activeTxs counts txs a processor has dequeued but not finished with, including the requeue delay, and the
+// batches of mints being confirmed in the background.
+var activeTxs atomic.Int64
+
+// drainStarted is closed once the processing queue is drained on shutdown, see startDrain
+var drainStarted = make(chan struct{})
+
+// startDrain stops the processors from requeueing txs that can't be broadcast yet, so the drain only waits for
+// work that can finish. Those txs are left in the state.
+func startDrain() {
+	select {
+	case <-drainStarted:
+	default:
+		close(drainStarted)
+	}
+}
+
+// draining returns true once the drain started
+func draining() bool {
+	select {
+	case <-drainStarted:
+		return true
+	default:
+		return false
+	}
+}
+
+// nextTx blocks until a tx is available, preferring the priority queue over the standard processing queue.
+// It returns false once the context is done.
+func nextTx(ctx context.Context, processingQueue chan *types.TxState) (*types.TxState, bool) {
+	select {
+	case tx := <-priorityQueue:
+		return tx, true
+	default:
+	}
+
+	select {
+	case tx := <-priorityQueue:
+		return tx, true
+	case tx := <-processingQueue:
+		return tx, true
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// enqueueTx places the tx on the priority queue if it contains a Fast Transfer, otherwise on the standard queue.
+func enqueueTx(processingQueue chan *types.TxState, tx *types.TxState) {
+	if tx.HasFastTransfer() {
+		priorityQueue <- tx
+		return
+	}
+	processingQueue <- tx
+}
+
+// requeueAfter puts the tx back on the queue after wait, unless ctx is done first
+func requeueAfter(ctx context.Context, processingQueue chan *types.TxState, tx *types.TxState, wait time.Duration) {
+	select {
+	case <-time.After(wait):
+		enqueueTx(processingQueue, tx)
+	case <-ctx.Done():
+	}
+}
+
+// holdAfter puts a tx that can't be broadcast yet, e.g. waiting on its attestation or a paused route, back on the
+// queue after wait. It is not requeued once the drain started or ctx is done. Returns true if it was requeued.
+func holdAfter(ctx context.Context, processingQueue chan *types.TxState, tx *types.TxState, wait time.Duration) bool {
+	if draining() {
+		return false
+	}
+	select {
+	case <-time.After(wait):
+		enqueueTx(processingQueue, tx)
+		return true
+	case <-drainStarted:
+	case <-ctx.Done():
+	}
+	return false
+}
+
+// outstandingTxs returns the number of txs that are queued or being processed.
+func outstandingTxs(processingQueue chan *types.TxState) int {
+	return len(priorityQueue) + len(processingQueue) + int(activeTxs.Load())
+}
+
+// drainQueues waits for the processor workers to finish all outstanding txs, giving up after timeout.
+// It returns the number of txs that completed during the drain and the number still outstanding.
+func drainQueues(processingQueue chan *types.TxState, timeout time.Duration) (drained int, abandoned int) {
+	initial := outstandingTxs(processingQueue)
+	deadline := time.Now().Add(timeout)
+
+	remaining := initial
+	for remaining > 0 && time.Now().Before(deadline) {
+		time.Sleep(drainPollInterval)
+		remaining = outstandingTxs(processingQueue)
+	}
+
+	return max(initial-remaining, 0), remaining
+}
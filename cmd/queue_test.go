@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func mustNextTx(t *testing.T, processingQueue chan *types.TxState) *types.TxState {
+	t.Helper()
+	tx, ok := nextTx(context.Background(), processingQueue)
+	require.True(t, ok)
+	return tx
+}
+
+// TestNextTx_PrefersPriority verifies fast transfers are dequeued ahead of standard transfers
+func TestNextTx_PrefersPriority(t *testing.T) {
+	processingQueue := make(chan *types.TxState, 10)
+
+	standard := &types.TxState{TxHash: "standard", Msgs: []*types.MessageState{{}}}
+	fast := &types.TxState{TxHash: "fast", Msgs: []*types.MessageState{{ExpirationBlock: 1000}}}
+
+	enqueueTx(processingQueue, standard)
+	enqueueTx(processingQueue, fast)
+
+	require.Equal(t, "fast", mustNextTx(t, processingQueue).TxHash)
+	require.Equal(t, "standard", mustNextTx(t, processingQueue).TxHash)
+}
+
+// TestEnqueueTx_RequeuePreservesPriority verifies a requeued fast transfer returns to the priority queue
+func TestEnqueueTx_RequeuePreservesPriority(t *testing.T) {
+	processingQueue := make(chan *types.TxState, 10)
+
+	fast := &types.TxState{TxHash: "fast", Msgs: []*types.MessageState{{FinalityThreshold: 1000}}}
+
+	enqueueTx(processingQueue, fast)
+	tx := mustNextTx(t, processingQueue)
+	tx.RetryAttempt++
+	enqueueTx(processingQueue, tx)
+
+	require.Empty(t, processingQueue)
+	require.Len(t, priorityQueue, 1)
+	require.Equal(t, "fast", mustNextTx(t, processingQueue).TxHash)
+}
+
+// TestNextTx_ContextDone verifies processors stop waiting for work once the context is done
+func TestNextTx_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, ok := nextTx(ctx, make(chan *types.TxState))
+	require.False(t, ok)
+}
+
+// TestDrainQueues verifies drained and abandoned txs are counted
+func TestDrainQueues(t *testing.T) {
+	processingQueue := make(chan *types.TxState, 10)
+	processingQueue <- &types.TxState{TxHash: "a"}
+	processingQueue <- &types.TxState{TxHash: "b"}
+
+	// consume a single tx, leaving the other queued
+	go func() {
+		<-processingQueue
+	}()
+
+	drained, abandoned := drainQueues(processingQueue, 500*time.Millisecond)
+	require.Equal(t, 1, drained)
+	require.Equal(t, 1, abandoned)
+
+	<-processingQueue
+	drained, abandoned = drainQueues(processingQueue, time.Second)
+	require.Equal(t, 0, drained)
+	require.Equal(t, 0, abandoned)
+}
+
+// TestHoldAfter verifies txs that can't be broadcast yet are requeued until the drain starts, and waits end early
+// once it started
+func TestHoldAfter(t *testing.T) {
+	t.Cleanup(func() { drainStarted = make(chan struct{}) })
+	processingQueue := make(chan *types.TxState, 10)
+	tx := &types.TxState{TxHash: "pending"}
+
+	require.True(t, holdAfter(context.Background(), processingQueue, tx, time.Millisecond))
+	require.Equal(t, "pending", mustNextTx(t, processingQueue).TxHash)
+
+	done := make(chan bool)
+	go func() {
+		done <- holdAfter(context.Background(), processingQueue, tx, time.Hour)
+	}()
+	startDrain()
+	select {
+	case requeued := <-done:
+		require.False(t, requeued)
+	case <-time.After(time.Second):
+		t.Fatal("hold did not end when the drain started")
+	}
+
+	// once draining, held txs are left in the state right away
+	require.False(t, holdAfter(context.Background(), processingQueue, tx, time.Millisecond))
+	require.Empty(t, processingQueue)
+
+	// retries are still requeued during the drain, unless the processors are stopped
+	requeueAfter(context.Background(), processingQueue, tx, time.Millisecond)
+	require.Len(t, processingQueue, 1)
+	<-processingQueue
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	requeueAfter(ctx, processingQueue, tx, time.Hour)
+	require.Empty(t, processingQueue)
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
+	"github.com/strangelove-ventures/noble-cctp-relayer/notify"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// relayPollInterval is how often the relay command checks whether the tx has finished processing
+const relayPollInterval = time.Second
+
+func relayCmd(a *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relay",
+		Short: "Relay the CCTP messages sent by a single source transaction",
+		Long: `Looks up the CCTP messages sent by a source transaction and pushes them through the processing pipeline once.
+Use this to recover transfers that were missed, for example because the relayer was down during the burn.`,
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			a.InitAppState()
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s relay --source-domain 0 --tx-hash 0x0a1b...`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := a.Logger
+			cfg := a.Config
+
+			sourceDomain, err := cmd.Flags().GetUint32(flagSourceDomain)
+			if err != nil {
+				return fmt.Errorf("invalid source domain error=%w", err)
+			}
+
+			txHash, err := cmd.Flags().GetString(flagTxHash)
+			if err != nil {
+				return fmt.Errorf("invalid tx hash error=%w", err)
+			}
+
+			dryRun, err = cmd.Flags().GetBool(flagDryRun)
+			if err != nil {
+				return fmt.Errorf("invalid dry run flag error=%w", err)
+			}
+
+			// metrics are recorded but not exposed so the command can run alongside a relayer
+			metrics := relayer.NewPromMetrics(prometheus.NewRegistry())
+
+			registeredDomains, err := initializeChains(cmd.Context(), a, metrics)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				for _, c := range registeredDomains {
+					if err := c.CloseClients(); err != nil {
+						logger.Error("Error closing clients", "error", err)
+					}
+				}
+			}()
+
+			if err := initializeFilters(cmd.Context(), cfg, logger, registeredDomains, metrics); err != nil {
+				return fmt.Errorf("failed to initialize filters: %w", err)
+			}
+			defer func() {
+				if err := FilterRegistry.Close(); err != nil {
+					logger.Error("Error closing filter registry", "error", err)
+				}
+			}()
+
+			Notifier = notify.New(cfg.Notifications, logger)
+
+			tx, err := fetchTx(cmd.Context(), cfg, logger, registeredDomains, types.Domain(sourceDomain), txHash)
+			if err != nil {
+				return fmt.Errorf("unable to fetch tx %s: %w", txHash, err)
+			}
+			if len(tx.Msgs) == 0 {
+				return fmt.Errorf("no CCTP messages found in tx %s", txHash)
+			}
+			logger.Info("Relaying tx", "tx", tx.TxHash, "source_domain", sourceDomain, "messages", len(tx.Msgs))
+
+			processingQueue := make(chan *types.TxState, 1)
+			enqueueTx(processingQueue, tx)
+
+			processorCtx, cancelProcessor := context.WithCancel(cmd.Context())
+			defer cancelProcessor()
+			go StartProcessor(processorCtx, a, registeredDomains, processingQueue, sequenceMap, metrics)
+
+			if err := waitForTx(cmd.Context(), processingQueue, tx.TxHash); err != nil {
+				return err
+			}
+			cancelProcessor()
+
+			var failed int
+			for _, msg := range tx.Msgs {
+				logger.Info("Relay finished", "iris_lookup_id", msg.IrisLookupID, "dest_domain", msg.DestDomain, "status", msg.Status, "dest_tx", msg.DestTxHash)
+				if msg.Status == types.Failed {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d messages failed to relay", failed, len(tx.Msgs))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint32(flagSourceDomain, 0, "domain of the chain the tx was sent on")
+	cmd.Flags().String(flagTxHash, "", "hash of the source tx that sent the CCTP messages")
+	_ = cmd.MarkFlagRequired(flagSourceDomain)
+	_ = cmd.MarkFlagRequired(flagTxHash)
+
+	return cmd
+}
+
+// fetchTx builds the tx state for a source tx. Messages are read from the source chain when it supports
+// looking up past txs, otherwise they are fetched from Circle's v2 messages api.
+func fetchTx(
+	ctx context.Context,
+	cfg *types.Config,
+	logger log.Logger,
+	registeredDomains map[types.Domain]types.Chain,
+	sourceDomain types.Domain,
+	txHash string,
+) (*types.TxState, error) {
+	if fetcher, ok := registeredDomains[sourceDomain].(types.TxFetcher); ok {
+		return fetcher.FetchTx(ctx, txHash)
+	}
+
+	apiVersion, err := cfg.Circle.GetSourceAPIVersion(sourceDomain)
+	if err != nil {
+		return nil, err
+	}
+	if apiVersion != types.APIVersionV2 {
+		return nil, fmt.Errorf("source domain %d does not support tx lookups, fetching messages from circle requires the v2 api", sourceDomain)
+	}
+
+	responses, err := circle.CheckAttestationV2All(cfg.Circle.AttestationBaseURLs(), logger, txHash, sourceDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	return txStateFromV2Messages(txHash, responses)
+}
+
+// txStateFromV2Messages builds a tx state from the messages returned by Circle's v2 messages api
+func txStateFromV2Messages(txHash string, responses []types.MessageResponseV2) (*types.TxState, error) {
+	tx := &types.TxState{TxHash: txHash}
+	for _, response := range responses {
+		raw, err := hex.DecodeString(strings.TrimPrefix(response.Message, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode message: %w", err)
+		}
+		msg, err := types.NewMessageState(raw, txHash)
+		if err != nil {
+			return nil, err
+		}
+		tx.Msgs = append(tx.Msgs, msg)
+	}
+	return tx, nil
+}
+
+// waitForTx blocks until the processor has picked up the tx and nothing is left queued or in progress
+func waitForTx(ctx context.Context, processingQueue chan *types.TxState, txHash string) error {
+	ticker := time.NewTicker(relayPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, ok := State.Load(txHash); ok && outstandingTxs(processingQueue) == 0 {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	nobletypes "github.com/circlefin/noble-cctp/x/cctp/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func testMessageSentBytes(t *testing.T, nonce uint64) []byte {
+	t.Helper()
+
+	burn := nobletypes.BurnMessage{
+		BurnToken:     make([]byte, 32),
+		MintRecipient: make([]byte, 32),
+		Amount:        math.NewInt(1000000),
+		MessageSender: make([]byte, 32),
+	}
+	body, err := burn.Bytes()
+	require.NoError(t, err)
+
+	msg := nobletypes.Message{
+		SourceDomain:      0,
+		DestinationDomain: 4,
+		Nonce:             nonce,
+		Sender:            make([]byte, 32),
+		Recipient:         make([]byte, 32),
+		DestinationCaller: make([]byte, 32),
+		MessageBody:       body,
+	}
+	raw, err := msg.Bytes()
+	require.NoError(t, err)
+	return raw
+}
+
+func TestTxStateFromV2Messages(t *testing.T) {
+	first := testMessageSentBytes(t, 1)
+	second := testMessageSentBytes(t, 2)
+
+	tx, err := txStateFromV2Messages("0xabc", []types.MessageResponseV2{
+		{Message: "0x" + hex.EncodeToString(first)},
+		{Message: hex.EncodeToString(second)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0xabc", tx.TxHash)
+	require.Len(t, tx.Msgs, 2)
+
+	require.Equal(t, types.Created, tx.Msgs[0].Status)
+	require.Equal(t, types.Domain(0), tx.Msgs[0].SourceDomain)
+	require.Equal(t, types.Domain(4), tx.Msgs[0].DestDomain)
+	require.Equal(t, uint64(1), tx.Msgs[0].Nonce)
+	require.Equal(t, "0xabc", tx.Msgs[0].SourceTxHash)
+	require.Equal(t, first, tx.Msgs[0].MsgSentBytes)
+	require.Equal(t, uint64(2), tx.Msgs[1].Nonce)
+	require.NotEqual(t, tx.Msgs[0].IrisLookupID, tx.Msgs[1].IrisLookupID)
+}
+
+func TestTxStateFromV2MessagesInvalid(t *testing.T) {
+	_, err := txStateFromV2Messages("0xabc", []types.MessageResponseV2{{Message: "0xzz"}})
+	require.Error(t, err)
+
+	_, err = txStateFromV2Messages("0xabc", []types.MessageResponseV2{{Message: "0x1234"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"syscall"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/solana"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// hotReloadable are the top-level config sections applied by a reload. The min-mint-amount of each chain is
+// reloaded as well.
+var hotReloadable = map[string]bool{
+	"chains":              true,
+	"enabled-routes":      true,
+	"min-mint-amounts":    true,
+	"filters":             true,
+	"filter-order":        true,
+	"filter-evaluate-all": true,
+}
+
+// watchConfigReload reloads the config file every time the relayer receives SIGHUP, until ctx is done
+func (a *AppState) watchConfigReload(ctx context.Context, registeredDomains map[types.Domain]types.Chain) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			if err := a.reloadConfig(ctx, registeredDomains); err != nil {
+				a.Logger.Error("Unable to reload config, keeping the running config", "location", a.ConfigPath, "err", err)
+			}
+		}
+	}
+}
+
+// reloadConfig parses and validates the config file, then applies its enabled routes, min mint amounts, filters
+// and filter order by replacing the registered filters and swapping in a new config snapshot, see CurrentConfig.
+// Chain connections and queued messages are left untouched, changes to other sections are logged as requiring a
+// restart. Nothing is applied if the config is invalid.
+func (a *AppState) reloadConfig(ctx context.Context, registeredDomains map[types.Domain]types.Chain) error {
+	cfg, err := ParseConfig(a.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	candidate := &AppState{Config: cfg, Logger: a.Logger, SkipReachabilityChecks: true}
+	if err := candidate.validateConfig(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	report := &configReport{}
+	candidate.preflightChecks(ctx, report)
+	if fatal := report.fatalCount(); fatal > 0 {
+		return fmt.Errorf("%d fatal problem(s), run validate-config for a full report", fatal)
+	}
+
+	filters, err := buildFilters(ctx, cfg, a.Logger, registeredDomains)
+	if err != nil {
+		return err
+	}
+	running := a.CurrentConfig()
+
+	// readers hold on to the snapshot they loaded, the running config is never modified in place
+	snapshot := *running
+	snapshot.EnabledRoutes = cfg.EnabledRoutes
+	snapshot.MinMintAmounts = cfg.MinMintAmounts
+	snapshot.Filters = cfg.Filters
+	snapshot.FilterOrder = cfg.FilterOrder
+	snapshot.FilterEvaluateAll = cfg.FilterEvaluateAll
+	a.reloaded.Store(&snapshot)
+
+	FilterRegistry.SetEvaluateAll(cfg.FilterEvaluateAll)
+	FilterRegistry.Replace(filters)
+
+	for _, section := range restartRequired(running, cfg) {
+		a.Logger.Info("Config change requires a restart to apply", "section", section)
+	}
+	a.Logger.Info("Reloaded config", "location", a.ConfigPath, "filters", len(filters))
+	return nil
+}
+
+// restartRequired returns the config sections that changed from running to reloaded but can't be hot-reloaded
+func restartRequired(running, reloaded *types.Config) []string {
+	var sections []string
+
+	names := make(map[string]bool)
+	for name := range running.Chains {
+		names[name] = true
+	}
+	for name := range reloaded.Chains {
+		names[name] = true
+	}
+	for name := range names {
+		before, after := running.Chains[name], reloaded.Chains[name]
+		if before == nil || after == nil || !reflect.DeepEqual(withoutMinMintAmount(before), withoutMinMintAmount(after)) {
+			sections = append(sections, "chains."+name)
+		}
+	}
+
+	runningValue, reloadedValue := reflect.ValueOf(*running), reflect.ValueOf(*reloaded)
+	for i := 0; i < runningValue.NumField(); i++ {
+		section := runningValue.Type().Field(i).Tag.Get("yaml")
+		if hotReloadable[section] {
+			continue
+		}
+		if !reflect.DeepEqual(runningValue.Field(i).Interface(), reloadedValue.Field(i).Interface()) {
+			sections = append(sections, section)
+		}
+	}
+
+	sort.Strings(sections)
+	return sections
+}
+
+// withoutMinMintAmount returns a copy of the chain config without its hot-reloadable min-mint-amount
+func withoutMinMintAmount(cfg types.ChainConfig) types.ChainConfig {
+	switch cc := cfg.(type) {
+	case *noble.ChainConfig:
+		c := *cc
+		c.MinMintAmount = 0
+		return &c
+	case *solana.ChainConfig:
+		c := *cc
+		c.MinMintAmount = 0
+		return &c
+	case *ethereum.ChainConfig:
+		c := *cc
+		c.MinMintAmount = 0
+		return &c
+	}
+	return cfg
+}
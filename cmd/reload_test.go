@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const reloadTestConfig = `
+chains:
+  noble:
+    domain: 4
+    chain-id: grand-1
+    rpc: http://localhost:26657
+    broadcast-retries: 5
+    broadcast-retry-interval: 5
+    minter-private-key: ` + testPrivateKey + `
+  ethereum:
+    domain: 0
+    chain-id: 1
+    rpc: http://localhost:8545
+    ws: ws://localhost:8546
+    message-transmitter: "` + testMessageTransmitter + `"
+    broadcast-retries: 5
+    broadcast-retry-interval: 5
+    min-mint-amount: 1
+    minter-private-key: ` + testPrivateKey + `
+enabled-routes:
+  0: [4]
+circle:
+  attestation-base-url: https://iris-api.circle.com
+  api-version: v2
+  fetch-retry-interval: 10
+processor-worker-count: 4
+`
+
+func writeReloadConfig(t *testing.T, path, config string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+}
+
+func TestReloadConfig(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeReloadConfig(t, path, reloadTestConfig)
+
+	cfg, err := ParseConfig(path)
+	require.NoError(t, err)
+	a := &AppState{Config: cfg, ConfigPath: path, Logger: log.NewNopLogger()}
+
+	registeredDomains := map[types.Domain]types.Chain{}
+	require.NoError(t, initializeFilters(ctx, cfg, a.Logger, registeredDomains, nil))
+	t.Cleanup(func() { FilterRegistry = nil })
+
+	reverse := &types.MessageState{SourceDomain: 4, DestDomain: 0}
+	_, filteredBy, _, _ := FilterRegistry.Filter(ctx, reverse)
+	require.Equal(t, "route", filteredBy)
+
+	// an invalid config is not applied
+	writeReloadConfig(t, path, strings.NewReplacer(
+		"  0: [4]", "  0: [4]\n  4: [0]",
+		"processor-worker-count: 4", "processor-worker-count: 0",
+	).Replace(reloadTestConfig))
+	require.Error(t, a.reloadConfig(ctx, registeredDomains))
+	_, filteredBy, _, _ = FilterRegistry.Filter(ctx, reverse)
+	require.Equal(t, "route", filteredBy)
+
+	// enabling the reverse route applies to the running filters
+	writeReloadConfig(t, path, strings.Replace(reloadTestConfig, "  0: [4]", "  0: [4]\n  4: [0]", 1))
+	require.NoError(t, a.reloadConfig(ctx, registeredDomains))
+	_, filteredBy, _, _ = FilterRegistry.Filter(ctx, reverse)
+	require.NotEqual(t, "route", filteredBy)
+	require.Equal(t, map[types.Domain][]types.Domain{0: {4}, 4: {0}}, a.CurrentConfig().EnabledRoutes)
+	// the config loaded on startup is left untouched for its readers
+	require.Equal(t, map[types.Domain][]types.Domain{0: {4}}, cfg.EnabledRoutes)
+}
+
+func TestRestartRequired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeReloadConfig(t, path, reloadTestConfig)
+	running, err := ParseConfig(path)
+	require.NoError(t, err)
+
+	// routes and min mint amounts are hot-reloadable
+	writeReloadConfig(t, path, strings.NewReplacer(
+		"  0: [4]", "  0: [4]\n  4: [0]",
+		"min-mint-amount: 1", "min-mint-amount: 1000",
+	).Replace(reloadTestConfig))
+	reloaded, err := ParseConfig(path)
+	require.NoError(t, err)
+	require.Empty(t, restartRequired(running, reloaded))
+
+	writeReloadConfig(t, path, strings.NewReplacer(
+		"ws://localhost:8546", "ws://localhost:9546",
+		"processor-worker-count: 4", "processor-worker-count: 8",
+	).Replace(reloadTestConfig))
+	reloaded, err = ParseConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"chains.ethereum", "processor-worker-count"}, restartRequired(running, reloaded))
+}
@@ -24,8 +24,11 @@ func NewRootCmd() *cobra.Command {
 	// Add commands
 	rootCmd.AddCommand(
 		Start(a),
+		relayCmd(a),
+		queryCmd(),
 		getVersionCmd(),
 		configShowCmd(a),
+		validateConfigCmd(a),
 	)
 
 	addAppPersistantFlags(rootCmd, a)
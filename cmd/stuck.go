@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/notify"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// reportStuckPending warns, once, about a message that has been pending longer than circle.stuck-pending-threshold
+func reportStuckPending(ctx context.Context, cfg types.CircleSettings, msg *types.MessageState, logger log.Logger, metrics *relayer.PromMetrics) {
+	State.Mu.Lock()
+	pendingFor := time.Since(msg.Updated)
+	if msg.Status != types.Pending || msg.StuckPending || pendingFor < cfg.StuckPendingDuration() {
+		State.Mu.Unlock()
+		return
+	}
+	msg.StuckPending = true
+	event := notify.NewEvent(msg)
+	State.Mu.Unlock()
+
+	logger.Error("Attestation is stuck pending", "nonce", msg.Nonce, "tx", msg.SourceTxHash, "pending_for", pendingFor.Round(time.Second))
+	if metrics != nil {
+		metrics.IncStuckPending(fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+	}
+	if Notifier != nil {
+		event.Type = notify.EventStuckPending
+		Notifier.Notify(ctx, event)
+	}
+}
+
+// leavePending clears the stuck flag of a message about to leave the pending status and takes it out of the pending
+// gauges. Must be called with the state locked, before the status changes.
+func leavePending(msg *types.MessageState, metrics *relayer.PromMetrics) {
+	if metrics != nil {
+		srcDomain := fmt.Sprint(msg.SourceDomain)
+		destDomain := fmt.Sprint(msg.DestDomain)
+		if msg.Status == types.Pending {
+			metrics.DecPending(srcDomain, destDomain)
+		}
+		if msg.StuckPending {
+			metrics.DecStuckPending(srcDomain, destDomain)
+		}
+	}
+	msg.StuckPending = false
+}
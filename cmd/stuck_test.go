@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/notify"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []notify.Event
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, event notify.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestReportStuckPending(t *testing.T) {
+	notifier := &recordingNotifier{}
+	Notifier = notifier
+	t.Cleanup(func() { Notifier = nil })
+
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	cfg := types.CircleSettings{StuckPendingThreshold: 60}
+
+	msg := &types.MessageState{Status: types.Pending, SourceDomain: 0, DestDomain: 4, Nonce: 7, SourceTxHash: "0xstuck", Updated: time.Now()}
+	reportStuckPending(context.Background(), cfg, msg, log.NewNopLogger(), m)
+	require.False(t, msg.StuckPending)
+	require.Empty(t, notifier.events)
+
+	// reported once after the threshold
+	msg.Updated = time.Now().Add(-2 * time.Minute)
+	reportStuckPending(context.Background(), cfg, msg, log.NewNopLogger(), m)
+	reportStuckPending(context.Background(), cfg, msg, log.NewNopLogger(), m)
+	require.True(t, msg.StuckPending)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.StuckPending.WithLabelValues("0", "4")))
+	require.Len(t, notifier.events, 1)
+	require.Equal(t, notify.EventStuckPending, notifier.events[0].Type)
+	require.Equal(t, uint64(7), notifier.events[0].Nonce)
+	require.Equal(t, "0xstuck", notifier.events[0].SourceTxHash)
+}
+
+func TestLeavePending(t *testing.T) {
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	m.IncPending("0", "4")
+	m.IncStuckPending("0", "4")
+
+	msg := &types.MessageState{Status: types.Pending, SourceDomain: 0, DestDomain: 4, StuckPending: true}
+	leavePending(msg, m)
+	require.False(t, msg.StuckPending)
+	require.Zero(t, testutil.ToFloat64(m.AttestationPending.WithLabelValues("0", "4")))
+	require.Zero(t, testutil.ToFloat64(m.StuckPending.WithLabelValues("0", "4")))
+
+	// a message that wasn't pending isn't in the gauges
+	msg.Status = types.Created
+	leavePending(msg, m)
+	require.Zero(t, testutil.ToFloat64(m.AttestationPending.WithLabelValues("0", "4")))
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// defaultStateRetention is used when state-retention is not set
+	defaultStateRetention = 24 * time.Hour
+
+	// defaultStateSweepInterval is used when state-sweep-interval is not set
+	defaultStateSweepInterval = 5 * time.Minute
+
+	// defaultStateArchiveSize is used when state-archive-size is not set
+	defaultStateArchiveSize = 10000
+)
+
+// Archive holds the terminal txs swept from the State
+var Archive = types.NewStateArchive(defaultStateArchiveSize)
+
+// startStateSweeper archives the txs whose messages all reached a terminal status more than state-retention ago
+// every state-sweep-interval, until ctx is done
+func startStateSweeper(ctx context.Context, cfg *types.Config, logger log.Logger, metrics *relayer.PromMetrics) {
+	retention := defaultStateRetention
+	if cfg.StateRetention > 0 {
+		retention = time.Duration(cfg.StateRetention) * time.Second
+	}
+	interval := defaultStateSweepInterval
+	if cfg.StateSweepInterval > 0 {
+		interval = time.Duration(cfg.StateSweepInterval) * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			swept, active := sweepState(time.Now().Add(-retention))
+			if swept > 0 {
+				logger.Debug("Archived terminal txs", "swept", swept, "active", active, "archived", Archive.Len())
+			}
+			if metrics != nil {
+				metrics.SetStateTxs("active", active)
+				metrics.SetStateTxs("archived", Archive.Len())
+			}
+		}
+	}
+}
+
+// sweepState moves the txs whose messages are all complete, already-minted, failed or filtered and were last
+// updated before cutoff from the State to the Archive. Txs are checked and moved with the state locked, so a tx
+// requeued while the sweep runs is either still in the State or already in the Archive when it is reset. Returns
+// the number of txs swept and left in the State.
+func sweepState(cutoff time.Time) (swept int, active int) {
+	State.DeleteFunc(func(_ string, tx *types.TxState) bool {
+		if !terminalBefore(tx, cutoff) {
+			active++
+			return false
+		}
+		Archive.Store(tx)
+		swept++
+		return true
+	})
+	return swept, active
+}
+
+// terminalBefore returns whether every message of tx reached a terminal status before cutoff, must be called with
+// the state locked
+func terminalBefore(tx *types.TxState, cutoff time.Time) bool {
+	if len(tx.Msgs) == 0 {
+		return false
+	}
+	for _, msg := range tx.Msgs {
+		if !types.IsTerminal(msg.Status) {
+			return false
+		}
+		if msg.Updated.After(cutoff) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestSweepState(t *testing.T) {
+	Archive = types.NewStateArchive(defaultStateArchiveSize)
+	t.Cleanup(func() { Archive = types.NewStateArchive(defaultStateArchiveSize) })
+
+	old := time.Now().Add(-2 * time.Hour)
+	txs := []*types.TxState{
+		{TxHash: "0xcomplete", Msgs: []*types.MessageState{
+			{Status: types.Complete, Updated: old},
+			{Status: types.Filtered, Updated: old},
+			{Status: types.AlreadyMinted, Updated: old},
+		}},
+		{TxHash: "0xfailed", Msgs: []*types.MessageState{{Status: types.Failed, Updated: old, Attestation: "0x01"}}},
+		{TxHash: "0xrecent", Msgs: []*types.MessageState{{Status: types.Complete, Updated: time.Now()}}},
+		{TxHash: "0xpending", Msgs: []*types.MessageState{
+			{Status: types.Complete, Updated: old},
+			{Status: types.Pending, Updated: old},
+		}},
+	}
+	for _, tx := range txs {
+		State.Store(tx.TxHash, tx)
+		t.Cleanup(func() { State.Delete(tx.TxHash) })
+	}
+
+	swept, active := sweepState(time.Now().Add(-time.Hour))
+	require.Equal(t, 2, swept)
+	require.GreaterOrEqual(t, active, 2)
+
+	_, ok := State.Load("0xcomplete")
+	require.False(t, ok)
+	_, ok = Archive.Load("0xcomplete")
+	require.True(t, ok)
+	_, ok = State.Load("0xrecent")
+	require.True(t, ok)
+	_, ok = State.Load("0xpending")
+	require.True(t, ok)
+
+	// archived failed txs can still be requeued
+	processingQueue := make(chan *types.TxState, 1)
+	_, requeued, _, err := requeueFailedTx(processingQueue, "0xfailed")
+	require.NoError(t, err)
+	require.Equal(t, 1, requeued)
+	tx, ok := State.Load("0xfailed")
+	require.True(t, ok)
+	require.Equal(t, types.Attested, tx.Msgs[0].Status)
+	_, ok = Archive.Load("0xfailed")
+	require.False(t, ok)
+
+	_, _, _, err = requeueFailedTx(processingQueue, "0xcomplete")
+	require.ErrorIs(t, err, errNoFailedMsgs)
+}
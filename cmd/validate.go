@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// reachabilityTimeout bounds how long a chain's endpoints are given to respond during pre-flight checks
+const reachabilityTimeout = 10 * time.Second
+
+// configIssue is a problem found while checking the config
+type configIssue struct {
+	chain   string // empty for settings that are not tied to a chain
+	fatal   bool
+	message string
+}
+
+// configReport collects the problems found by the pre-flight checks
+type configReport struct {
+	issues []configIssue
+}
+
+func (r *configReport) fatalf(chain, format string, args ...any) {
+	r.issues = append(r.issues, configIssue{chain: chain, fatal: true, message: fmt.Sprintf(format, args...)})
+}
+
+func (r *configReport) warnf(chain, format string, args ...any) {
+	r.issues = append(r.issues, configIssue{chain: chain, message: fmt.Sprintf(format, args...)})
+}
+
+// fatalCount returns the number of problems that prevent the relayer from starting
+func (r *configReport) fatalCount() int {
+	var n int
+	for _, issue := range r.issues {
+		if issue.fatal {
+			n++
+		}
+	}
+	return n
+}
+
+// print writes the report, listing fatal problems before warnings
+func (r *configReport) print(w io.Writer) {
+	if len(r.issues) == 0 {
+		fmt.Fprintln(w, "Config is valid")
+		return
+	}
+
+	for _, fatal := range []bool{true, false} {
+		for _, issue := range r.issues {
+			if issue.fatal != fatal {
+				continue
+			}
+			level := "WARN "
+			if issue.fatal {
+				level = "FATAL"
+			}
+			if issue.chain != "" {
+				fmt.Fprintf(w, "%s [%s] %s\n", level, issue.chain, issue.message)
+			} else {
+				fmt.Fprintf(w, "%s %s\n", level, issue.message)
+			}
+		}
+	}
+	fmt.Fprintf(w, "%d fatal problem(s), %d warning(s)\n", r.fatalCount(), len(r.issues)-r.fatalCount())
+}
+
+func validateConfigCmd(a *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:     "validate-config",
+		Aliases: []string{"vc"},
+		Short:   "Checks the config for problems before starting the relayer",
+		Long: `Checks that every domain in enabled-routes has a chain config, that the circle api-version is valid,
+that each chain's private key parses and that each chain's RPC/WS endpoints are reachable.
+Use --skip-reachability-checks to validate offline.`,
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			a.InitLogger()
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s validate-config --config %s
+$ %s validate-config --skip-reachability-checks`, appName, defaultConfigPath, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := ParseConfig(a.ConfigPath)
+			if err != nil {
+				return fmt.Errorf("unable to parse config file %s: %w", a.ConfigPath, err)
+			}
+			a.Config = cfg
+
+			report := &configReport{}
+			if err := a.validateConfig(); err != nil {
+				report.fatalf("", "%v", err)
+			}
+			a.preflightChecks(cmd.Context(), report)
+
+			report.print(cmd.OutOrStdout())
+			if n := report.fatalCount(); n > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("config has %d fatal problem(s)", n)
+			}
+			return nil
+		},
+	}
+}
+
+// runPreflightChecks logs any problems with the loaded config and exits if the relayer cannot start
+func (a *AppState) runPreflightChecks() {
+	report := &configReport{}
+	a.preflightChecks(context.Background(), report)
+
+	for _, issue := range report.issues {
+		if issue.fatal {
+			a.Logger.Error("Pre-flight check failed", "chain", issue.chain, "problem", issue.message)
+		} else {
+			a.Logger.Info("Pre-flight check warning", "chain", issue.chain, "problem", issue.message)
+		}
+	}
+	if report.fatalCount() > 0 {
+		a.Logger.Error("Invalid config, run validate-config for a full report", "location", a.ConfigPath)
+		os.Exit(1)
+	}
+}
+
+// preflightChecks adds the problems found in the loaded config to the report
+func (a *AppState) preflightChecks(ctx context.Context, report *configReport) {
+	cfg := a.Config
+
+	if _, err := cfg.Circle.GetAPIVersion(); err != nil {
+		report.fatalf("", "circle api-version: %v", err)
+	}
+	overrides := make([]types.Domain, 0, len(cfg.Circle.APIVersions))
+	for source := range cfg.Circle.APIVersions {
+		overrides = append(overrides, source)
+	}
+	sort.Slice(overrides, func(i, j int) bool { return overrides[i] < overrides[j] })
+	for _, source := range overrides {
+		if _, err := types.ParseAPIVersion(cfg.Circle.APIVersions[source]); err != nil {
+			report.fatalf("", "circle api-versions of source domain %d: %v", source, err)
+		}
+	}
+	thresholdSources := make([]types.Domain, 0, len(cfg.RouteFinalityThresholds))
+	for source := range cfg.RouteFinalityThresholds {
+		thresholdSources = append(thresholdSources, source)
+	}
+	sort.Slice(thresholdSources, func(i, j int) bool { return thresholdSources[i] < thresholdSources[j] })
+	for _, source := range thresholdSources {
+		if version, err := cfg.Circle.GetSourceAPIVersion(source); err == nil && version != types.APIVersionV2 {
+			report.warnf("", "route-finality-thresholds from source domain %d are only enforced with circle api-version v2", source)
+		}
+	}
+
+	if cfg.Circle.VerifiesAttestations() {
+		if err := circle.ValidateAttesterSet(cfg.Circle.AttesterAddresses, cfg.Circle.AttesterThreshold); err != nil {
+			report.fatalf("", "circle attestation verification: %v", err)
+		}
+	}
+
+	if host, _, err := net.SplitHostPort(cfg.APIListenAddress()); err != nil {
+		report.fatalf("", "api listen-address: %v", err)
+	} else if !isLoopback(host) && cfg.API.TLSCertFile == "" {
+		report.warnf("", "api listens on %s without tls-cert-file, requests and the auth-token are sent in plain text", host)
+	}
+	if err := checkAPITLS(cfg); err != nil {
+		report.fatalf("", "api tls: %v", err)
+	}
+
+	names := make([]string, 0, len(cfg.Chains))
+	for name := range cfg.Chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checkRoutes(cfg, names, report)
+
+	for _, name := range names {
+		if cc, ok := cfg.Chains[name].(*noble.ChainConfig); ok && cc.Domain == nil {
+			report.warnf(name, "domain is not set, defaulting to %d; add `domain: %d` to the chain config", noble.DefaultDomain, noble.DefaultDomain)
+		}
+
+		c, err := cfg.Chains[name].Chain(name)
+		if err != nil {
+			report.fatalf(name, "unable to load chain: %v", err)
+			continue
+		}
+
+		if a.SkipReachabilityChecks {
+			continue
+		}
+		if err := checkReachability(ctx, a.Logger, c); err != nil {
+			report.fatalf(name, "%v", err)
+		}
+	}
+}
+
+// isLoopback returns true if the listen host only accepts connections from the host the relayer runs on
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// checkAPITLS verifies the api tls cert and key are set together and can be loaded
+func checkAPITLS(cfg *types.Config) error {
+	certFile, keyFile := cfg.API.TLSCertFile, cfg.API.TLSKeyFile
+	if certFile == "" && keyFile == "" {
+		return nil
+	}
+	if certFile == "" || keyFile == "" {
+		return errors.New("tls-cert-file and tls-key-file must be set together")
+	}
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return fmt.Errorf("unable to load key pair: %w", err)
+	}
+	return nil
+}
+
+// checkRoutes verifies every domain in the enabled routes has a chain config
+func checkRoutes(cfg *types.Config, names []string, report *configReport) {
+	domains := make(map[types.Domain]string)
+	for _, name := range names {
+		domain, _ := cfg.Chains[name].DomainType()
+		if other, ok := domains[domain]; ok {
+			report.fatalf(name, "domain %d is also configured by chain %s", domain, other)
+			continue
+		}
+		domains[domain] = name
+	}
+
+	sources := make([]types.Domain, 0, len(cfg.EnabledRoutes))
+	for source := range cfg.EnabledRoutes {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i] < sources[j] })
+
+	routed := make(map[types.Domain]bool)
+	for _, source := range sources {
+		if _, ok := domains[source]; !ok {
+			report.fatalf("", "enabled route source domain %d has no chain config, its burns will never be observed", source)
+		}
+		routed[source] = true
+
+		for _, dest := range cfg.EnabledRoutes[source] {
+			if _, ok := domains[dest]; !ok {
+				report.fatalf("", "enabled route %d -> %d has no chain config for domain %d, every message on it will be filtered", source, dest, dest)
+			}
+			routed[dest] = true
+		}
+	}
+
+	for _, name := range names {
+		if domain, _ := cfg.Chains[name].DomainType(); !routed[domain] {
+			report.warnf(name, "domain %d is not part of any enabled route", domain)
+		}
+	}
+}
+
+// checkReachability connects to the chain's endpoints and closes the clients again
+func checkReachability(ctx context.Context, logger log.Logger, c types.Chain) error {
+	ctx, cancel := context.WithTimeout(ctx, reachabilityTimeout)
+	defer cancel()
+
+	if err := c.InitializeClients(ctx, logger); err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.CloseClients(); err != nil {
+			logger.Error("Error closing clients", "chain", c.Name(), "error", err)
+		}
+	}()
+
+	if checker, ok := c.(types.ReachabilityChecker); ok {
+		return checker.CheckReachability(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	testPrivateKey         = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testMessageTransmitter = "0x26413e8157CD32011E726065a5462e97dD4d03D9"
+)
+
+func preflightAppState(cfg *types.Config) *AppState {
+	return &AppState{
+		Config:                 cfg,
+		Logger:                 log.NewNopLogger(),
+		SkipReachabilityChecks: true,
+	}
+}
+
+func TestPreflightChecksValid(t *testing.T) {
+	nobleDomain := types.Domain(4)
+	a := preflightAppState(&types.Config{
+		Chains: map[string]types.ChainConfig{
+			"noble":    &noble.ChainConfig{Domain: &nobleDomain, MinterPrivateKey: testPrivateKey},
+			"ethereum": &ethereum.ChainConfig{Domain: 0, MessageTransmitter: testMessageTransmitter, MinterPrivateKey: testPrivateKey},
+		},
+		EnabledRoutes: map[types.Domain][]types.Domain{0: {4}, 4: {0}},
+		Circle:        types.CircleSettings{APIVersion: "v2"},
+	})
+
+	report := &configReport{}
+	a.preflightChecks(context.Background(), report)
+	require.Empty(t, report.issues)
+
+	var out bytes.Buffer
+	report.print(&out)
+	require.Equal(t, "Config is valid\n", out.String())
+}
+
+func TestPreflightChecksPublicAPI(t *testing.T) {
+	nobleDomain := types.Domain(4)
+	cfg := &types.Config{
+		Chains: map[string]types.ChainConfig{
+			"noble":    &noble.ChainConfig{Domain: &nobleDomain, MinterPrivateKey: testPrivateKey},
+			"ethereum": &ethereum.ChainConfig{Domain: 0, MessageTransmitter: testMessageTransmitter, MinterPrivateKey: testPrivateKey},
+		},
+		EnabledRoutes: map[types.Domain][]types.Domain{0: {4}, 4: {0}},
+		Circle:        types.CircleSettings{APIVersion: "v2"},
+	}
+	cfg.API.ListenAddress = "0.0.0.0:8000"
+
+	report := &configReport{}
+	preflightAppState(cfg).preflightChecks(context.Background(), report)
+	require.Zero(t, report.fatalCount())
+
+	var out bytes.Buffer
+	report.print(&out)
+	require.Contains(t, out.String(), "WARN  api listens on 0.0.0.0 without tls-cert-file")
+
+	// loopback addresses only serve the relayer's host
+	cfg.API.ListenAddress = "127.0.0.1:8000"
+	report = &configReport{}
+	preflightAppState(cfg).preflightChecks(context.Background(), report)
+	require.Empty(t, report.issues)
+}
+
+func TestPreflightChecksProblems(t *testing.T) {
+	cfg := &types.Config{
+		Chains: map[string]types.ChainConfig{
+			"noble":    &noble.ChainConfig{MinterPrivateKey: testPrivateKey},
+			"ethereum": &ethereum.ChainConfig{Domain: 0, MessageTransmitter: testMessageTransmitter, MinterPrivateKey: "not-a-key"},
+			"optimism": &ethereum.ChainConfig{Domain: 2, MessageTransmitter: testMessageTransmitter, MinterPrivateKey: testPrivateKey},
+		},
+		EnabledRoutes: map[types.Domain][]types.Domain{0: {4}, 4: {0, 3}},
+		Circle:        types.CircleSettings{APIVersion: "v3"},
+	}
+	cfg.API.ListenAddress = "8000"
+	a := preflightAppState(cfg)
+
+	report := &configReport{}
+	a.preflightChecks(context.Background(), report)
+	require.Equal(t, 4, report.fatalCount())
+
+	var out bytes.Buffer
+	report.print(&out)
+	require.Contains(t, out.String(), "FATAL circle api-version")
+	require.Contains(t, out.String(), "FATAL enabled route 4 -> 3 has no chain config for domain 3")
+	require.Contains(t, out.String(), "FATAL api listen-address")
+	require.Contains(t, out.String(), "FATAL [ethereum] unable to load chain")
+	require.Contains(t, out.String(), "WARN  [noble] domain is not set, defaulting to 4")
+	require.Contains(t, out.String(), "WARN  [optimism] domain 2 is not part of any enabled route")
+	require.Contains(t, out.String(), "4 fatal problem(s), 2 warning(s)")
+}
+
+func TestCheckRoutesDuplicateDomain(t *testing.T) {
+	cfg := &types.Config{
+		Chains: map[string]types.ChainConfig{
+			"ethereum": &ethereum.ChainConfig{Domain: 0},
+			"sepolia":  &ethereum.ChainConfig{Domain: 0},
+		},
+		EnabledRoutes: map[types.Domain][]types.Domain{7: {0}},
+	}
+
+	report := &configReport{}
+	checkRoutes(cfg, []string{"ethereum", "sepolia"}, report)
+	require.Equal(t, 2, report.fatalCount())
+	require.Equal(t, "sepolia", report.issues[0].chain)
+	require.Contains(t, report.issues[0].message, "also configured by chain ethereum")
+	require.Contains(t, report.issues[1].message, "source domain 7 has no chain config")
+}
+
+func TestCheckRoutesCustomNobleDomain(t *testing.T) {
+	nobleDomain := types.Domain(104)
+	cfg := &types.Config{
+		Chains: map[string]types.ChainConfig{
+			"noble":    &noble.ChainConfig{Domain: &nobleDomain},
+			"ethereum": &ethereum.ChainConfig{Domain: 0},
+		},
+		EnabledRoutes: map[types.Domain][]types.Domain{0: {104}, 104: {0}},
+	}
+
+	report := &configReport{}
+	checkRoutes(cfg, []string{"ethereum", "noble"}, report)
+	require.Empty(t, report.issues)
+
+	cfg.EnabledRoutes = map[types.Domain][]types.Domain{0: {4}}
+	report = &configReport{}
+	checkRoutes(cfg, []string{"ethereum", "noble"}, report)
+	require.Equal(t, 1, report.fatalCount())
+	require.Contains(t, report.issues[0].message, "no chain config for domain 4")
+}
+
+func TestPreflightChecksAPIVersions(t *testing.T) {
+	nobleDomain := types.Domain(4)
+	a := preflightAppState(&types.Config{
+		Chains: map[string]types.ChainConfig{
+			"noble":    &noble.ChainConfig{Domain: &nobleDomain, MinterPrivateKey: testPrivateKey},
+			"ethereum": &ethereum.ChainConfig{Domain: 0, MessageTransmitter: testMessageTransmitter, MinterPrivateKey: testPrivateKey},
+		},
+		EnabledRoutes:           map[types.Domain][]types.Domain{0: {4}, 4: {0}},
+		RouteFinalityThresholds: map[types.Domain]map[types.Domain]uint32{0: {4: 2000}, 4: {0: 2000}},
+		Circle:                  types.CircleSettings{APIVersion: "v2", APIVersions: map[types.Domain]string{0: "v1", 4: "v3"}},
+	})
+
+	report := &configReport{}
+	a.preflightChecks(context.Background(), report)
+	require.Equal(t, 1, report.fatalCount())
+
+	var out bytes.Buffer
+	report.print(&out)
+	require.Contains(t, out.String(), "FATAL circle api-versions of source domain 4")
+	require.Contains(t, out.String(), "WARN  route-finality-thresholds from source domain 0 are only enforced with circle api-version v2")
+	require.NotContains(t, out.String(), "from source domain 4")
+}
+
+func TestCheckAPITLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	cfg := &types.Config{}
+	require.NoError(t, checkAPITLS(cfg))
+
+	cfg.API.TLSCertFile = certFile
+	require.ErrorContains(t, checkAPITLS(cfg), "must be set together")
+
+	cfg.API.TLSKeyFile = filepath.Join(dir, "missing.pem")
+	require.ErrorContains(t, checkAPITLS(cfg), "unable to load key pair")
+
+	cfg.API.TLSKeyFile = keyFile
+	require.NoError(t, checkAPITLS(cfg))
+}
@@ -3,24 +3,48 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"runtime"
 	"strings"
 
+	"github.com/gin-gonic/gin"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 )
 
 var (
 	// Version defines the application version (defined at compile time)
-	Version = ""
-	Commit  = ""
-	Dirty   = ""
+	Version   = ""
+	Commit    = ""
+	Dirty     = ""
+	BuildDate = ""
 )
 
 type versionInfo struct {
-	Version string `json:"version" yaml:"version"`
-	Commit  string `json:"commit" yaml:"commit"`
-	Go      string `json:"go" yaml:"go"`
+	Version   string `json:"version" yaml:"version"`
+	Commit    string `json:"commit" yaml:"commit"`
+	BuildDate string `json:"build_date" yaml:"build_date"`
+	Go        string `json:"go" yaml:"go"`
+}
+
+// getVersionInfo returns the version info injected at compile time
+func getVersionInfo() versionInfo {
+	commit := Commit
+	if Dirty != "0" {
+		commit += " (dirty)"
+	}
+
+	return versionInfo{
+		Version:   Version,
+		Commit:    commit,
+		BuildDate: BuildDate,
+		Go:        fmt.Sprintf("%s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH),
+	}
+}
+
+// getVersion returns the version info of the running relayer
+func getVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, getVersionInfo())
 }
 
 func getVersionCmd() *cobra.Command {
@@ -39,16 +63,7 @@ $ %s v`,
 				return err
 			}
 
-			commit := Commit
-			if Dirty != "0" {
-				commit += " (dirty)"
-			}
-
-			verInfo := versionInfo{
-				Version: Version,
-				Commit:  commit,
-				Go:      fmt.Sprintf("%s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH),
-			}
+			verInfo := getVersionInfo()
 
 			var bz []byte
 			if jsn {
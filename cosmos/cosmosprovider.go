@@ -1,6 +1,7 @@
 package cosmos
 
 import (
+	"net/http"
 	"time"
 
 	"google.golang.org/grpc/encoding"
@@ -24,7 +25,13 @@ type CosmosProvider struct {
 
 // NewProvider validates the CosmosProviderConfig, instantiates a ChainClient and then instantiates a CosmosProvider
 func NewProvider(rpcURL string) (*CosmosProvider, error) {
-	rpcClient, err := newRPCClient(rpcURL, 5*time.Second)
+	return NewProviderWithTransport(rpcURL, nil)
+}
+
+// NewProviderWithTransport instantiates a CosmosProvider whose rpc requests are sent with transport, e.g. to fail
+// over to other rpc endpoints. A nil transport dials rpcURL directly.
+func NewProviderWithTransport(rpcURL string, transport http.RoundTripper) (*CosmosProvider, error) {
+	rpcClient, err := newRPCClient(rpcURL, 5*time.Second, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -38,11 +45,15 @@ func NewProvider(rpcURL string) (*CosmosProvider, error) {
 }
 
 // NewRPCClient initializes a new tendermint RPC client connected to the specified address.
-func newRPCClient(addr string, timeout time.Duration) (*rpchttp.HTTP, error) {
+func newRPCClient(addr string, timeout time.Duration, transport http.RoundTripper) (*rpchttp.HTTP, error) {
 	httpClient, err := libclient.DefaultHTTPClient(addr)
 	if err != nil {
 		return nil, err
 	}
+	// the default transport always dials addr
+	if transport != nil {
+		httpClient.Transport = transport
+	}
 	httpClient.Timeout = timeout
 	rpcClient, err := rpchttp.NewWithClient(addr, "/websocket", httpClient)
 	if err != nil {
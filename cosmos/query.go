@@ -10,6 +10,8 @@ import (
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
@@ -60,6 +62,16 @@ func (cc *CosmosProvider) QueryUsedNonce(ctx context.Context, sourceDomain types
 	return true, nil
 }
 
+// SimulateTx simulates an encoded tx and returns the gas it used
+func (cc *CosmosProvider) SimulateTx(ctx context.Context, txBytes []byte) (uint64, error) {
+	res, err := txtypes.NewServiceClient(cc).Simulate(ctx, &txtypes.SimulateRequest{TxBytes: txBytes})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.GasInfo.GasUsed, nil
+}
+
 // QueryLatestHeight queries the latest height from the RPC client
 func (cc *CosmosProvider) QueryLatestHeight(ctx context.Context) (int64, error) {
 	status, err := cc.RPCClient.Status(ctx)
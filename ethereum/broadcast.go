@@ -2,12 +2,12 @@ package ethereum
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -26,15 +26,43 @@ func (e *Ethereum) InitializeBroadcaster(
 	logger log.Logger,
 	sequenceMap *types.SequenceMap,
 ) error {
-	nextNonce, err := GetEthereumAccountNonce(e.rpcURL, e.minterAddress)
-	if err != nil {
-		return fmt.Errorf("unable to retrieve evm account nonce: %w", err)
+	for _, wallet := range e.wallets {
+		wallet.nonces = newNonceManager(e.domain, wallet.index, sequenceMap, e.rpcClient, common.HexToAddress(wallet.address))
+		if err := wallet.nonces.seed(ctx); err != nil {
+			return fmt.Errorf("unable to retrieve evm account nonce for %s: %w", wallet.address, err)
+		}
+	}
+
+	if e.stuckTxTimeout > 0 {
+		go e.monitorStuckTxs(ctx, logger)
 	}
-	sequenceMap.Put(e.Domain(), uint64(nextNonce))
 
 	return nil
 }
 
+// newTransactor creates the signer and message transmitter binding used to send mints from a wallet
+func (e *Ethereum) newTransactor(wallet *minterWallet) (*bind.TransactOpts, *contracts.MessageTransmitter, error) {
+	backend := NewContractBackendWrapper(e.rpcClient)
+
+	var auth *bind.TransactOpts
+	if wallet.kms != nil {
+		auth = wallet.kms.transactOpts(big.NewInt(e.chainID))
+	} else {
+		var err error
+		auth, err = bind.NewKeyedTransactorWithChainID(wallet.privateKey, big.NewInt(e.chainID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create auth: %w", err)
+		}
+	}
+
+	messageTransmitter, err := contracts.NewMessageTransmitter(common.HexToAddress(e.messageTransmitterAddress), backend)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create message transmitter: %w", err)
+	}
+
+	return auth, messageTransmitter, nil
+}
+
 func (e *Ethereum) Broadcast(
 	ctx context.Context,
 	logger log.Logger,
@@ -44,48 +72,67 @@ func (e *Ethereum) Broadcast(
 ) error {
 	logger = logger.With("chain", e.name, "chain_id", e.chainID, "domain", e.domain)
 
-	backend := NewContractBackendWrapper(e.rpcClient)
-
-	auth, err := bind.NewKeyedTransactorWithChainID(e.privateKey, big.NewInt(e.chainID))
-	if err != nil {
-		return fmt.Errorf("unable to create auth: %w", err)
-	}
-
-	messageTransmitter, err := contracts.NewMessageTransmitter(common.HexToAddress(e.messageTransmitterAddress), backend)
-	if err != nil {
-		return fmt.Errorf("unable to create message transmitter: %w", err)
-	}
-
 	var broadcastErrors error
 MsgLoop:
 	for _, msg := range msgs {
-		attestationBytes, err := hex.DecodeString(msg.Attestation[2:])
+		msgLogger := types.MessageLogger(logger, msg)
+
+		attestation, err := types.NormalizeAttestation(msg.Attestation)
+		if err != nil {
+			return fmt.Errorf("invalid message attestation: %w", err)
+		}
+		attestationBytes, err := types.DecodeAttestation(attestation)
 		if err != nil {
-			return errors.New("unable to decode message attestation")
+			return fmt.Errorf("unable to decode message attestation: %w", err)
 		}
 
 		for attempt := 0; attempt <= e.maxRetries; attempt++ {
 			// check if another worker already broadcasted tx due to flush
-			if msg.Status == types.Complete {
+			e.stateMu.Lock()
+			minted := msg.Status == types.Complete || msg.Status == types.AlreadyMinted
+			e.stateMu.Unlock()
+			if minted {
 				continue MsgLoop
 			}
 
-			if err := e.attemptBroadcast(
+			wallet := e.walletFor(msg)
+			auth, messageTransmitter, err := e.newTransactor(wallet)
+			if err != nil {
+				return err
+			}
+
+			err = e.attemptBroadcast(
 				ctx,
-				logger,
+				msgLogger,
 				msg,
-				sequenceMap,
+				wallet,
 				auth,
 				messageTransmitter,
 				attestationBytes,
-			); err == nil {
+				m,
+			)
+			if err == nil {
+				e.walletPool.Succeeded(wallet.index)
 				continue MsgLoop
 			}
 
+			// retrying won't help until fees come down, leave the message to be requeued
+			if errors.Is(err, errMaxFeeExceeded) {
+				msgLogger.Info("Skipping broadcast until fees are below the configured cap", "src-tx", msg.SourceTxHash, "err", err)
+				broadcastErrors = errors.Join(broadcastErrors, err)
+				continue MsgLoop
+			}
+
+			// skip the wallet until it is funded again so the retry uses the next one
+			if isInsufficientFunds(err) {
+				msgLogger.Error("Minter wallet has insufficient funds, skipping it", "address", wallet.address)
+				e.walletPool.Failed(wallet.index)
+			}
+
 			// if it's not the last attempt, retry
 			// TODO increase the destination.ethereum.broadcast retries (3-5) and retry interval (15s).  By checking for used nonces, there is no gas cost for failed mints.
 			if attempt != e.maxRetries {
-				logger.Info(fmt.Sprintf("Retrying in %d seconds", e.retryIntervalSeconds))
+				msgLogger.Info(fmt.Sprintf("Retrying in %d seconds", e.retryIntervalSeconds))
 				time.Sleep(time.Duration(e.retryIntervalSeconds) * time.Second)
 			}
 		}
@@ -103,10 +150,11 @@ func (e *Ethereum) attemptBroadcast(
 	ctx context.Context,
 	logger log.Logger,
 	msg *types.MessageState,
-	sequenceMap *types.SequenceMap,
+	wallet *minterWallet,
 	auth *bind.TransactOpts,
 	messageTransmitter *contracts.MessageTransmitter,
 	attestationBytes []byte,
+	m *relayer.PromMetrics,
 ) error {
 	logger.Info(fmt.Sprintf(
 		"Broadcasting message from %d to %d: with source tx hash %s",
@@ -114,20 +162,17 @@ func (e *Ethereum) attemptBroadcast(
 		msg.DestDomain,
 		msg.SourceTxHash))
 
-	nonce := sequenceMap.Next(e.domain)
-	auth.Nonce = big.NewInt(int64(nonce))
-
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	// TODO remove
-	nextNonce, err := GetEthereumAccountNonce(e.rpcURL, e.minterAddress)
+	fees, err := e.suggestFees(ctx)
 	if err != nil {
-		logger.Error("unable to retrieve account number")
-	} else {
-		auth.Nonce = big.NewInt(nextNonce)
+		return err
 	}
-	// TODO end remove
+	fees.apply(auth)
+
+	wallet.mu.Lock()
+	defer wallet.mu.Unlock()
+
+	nonce := wallet.nonces.reserve()
+	auth.Nonce = new(big.Int).SetUint64(nonce)
 
 	// check if nonce already used
 	co := &bind.CallOpts{
@@ -135,7 +180,7 @@ func (e *Ethereum) attemptBroadcast(
 		Context: ctx,
 	}
 
-	logger.Debug("Checking if nonce was used for broadcast to Ethereum", "source_domain", msg.SourceDomain, "nonce", msg.Nonce)
+	logger.Debug("Checking if nonce was used for broadcast to Ethereum", "source_domain", msg.SourceDomain)
 
 	key := append(
 		common.LeftPadBytes((big.NewInt(int64(msg.SourceDomain))).Bytes(), 4),
@@ -146,10 +191,17 @@ func (e *Ethereum) attemptBroadcast(
 	if nonceErr != nil {
 		logger.Debug("Error querying whether nonce was used.   Continuing...", "error:", nonceErr)
 	} else if response.Uint64() == uint64(1) {
-		// nonce has already been used, mark as complete
+		wallet.nonces.release(nonce)
+		// our earlier mint was included while waiting on its confirmations, leave it to be confirmed
+		if destTxHash := e.destTxHash(msg); destTxHash != "" && e.mintSucceeded(ctx, destTxHash) {
+			return nil
+		}
+		// nonce has already been used, the message was minted by someone else
 		logger.Debug(fmt.Sprintf("This source domain/nonce has already been used: %d %d",
 			msg.SourceDomain, msg.Nonce), "src-tx", msg.SourceTxHash, "reviever")
-		msg.Status = types.Complete
+		e.stateMu.Lock()
+		msg.Status = types.AlreadyMinted
+		e.stateMu.Unlock()
 		return nil
 	}
 
@@ -160,37 +212,72 @@ func (e *Ethereum) attemptBroadcast(
 		attestationBytes,
 	)
 	if err == nil {
-		msg.Status = types.Complete
+		wallet.nonces.sent(nonce)
+		if e.stuckTxTimeout > 0 {
+			wallet.submitted.track(nonce, tx, msg, attestationBytes)
+		}
+
+		if m != nil {
+			m.ObserveBroadcastGasPrice(e.name, fmt.Sprint(e.domain), weiToGwei(tx.GasFeeCap()))
+		}
 
+		e.stateMu.Lock()
 		msg.DestTxHash = tx.Hash().Hex()
+		e.stateMu.Unlock()
 
-		logger.Info(fmt.Sprintf("Successfully broadcast %s to Ethereum.  Tx hash: %s", msg.SourceTxHash, msg.DestTxHash))
+		logger.Info(fmt.Sprintf("Successfully broadcast %s to Ethereum.  Tx hash: %s", msg.SourceTxHash, tx.Hash().Hex()), "minter", wallet.address)
 
 		return nil
 	}
 
 	logger.Error(fmt.Sprintf("error during broadcast: %s", err.Error()))
+
+	// the tx was not accepted, make the nonce available to the next attempt
+	wallet.nonces.release(nonce)
+
 	if parsedErr, ok := err.(JSONError); ok {
 		if parsedErr.ErrorCode() == 3 && parsedErr.Error() == "execution reverted: Nonce already used" {
-			msg.Status = types.Complete
+			if destTxHash := e.destTxHash(msg); destTxHash != "" && e.mintSucceeded(ctx, destTxHash) {
+				return nil
+			}
+			e.stateMu.Lock()
+			msg.Status = types.AlreadyMinted
+			e.stateMu.Unlock()
 			logger.Error(fmt.Sprintf("This account nonce has already been used: %d", nonce))
 
 			return nil
 		}
+	}
 
-		match, _ := regexp.MatchString("nonce too low: next nonce [0-9]+, tx nonce [0-9]+", parsedErr.Error())
-		if match {
-			numberRegex := regexp.MustCompile("[0-9]+")
-			nextNonce, err := strconv.ParseInt(numberRegex.FindAllString(parsedErr.Error(), 1)[0], 10, 0)
-			if err != nil {
-				nextNonce, err = GetEthereumAccountNonce(e.rpcURL, e.minterAddress)
-				if err != nil {
-					logger.Error("unable to retrieve account number")
-				}
-			}
-			sequenceMap.Put(e.domain, uint64(nextNonce))
+	if match := nonceTooLowRegex.FindStringSubmatch(err.Error()); match != nil {
+		if nextNonce, parseErr := strconv.ParseUint(match[1], 10, 64); parseErr == nil {
+			wallet.nonces.sync(nextNonce)
+			return err
+		}
+	}
+
+	if isNonceCollision(err) {
+		if err := wallet.nonces.resync(ctx); err != nil {
+			logger.Error("Unable to resync account nonce", "err", err)
 		}
 	}
 
 	return err
 }
+
+// destTxHash returns the hash of the message's last mint tx, which the stuck tx monitor replaces concurrently
+func (e *Ethereum) destTxHash(msg *types.MessageState) string {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+	return msg.DestTxHash
+}
+
+var nonceTooLowRegex = regexp.MustCompile("nonce too low: next nonce ([0-9]+), tx nonce [0-9]+")
+
+// isNonceCollision returns true if the broadcast failed because the account nonce was already taken
+func isNonceCollision(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "nonce too low") ||
+		strings.Contains(msg, "replacement transaction underpriced") ||
+		strings.Contains(msg, "already known")
+}
@@ -3,24 +3,59 @@ package ethereum
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"embed"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"cosmossdk.io/log"
 
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
 //go:embed abi/MessageTransmitter.json
 var content embed.FS
 
+// LoadMessageTransmitterABI parses the MessageTransmitter ABI json at path, or the embedded ABI if path is
+// empty. The ABI must define the MessageSent event.
+func LoadMessageTransmitterABI(path string) (abi.ABI, error) {
+	var bz []byte
+	var err error
+	if path == "" {
+		bz, err = content.ReadFile("abi/MessageTransmitter.json")
+	} else {
+		bz, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("unable to read MessageTransmitter abi: %w", err)
+	}
+
+	messageTransmitterABI, err := abi.JSON(bytes.NewReader(bz))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("unable to parse MessageTransmitter abi: %w", err)
+	}
+	if _, ok := messageTransmitterABI.Events["MessageSent"]; !ok {
+		return abi.ABI{}, errors.New("MessageTransmitter abi has no MessageSent event")
+	}
+	return messageTransmitterABI, nil
+}
+
 var _ types.Chain = (*Ethereum)(nil)
+var _ types.BalanceReporter = (*Ethereum)(nil)
+var _ types.ReachabilityChecker = (*Ethereum)(nil)
+var _ types.Resumer = (*Ethereum)(nil)
+var _ types.StateLocker = (*Ethereum)(nil)
 
 type Ethereum struct {
 	// from config
@@ -29,24 +64,52 @@ type Ethereum struct {
 	domain                    types.Domain
 	rpcURL                    string
 	wsURL                     string
+	wsFallbacks               []string
+	rpcFailover               *types.RPCFailover // nil without rpc fallbacks
 	messageTransmitterAddress string
+	tokenMessengerAddress     string
+	messageTransmitterABI     abi.ABI
 	startBlock                uint64
 	lookbackPeriod            uint64
-	privateKey                *ecdsa.PrivateKey
-	minterAddress             string
+	backfillRangeSize         uint64
+	flushBatchSize            uint64
 	maxRetries                int
 	retryIntervalSeconds      int
 	minAmount                 uint64
 	MetricsDenom              string
 	MetricsExponent           int
+	minBalanceAlert           uint64
+	confirmations             uint64
+	maxFeePerGasGwei          uint64
+	stuckTxTimeout            int
 
 	mu sync.Mutex
 
+	// guards the message states updated by the stuck tx monitor, the processor's state lock once set by UseStateLock
+	stateMu sync.Locker
+
+	// txs waiting for enough confirmations before being processed
+	confirmationBuf  *confirmationBuffer
+	confirmationOnce sync.Once
+
+	// recent block hashes and observed txs used to detect reorgs
+	reorgs     *reorgTracker
+	rescanOnce sync.Once
+
+	// set once by StartListener, nil without metrics
+	metrics     *relayer.PromMetrics
+	metricsOnce sync.Once
+
+	// minter accounts mints are broadcast from, picked round-robin by the wallet pool
+	wallets    []*minterWallet
+	walletPool *types.WalletPool
+
 	wsClient  *ethclient.Client
 	rpcClient *ethclient.Client
 
 	latestBlock      uint64
 	lastFlushedBlock uint64
+	blockTime        types.BlockTimeTracker
 }
 
 func NewChain(
@@ -56,38 +119,104 @@ func NewChain(
 	rpcURL string,
 	wsURL string,
 	messageTransmitterAddress string,
+	tokenMessengerAddress string,
+	messageTransmitterABI abi.ABI,
 	startBlock uint64,
 	lookbackPeriod uint64,
-	privateKey string,
+	privateKeys []string,
+	kmsKeyARN string,
 	maxRetries int,
 	retryIntervalSeconds int,
 	minAmount uint64,
 	metricsDenom string,
 	metricsExponent int,
+	confirmations uint64,
+	maxFeePerGasGwei uint64,
+	stuckTxTimeout int,
+	minBalanceAlert uint64,
+	rpcFallbacks []string,
+	wsFallbacks []string,
+	backfillRangeSize uint64,
+	flushBatchSize uint64,
 ) (*Ethereum, error) {
-	privEcdsaKey, ethereumAddress, err := GetEcdsaKeyAddress(privateKey)
-	if err != nil {
-		return nil, err
+	wallets := make([]*minterWallet, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		privEcdsaKey, ethereumAddress, err := GetEcdsaKeyAddress(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		wallets[i] = &minterWallet{
+			index:      i,
+			privateKey: privEcdsaKey,
+			address:    ethereumAddress,
+			submitted:  newSubmittedTxs(),
+		}
+	}
+
+	// a kms key replaces the local minter keys, its address is known once the public key is fetched
+	if kmsKeyARN != "" {
+		signer, err := newKMSSigner(kmsKeyARN)
+		if err != nil {
+			return nil, err
+		}
+		wallets = []*minterWallet{{
+			kms:       signer,
+			submitted: newSubmittedTxs(),
+		}}
+	}
+
+	if backfillRangeSize == 0 {
+		backfillRangeSize = defaultBackfillRangeSize
+	}
+	if flushBatchSize == 0 {
+		flushBatchSize = defaultFlushBatchSize
+	}
+
+	var rpcFailover *types.RPCFailover
+	if len(rpcFallbacks) > 0 {
+		var err error
+		if rpcFailover, err = types.NewRPCFailover(rpcURL, rpcFallbacks, nil); err != nil {
+			return nil, err
+		}
 	}
+
 	return &Ethereum{
 		name:                      name,
 		chainID:                   chainID,
 		domain:                    domain,
 		rpcURL:                    rpcURL,
 		wsURL:                     wsURL,
+		wsFallbacks:               wsFallbacks,
+		rpcFailover:               rpcFailover,
 		messageTransmitterAddress: messageTransmitterAddress,
+		tokenMessengerAddress:     tokenMessengerAddress,
+		messageTransmitterABI:     messageTransmitterABI,
 		startBlock:                startBlock,
 		lookbackPeriod:            lookbackPeriod,
-		privateKey:                privEcdsaKey,
-		minterAddress:             ethereumAddress,
+		backfillRangeSize:         backfillRangeSize,
+		flushBatchSize:            flushBatchSize,
 		maxRetries:                maxRetries,
 		retryIntervalSeconds:      retryIntervalSeconds,
 		minAmount:                 minAmount,
 		MetricsDenom:              metricsDenom,
 		MetricsExponent:           metricsExponent,
+		minBalanceAlert:           minBalanceAlert,
+		confirmations:             confirmations,
+		maxFeePerGasGwei:          maxFeePerGasGwei,
+		stuckTxTimeout:            stuckTxTimeout,
+		wallets:                   wallets,
+		walletPool:                types.NewWalletPool(len(wallets), types.DefaultWalletCooldown),
+		confirmationBuf:           newConfirmationBuffer(),
+		reorgs:                    newReorgTracker(),
+		stateMu:                   &sync.Mutex{},
 	}, nil
 }
 
+// UseStateLock makes the stuck tx monitor hold mu while it updates message states
+func (e *Ethereum) UseStateLock(mu sync.Locker) {
+	e.stateMu = mu
+}
+
 func (e *Ethereum) Name() string {
 	return e.name
 }
@@ -109,46 +238,135 @@ func (e *Ethereum) SetLatestBlock(block uint64) {
 	e.mu.Unlock()
 }
 
+// websocket returns the websocket client, which is replaced when the websocket reconnects
+func (e *Ethereum) websocket() *ethclient.Client {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.wsClient
+}
+
+// BlockTime returns the average block time observed while tracking the latest height, 0 if not known yet
+func (e *Ethereum) BlockTime() time.Duration {
+	return e.blockTime.Average()
+}
+
 func (e *Ethereum) LastFlushedBlock() uint64 {
 	return e.lastFlushedBlock
 }
 
+// Resume makes the listener backfill from block on startup, if it is past the configured start block
+func (e *Ethereum) Resume(block uint64) {
+	e.startBlock = max(e.startBlock, block)
+}
+
 func (e *Ethereum) IsDestinationCaller(destinationCaller []byte) (isCaller bool, readableAddress string) {
 	zeroByteArr := make([]byte, 32)
 
-	decodedMinter, err := hex.DecodeString(strings.ReplaceAll(e.minterAddress, "0x", ""))
-	if err != nil && bytes.Equal(destinationCaller, zeroByteArr) {
-		return true, ""
-	}
-
-	decodedMinterPadded := make([]byte, 32)
-	copy(decodedMinterPadded[12:], decodedMinter)
-
 	encodedCaller := "0x" + hex.EncodeToString(destinationCaller)[24:]
 
-	if bytes.Equal(destinationCaller, zeroByteArr) || bytes.Equal(destinationCaller, decodedMinterPadded) {
+	if bytes.Equal(destinationCaller, zeroByteArr) || e.callerWallet(destinationCaller) != nil {
 		return true, encodedCaller
 	}
 	return false, encodedCaller
 }
+
+// callerWallet returns the minter wallet a destination caller refers to, nil if it matches none
+func (e *Ethereum) callerWallet(destinationCaller []byte) *minterWallet {
+	for _, wallet := range e.wallets {
+		decodedMinter, err := hex.DecodeString(strings.ReplaceAll(wallet.address, "0x", ""))
+		if err != nil {
+			continue
+		}
+
+		decodedMinterPadded := make([]byte, 32)
+		copy(decodedMinterPadded[12:], decodedMinter)
+
+		if bytes.Equal(destinationCaller, decodedMinterPadded) {
+			return wallet
+		}
+	}
+	return nil
+}
+
 func (e *Ethereum) InitializeClients(ctx context.Context, logger log.Logger) error {
 	var err error
 
-	e.wsClient, err = ethclient.DialContext(ctx, e.wsURL)
+	e.wsClient, err = e.dialWebsocket(ctx, logger)
 	if err != nil {
 		return fmt.Errorf("unable to initialize websocket ethereum client; err: %w", err)
 	}
 
-	e.rpcClient, err = ethclient.DialContext(ctx, e.rpcURL)
+	if e.rpcFailover != nil {
+		var client *rpc.Client
+		client, err = rpc.DialOptions(ctx, e.rpcURL, rpc.WithHTTPClient(&http.Client{Transport: e.rpcFailover}))
+		if err == nil {
+			e.rpcClient = ethclient.NewClient(client)
+		}
+	} else {
+		e.rpcClient, err = ethclient.DialContext(ctx, e.rpcURL)
+	}
 	if err != nil {
 		return fmt.Errorf("unable to initialize rpc ethereum client; err: %w", err)
 	}
+
+	for _, wallet := range e.wallets {
+		if wallet.kms == nil {
+			continue
+		}
+		if err := wallet.kms.init(ctx); err != nil {
+			return fmt.Errorf("unable to fetch kms public key: %w", err)
+		}
+		wallet.address = wallet.kms.address.Hex()
+	}
+	return nil
+}
+
+// dialWebsocket connects to the ws, or the first of its fallbacks that is reachable
+func (e *Ethereum) dialWebsocket(ctx context.Context, logger log.Logger) (*ethclient.Client, error) {
+	var err error
+	for i, wsURL := range append([]string{e.wsURL}, e.wsFallbacks...) {
+		var client *ethclient.Client
+		client, err = ethclient.DialContext(ctx, wsURL)
+		if err != nil {
+			logger.Error("Unable to dial websocket", "chain", e.name, "endpoint", types.EndpointLabel(wsURL), "error", err)
+			continue
+		}
+		if i > 0 {
+			logger.Info("Connected to fallback websocket", "chain", e.name, "endpoint", types.EndpointLabel(wsURL))
+		}
+		return client, nil
+	}
+	return nil, err
+}
+
+// CheckReachability queries the latest block over both the rpc and websocket clients
+func (e *Ethereum) CheckReachability(ctx context.Context) error {
+	if _, err := e.rpcClient.BlockNumber(ctx); err != nil {
+		return fmt.Errorf("rpc %s is unreachable: %w", e.rpcURL, err)
+	}
+	if _, err := e.websocket().BlockNumber(ctx); err != nil {
+		return fmt.Errorf("websocket %s is unreachable: %w", e.wsURL, err)
+	}
+
+	contracts := map[string]string{"message-transmitter": e.messageTransmitterAddress}
+	if e.tokenMessengerAddress != "" {
+		contracts["token-messenger"] = e.tokenMessengerAddress
+	}
+	for name, address := range contracts {
+		code, err := e.rpcClient.CodeAt(ctx, common.HexToAddress(address), nil)
+		if err != nil {
+			return fmt.Errorf("unable to get code of %s %s: %w", name, address, err)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("%s %s is not a contract on chain id %d", name, address, e.chainID)
+		}
+	}
 	return nil
 }
 
 func (e *Ethereum) CloseClients() error {
-	if e.wsClient != nil {
-		e.wsClient.Close()
+	if wsClient := e.websocket(); wsClient != nil {
+		wsClient.Close()
 	}
 	if e.rpcClient != nil {
 		e.rpcClient.Close()
@@ -2,8 +2,8 @@ package ethereum
 
 import (
 	"fmt"
-	"os"
-	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
@@ -11,35 +11,83 @@ import (
 var _ types.ChainConfig = (*ChainConfig)(nil)
 
 type ChainConfig struct {
-	RPC                string `yaml:"rpc"`
-	WS                 string `yaml:"ws"`
+	RPC                string   `yaml:"rpc"`
+	WS                 string   `yaml:"ws"`
+	RPCFallbacks       []string `yaml:"rpc-fallbacks"` // tried in order when the rpc fails repeatedly, the rpc is rechecked periodically
+	WSFallbacks        []string `yaml:"ws-fallbacks"`  // dialed in order on startup when the ws is unreachable
 	Domain             types.Domain
-	ChainID            int64  `yaml:"chain-id"`
-	MessageTransmitter string `yaml:"message-transmitter"`
+	ChainType          types.ChainType `yaml:"chain-type"` // defaults to evm
+	ChainID            int64           `yaml:"chain-id"`
+	MessageTransmitter string          `yaml:"message-transmitter"`
+	TokenMessenger     string          `yaml:"token-messenger"`
+	// path to the MessageTransmitter ABI json, defaults to the ABI embedded in the relayer
+	MessageTransmitterABI string `yaml:"message-transmitter-abi"`
 
-	StartBlock     uint64 `yaml:"start-block"`
-	LookbackPeriod uint64 `yaml:"lookback-period"`
+	StartBlock        uint64 `yaml:"start-block"`
+	LookbackPeriod    uint64 `yaml:"lookback-period"`
+	BackfillRangeSize uint64 `yaml:"backfill-range-size"` // blocks per history query, defaults to 100
+	FlushBatchSize    uint64 `yaml:"flush-batch-size"`    // blocks flushed before the last flushed block advances, defaults to 1000
+	Confirmations     uint64 `yaml:"confirmations"`       // blocks to wait before processing a MessageSent log or completing a mint
 
 	BroadcastRetries       int `yaml:"broadcast-retries"`
 	BroadcastRetryInterval int `yaml:"broadcast-retry-interval"`
 
+	MaxFeePerGasGwei uint64 `yaml:"max-fee-per-gas-gwei"` // 0 disables the cap
+	StuckTxTimeout   int    `yaml:"stuck-tx-timeout"`     // seconds before an unconfirmed mint is re-broadcast with more gas, 0 disables
+
 	MinMintAmount uint64 `yaml:"min-mint-amount"`
 
 	MetricsDenom    string `yaml:"metrics-denom"`
 	MetricsExponent int    `yaml:"metrics-exponent"`
+	MinBalanceAlert uint64 `yaml:"min-balance-alert"` // minter balance in wei to alert below, 0 disables
+
+	MinterPrivateKey  string   `yaml:"minter-private-key"`
+	MinterPrivateKeys []string `yaml:"minter-private-keys"` // additional minter wallets broadcasts are spread across
+
+	UseKMS    bool   `yaml:"use-kms"` // sign with an AWS KMS key instead of the minter private keys
+	KMSKeyARN string `yaml:"kms-key-arn"`
+}
 
-	MinterPrivateKey string `yaml:"minter-private-key"`
+func (c *ChainConfig) DomainType() (types.Domain, types.ChainType) {
+	if c.ChainType == "" {
+		return c.Domain, types.ChainTypeEVM
+	}
+	return c.Domain, c.ChainType
+}
+
+// Validate checks the configured contract addresses are hex addresses
+func (c *ChainConfig) Validate(name string) error {
+	if !common.IsHexAddress(c.MessageTransmitter) {
+		return fmt.Errorf("message-transmitter %q of chain %s is not a valid hex address", c.MessageTransmitter, name)
+	}
+	if c.TokenMessenger != "" && !common.IsHexAddress(c.TokenMessenger) {
+		return fmt.Errorf("token-messenger %q of chain %s is not a valid hex address", c.TokenMessenger, name)
+	}
+	return nil
 }
 
 func (c *ChainConfig) Chain(name string) (types.Chain, error) {
-	envKey := strings.ToUpper(name) + "_PRIV_KEY"
-	privKey := os.Getenv(envKey)
-
-	if len(c.MinterPrivateKey) == 0 || len(privKey) != 0 {
-		if len(privKey) == 0 {
-			return nil, fmt.Errorf("env variable %s is empty, priv key not found for chain %s", envKey, name)
-		} else {
-			c.MinterPrivateKey = privKey
+	if err := c.Validate(name); err != nil {
+		return nil, err
+	}
+
+	messageTransmitterABI, err := LoadMessageTransmitterABI(c.MessageTransmitterABI)
+	if err != nil {
+		return nil, fmt.Errorf("chain %s: %w", name, err)
+	}
+
+	var privateKeys []string
+	var kmsKeyARN string
+	if c.UseKMS {
+		if c.KMSKeyARN == "" {
+			return nil, fmt.Errorf("kms-key-arn is required for chain %s when use-kms is enabled", name)
+		}
+		kmsKeyARN = c.KMSKeyARN
+	} else {
+		var err error
+		privateKeys, err = types.MinterPrivateKeys(name, c.MinterPrivateKey, c.MinterPrivateKeys)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -50,13 +98,24 @@ func (c *ChainConfig) Chain(name string) (types.Chain, error) {
 		c.RPC,
 		c.WS,
 		c.MessageTransmitter,
+		c.TokenMessenger,
+		messageTransmitterABI,
 		c.StartBlock,
 		c.LookbackPeriod,
-		c.MinterPrivateKey,
+		privateKeys,
+		kmsKeyARN,
 		c.BroadcastRetries,
 		c.BroadcastRetryInterval,
 		c.MinMintAmount,
 		c.MetricsDenom,
 		c.MetricsExponent,
+		c.Confirmations,
+		c.MaxFeePerGasGwei,
+		c.StuckTxTimeout,
+		c.MinBalanceAlert,
+		c.RPCFallbacks,
+		c.WSFallbacks,
+		c.BackfillRangeSize,
+		c.FlushBatchSize,
 	)
 }
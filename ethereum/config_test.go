@@ -0,0 +1,51 @@
+package ethereum_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
+)
+
+func TestChainConfigValidate(t *testing.T) {
+	cfg := &ethereum.ChainConfig{
+		MessageTransmitter: "0x26413e8157CD32011E726065a5462e97dD4d03D9",
+		TokenMessenger:     "0xD0C3da58f55358142b8d3e06C1C30c5C6114EFE8",
+	}
+	require.NoError(t, cfg.Validate("ethereum"))
+
+	// token messenger is optional
+	cfg.TokenMessenger = ""
+	require.NoError(t, cfg.Validate("ethereum"))
+
+	cfg.TokenMessenger = "0xnothex"
+	require.ErrorContains(t, cfg.Validate("ethereum"), "token-messenger")
+
+	cfg.TokenMessenger = ""
+	cfg.MessageTransmitter = ""
+	require.ErrorContains(t, cfg.Validate("ethereum"), "message-transmitter")
+}
+
+func TestLoadMessageTransmitterABI(t *testing.T) {
+	embedded, err := ethereum.LoadMessageTransmitterABI("")
+	require.NoError(t, err)
+	require.Contains(t, embedded.Events, "MessageSent")
+
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "MessageTransmitter.json")
+	require.NoError(t, os.WriteFile(custom, []byte(`[{"type":"event","name":"MessageSent","inputs":[{"name":"message","type":"bytes","indexed":false}]}]`), 0o600))
+	parsed, err := ethereum.LoadMessageTransmitterABI(custom)
+	require.NoError(t, err)
+	require.Equal(t, embedded.Events["MessageSent"].ID, parsed.Events["MessageSent"].ID)
+
+	noEvent := filepath.Join(dir, "NoEvent.json")
+	require.NoError(t, os.WriteFile(noEvent, []byte(`[]`), 0o600))
+	_, err = ethereum.LoadMessageTransmitterABI(noEvent)
+	require.ErrorContains(t, err, "no MessageSent event")
+
+	_, err = ethereum.LoadMessageTransmitterABI(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
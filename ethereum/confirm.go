@@ -0,0 +1,148 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// mintConfirmationInterval is how often the receipts of broadcast mints are checked
+	mintConfirmationInterval = 5 * time.Second
+
+	// mintConfirmationTimeout is how long to wait for a broadcast mint to be mined and confirmed
+	mintConfirmationTimeout = 30 * time.Minute
+)
+
+var _ types.MintConfirmer = (*Ethereum)(nil)
+
+// WaitForMints waits until the mint txs of msgs are included in a block that has the configured confirmations. Mints
+// that succeeded record their block and are confirmed, reverted mints are failed with the revert reason. The receipt
+// is fetched again on every check, so a mint reorged out of its block is waited on until it is included again. The
+// processor calls it in the background, so it can wait up to mintConfirmationTimeout without holding up a worker.
+func (e *Ethereum) WaitForMints(ctx context.Context, logger log.Logger, msgs []*types.MessageState) error {
+	pending := make([]*types.MessageState, 0, len(msgs))
+	e.stateMu.Lock()
+	for _, msg := range msgs {
+		if msg.DestTxHash != "" && msg.Status != types.AlreadyMinted && msg.Status != types.Failed {
+			pending = append(pending, msg)
+		}
+	}
+	e.stateMu.Unlock()
+
+	ticker := time.NewTicker(mintConfirmationInterval)
+	defer ticker.Stop()
+	timeout := time.After(mintConfirmationTimeout)
+
+	for {
+		var err error
+		pending, err = e.checkMints(ctx, logger, pending)
+		if err != nil {
+			logger.Error("Unable to check mint receipts", "err", err)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			e.stateMu.Lock()
+			first := pending[0].DestTxHash
+			e.stateMu.Unlock()
+			return fmt.Errorf("%d mint tx(s) not confirmed within %s, first %s", len(pending), mintConfirmationTimeout, first)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkMints resolves the mints of msgs that reached the required confirmations and returns the ones still pending.
+// The messages are read and updated with the state locked, the stuck tx monitor may replace a mint meanwhile.
+func (e *Ethereum) checkMints(ctx context.Context, logger log.Logger, msgs []*types.MessageState) ([]*types.MessageState, error) {
+	var pending []*types.MessageState
+	for i, msg := range msgs {
+		// read the hash on every check, a stuck mint may have been replaced
+		e.stateMu.Lock()
+		hash := msg.DestTxHash
+		e.stateMu.Unlock()
+
+		receipt, err := e.rpcClient.TransactionReceipt(ctx, common.HexToHash(hash))
+		if errors.Is(err, ethereum.NotFound) {
+			pending = append(pending, msg)
+			continue
+		}
+		if err != nil {
+			return append(pending, msgs[i:]...), fmt.Errorf("unable to query receipt of %s: %w", hash, err)
+		}
+
+		block := receipt.BlockNumber.Uint64()
+		e.stateMu.Lock()
+		msg.DestBlock = block
+		e.stateMu.Unlock()
+		if !isConfirmed(block, e.LatestBlock(), e.confirmations) {
+			pending = append(pending, msg)
+			continue
+		}
+
+		msgLogger := types.MessageLogger(logger, msg)
+		if receipt.Status == ethtypes.ReceiptStatusSuccessful {
+			e.stateMu.Lock()
+			msg.DestConfirmed = true
+			msg.DestError = ""
+			e.stateMu.Unlock()
+			msgLogger.Debug("Mint tx confirmed", "tx", hash, "block", block, "confirmations", e.confirmations)
+			continue
+		}
+
+		reason := fmt.Sprintf("mint tx %s reverted: %s", hash, e.revertReason(ctx, hash, receipt.BlockNumber))
+		e.stateMu.Lock()
+		msg.Status = types.Failed
+		msg.FailureReason = reason
+		msg.DestError = reason
+		e.stateMu.Unlock()
+		msgLogger.Error("Mint tx reverted", "tx", hash, "block", block, "reason", reason)
+	}
+	return pending, nil
+}
+
+// revertReason replays the reverted tx against the state before its block to recover the revert reason
+func (e *Ethereum) revertReason(ctx context.Context, hash string, blockNumber *big.Int) string {
+	tx, _, err := e.rpcClient.TransactionByHash(ctx, common.HexToHash(hash))
+	if err != nil {
+		return "unknown reason"
+	}
+	from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return "unknown reason"
+	}
+
+	_, err = e.rpcClient.CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}, new(big.Int).Sub(blockNumber, big.NewInt(1)))
+	if err == nil {
+		return "unknown reason"
+	}
+	return strings.TrimPrefix(err.Error(), "execution reverted: ")
+}
+
+// mintSucceeded returns whether the mint tx hash was mined successfully, false if it can't be found
+func (e *Ethereum) mintSucceeded(ctx context.Context, hash string) bool {
+	receipt, err := e.rpcClient.TransactionReceipt(ctx, common.HexToHash(hash))
+	return err == nil && receipt.Status == ethtypes.ReceiptStatusSuccessful
+}
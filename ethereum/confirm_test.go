@@ -0,0 +1,85 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// receiptService serves the eth_getTransactionReceipt calls of an ethclient from fixed receipts
+type receiptService struct {
+	receipts map[common.Hash]*ethtypes.Receipt
+}
+
+func (s *receiptService) GetTransactionReceipt(hash common.Hash) (json.RawMessage, error) {
+	receipt, ok := s.receipts[hash]
+	if !ok {
+		return json.RawMessage("null"), nil
+	}
+	receipt.TxHash = hash
+	receipt.Logs = []*ethtypes.Log{}
+	return receipt.MarshalJSON()
+}
+
+// TestCheckMints verifies mints are only confirmed once their block has the required confirmations
+func TestCheckMints(t *testing.T) {
+	confirmed, reverted, shallow, unmined := common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03"), common.HexToHash("0x04")
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &receiptService{receipts: map[common.Hash]*ethtypes.Receipt{
+		confirmed: {BlockNumber: big.NewInt(100), Status: ethtypes.ReceiptStatusSuccessful},
+		reverted:  {BlockNumber: big.NewInt(100), Status: ethtypes.ReceiptStatusFailed},
+		shallow:   {BlockNumber: big.NewInt(102), Status: ethtypes.ReceiptStatusSuccessful},
+	}}))
+	t.Cleanup(server.Stop)
+
+	e := &Ethereum{rpcClient: ethclient.NewClient(rpc.DialInProc(server)), confirmations: 3, stateMu: &sync.Mutex{}}
+	e.SetLatestBlock(103)
+
+	msgs := []*types.MessageState{
+		{Status: types.Attested, DestTxHash: confirmed.Hex()},
+		{Status: types.Attested, DestTxHash: reverted.Hex()},
+		{Status: types.Attested, DestTxHash: shallow.Hex()},
+		{Status: types.Attested, DestTxHash: unmined.Hex()},
+	}
+	pending, err := e.checkMints(context.Background(), log.NewNopLogger(), msgs)
+	require.NoError(t, err)
+	require.Equal(t, []*types.MessageState{msgs[2], msgs[3]}, pending)
+
+	require.True(t, msgs[0].DestConfirmed)
+	require.Equal(t, uint64(100), msgs[0].DestBlock)
+	require.Equal(t, types.Attested, msgs[0].Status)
+
+	require.Equal(t, types.Failed, msgs[1].Status)
+	require.Contains(t, msgs[1].FailureReason, "reverted")
+	require.Equal(t, msgs[1].FailureReason, msgs[1].DestError)
+
+	// included, but not deep enough yet
+	require.False(t, msgs[2].DestConfirmed)
+	require.Equal(t, uint64(102), msgs[2].DestBlock)
+
+	e.SetLatestBlock(105)
+	pending, err = e.checkMints(context.Background(), log.NewNopLogger(), pending)
+	require.NoError(t, err)
+	require.Equal(t, []*types.MessageState{msgs[3]}, pending)
+	require.True(t, msgs[2].DestConfirmed)
+
+	// already minted and failed messages are not waited on
+	require.NoError(t, e.WaitForMints(context.Background(), log.NewNopLogger(), []*types.MessageState{
+		{Status: types.AlreadyMinted, DestTxHash: unmined.Hex()},
+		{Status: types.Failed, DestTxHash: unmined.Hex()},
+		msgs[0],
+	}))
+}
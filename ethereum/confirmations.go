@@ -0,0 +1,99 @@
+package ethereum
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// confirmationCheckInterval is how often buffered txs are checked for sufficient confirmations
+const confirmationCheckInterval = 2 * time.Second
+
+// confirmationBuffer holds txs observed on chain until they have enough confirmations to be processed
+type confirmationBuffer struct {
+	mu      sync.Mutex
+	pending map[uint64][]*types.TxState // block number -> txs
+}
+
+func newConfirmationBuffer() *confirmationBuffer {
+	return &confirmationBuffer{
+		pending: make(map[uint64][]*types.TxState),
+	}
+}
+
+// add buffers a tx observed at blockNumber
+func (b *confirmationBuffer) add(blockNumber uint64, tx *types.TxState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending[blockNumber] = append(b.pending[blockNumber], tx)
+}
+
+// release removes and returns all txs with at least the given number of confirmations at latestBlock
+func (b *confirmationBuffer) release(latestBlock, confirmations uint64) []*types.TxState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var released []*types.TxState
+	for blockNumber, txs := range b.pending {
+		if isConfirmed(blockNumber, latestBlock, confirmations) {
+			released = append(released, txs...)
+			delete(b.pending, blockNumber)
+		}
+	}
+	return released
+}
+
+// len returns the number of buffered txs
+func (b *confirmationBuffer) len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := 0
+	for _, txs := range b.pending {
+		count += len(txs)
+	}
+	return count
+}
+
+func isConfirmed(blockNumber, latestBlock, confirmations uint64) bool {
+	return latestBlock >= blockNumber && latestBlock-blockNumber >= confirmations
+}
+
+// enqueue passes a tx observed in the block to the processing queue once it has enough confirmations.
+// Txs that are not yet confirmed are buffered and released by releaseConfirmed.
+func (e *Ethereum) enqueue(logger log.Logger, processingQueue chan *types.TxState, tx *types.TxState, blockNumber uint64, blockHash common.Hash) {
+	e.reorgs.observe(blockNumber, blockHash, tx.TxHash)
+
+	if e.confirmations == 0 || isConfirmed(blockNumber, e.LatestBlock(), e.confirmations) {
+		types.Enqueue(logger, processingQueue, tx, e.name, e.domain)
+		return
+	}
+	e.confirmationBuf.add(blockNumber, tx)
+}
+
+// releaseConfirmed periodically moves buffered txs that have reached the required confirmations to the processing queue
+func (e *Ethereum) releaseConfirmed(ctx context.Context, logger log.Logger, processingQueue chan *types.TxState) {
+	ticker := time.NewTicker(confirmationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released := e.confirmationBuf.release(e.LatestBlock(), e.confirmations)
+			if len(released) > 0 {
+				logger.Debug("Releasing confirmed txs", "count", len(released), "still_pending", e.confirmationBuf.len())
+			}
+			for _, tx := range released {
+				types.Enqueue(logger, processingQueue, tx, e.name, e.domain)
+			}
+		}
+	}
+}
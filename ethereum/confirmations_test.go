@@ -0,0 +1,51 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestConfirmationBuffer verifies txs are only released once they reach the required confirmations
+func TestConfirmationBuffer(t *testing.T) {
+	buf := newConfirmationBuffer()
+	buf.add(100, &types.TxState{TxHash: "a"})
+	buf.add(105, &types.TxState{TxHash: "b"})
+	require.Equal(t, 2, buf.len())
+
+	require.Empty(t, buf.release(105, 10))
+
+	released := buf.release(110, 10)
+	require.Len(t, released, 1)
+	require.Equal(t, "a", released[0].TxHash)
+	require.Equal(t, 1, buf.len())
+
+	released = buf.release(115, 10)
+	require.Len(t, released, 1)
+	require.Equal(t, "b", released[0].TxHash)
+	require.Zero(t, buf.len())
+}
+
+// TestEnqueue_Confirmations verifies unconfirmed txs are buffered instead of queued
+func TestEnqueue_Confirmations(t *testing.T) {
+	e := &Ethereum{confirmations: 5, confirmationBuf: newConfirmationBuffer(), reorgs: newReorgTracker()}
+	e.SetLatestBlock(100)
+	processingQueue := make(chan *types.TxState, 10)
+
+	e.enqueue(log.NewNopLogger(), processingQueue, &types.TxState{TxHash: "confirmed"}, 95, common.Hash{})
+	e.enqueue(log.NewNopLogger(), processingQueue, &types.TxState{TxHash: "unconfirmed"}, 98, common.Hash{})
+
+	require.Len(t, processingQueue, 1)
+	require.Equal(t, "confirmed", (<-processingQueue).TxHash)
+	require.Equal(t, 1, e.confirmationBuf.len())
+
+	// no confirmations required
+	e = &Ethereum{confirmationBuf: newConfirmationBuffer(), reorgs: newReorgTracker()}
+	e.enqueue(log.NewNopLogger(), processingQueue, &types.TxState{TxHash: "immediate"}, 1000, common.Hash{})
+	require.Len(t, processingQueue, 1)
+}
@@ -0,0 +1,104 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// errMaxFeeExceeded is returned when the current network fees are above the configured cap.
+// The message is left to be requeued instead of broadcasting a tx that won't be included.
+var errMaxFeeExceeded = errors.New("network fees exceed configured max fee per gas")
+
+// txFees are the EIP-1559 fee fields used for a broadcast, or the legacy gas price on chains without EIP-1559
+type txFees struct {
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+	gasPrice  *big.Int // set instead of the EIP-1559 fields for legacy txs
+}
+
+// apply sets the fees on the transactor, a legacy tx is sent when the gas price is set
+func (f txFees) apply(auth *bind.TransactOpts) {
+	if f.gasPrice != nil {
+		auth.GasPrice = f.gasPrice
+		return
+	}
+	auth.GasFeeCap = f.gasFeeCap
+	auth.GasTipCap = f.gasTipCap
+}
+
+// maxFee returns the most the tx pays per gas
+func (f txFees) maxFee() *big.Int {
+	if f.gasPrice != nil {
+		return f.gasPrice
+	}
+	return f.gasFeeCap
+}
+
+// computeDynamicFees derives the max fee from the latest base fee and suggested tip. The max fee leaves room
+// for the base fee to double before the tx is priced out. If maxFeeCap is non-zero the max fee is clamped to it,
+// and errMaxFeeExceeded is returned when the cap can't even cover the current base fee plus tip.
+func computeDynamicFees(baseFee, tip, maxFeeCap *big.Int) (txFees, error) {
+	gasFeeCap := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tip)
+
+	if maxFeeCap != nil && maxFeeCap.Sign() > 0 {
+		if minFee := new(big.Int).Add(baseFee, tip); minFee.Cmp(maxFeeCap) > 0 {
+			return txFees{}, fmt.Errorf("%w: base fee %s + tip %s wei > cap %s wei", errMaxFeeExceeded, baseFee, tip, maxFeeCap)
+		}
+		if gasFeeCap.Cmp(maxFeeCap) > 0 {
+			gasFeeCap = new(big.Int).Set(maxFeeCap)
+		}
+	}
+
+	return txFees{gasFeeCap: gasFeeCap, gasTipCap: tip}, nil
+}
+
+// computeLegacyFees uses the suggested gas price for chains without EIP-1559. If maxFeeCap is non-zero,
+// errMaxFeeExceeded is returned when the gas price is above it, a lower price wouldn't be included.
+func computeLegacyFees(gasPrice, maxFeeCap *big.Int) (txFees, error) {
+	if maxFeeCap != nil && maxFeeCap.Sign() > 0 && gasPrice.Cmp(maxFeeCap) > 0 {
+		return txFees{}, fmt.Errorf("%w: gas price %s wei > cap %s wei", errMaxFeeExceeded, gasPrice, maxFeeCap)
+	}
+	return txFees{gasPrice: gasPrice}, nil
+}
+
+// suggestFees queries the node for the latest base fee and suggested tip, or the suggested gas price if the chain
+// doesn't support EIP-1559
+func (e *Ethereum) suggestFees(ctx context.Context) (txFees, error) {
+	head, err := e.rpcClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return txFees{}, fmt.Errorf("unable to query latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		gasPrice, err := e.rpcClient.SuggestGasPrice(ctx)
+		if err != nil {
+			return txFees{}, fmt.Errorf("unable to query suggested gas price: %w", err)
+		}
+		return computeLegacyFees(gasPrice, e.maxFeePerGas())
+	}
+
+	tip, err := e.rpcClient.SuggestGasTipCap(ctx)
+	if err != nil {
+		return txFees{}, fmt.Errorf("unable to query suggested gas tip: %w", err)
+	}
+
+	return computeDynamicFees(head.BaseFee, tip, e.maxFeePerGas())
+}
+
+// maxFeePerGas returns the configured max fee cap in wei, nil if uncapped
+func (e *Ethereum) maxFeePerGas() *big.Int {
+	if e.maxFeePerGasGwei == 0 {
+		return nil
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(e.maxFeePerGasGwei), big.NewInt(params.GWei))
+}
+
+// weiToGwei converts a wei amount to gwei for metrics
+func weiToGwei(wei *big.Int) float64 {
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.GWei)).Float64()
+	return gwei
+}
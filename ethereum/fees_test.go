@@ -0,0 +1,45 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/stretchr/testify/require"
+)
+
+// TestComputeDynamicFees verifies the max fee is derived from the base fee and clamped to the configured cap
+func TestComputeDynamicFees(t *testing.T) {
+	baseFee, tip := big.NewInt(100), big.NewInt(10)
+
+	// uncapped, room for the base fee to double
+	fees, err := computeDynamicFees(baseFee, tip, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(210), fees.gasFeeCap)
+	require.Equal(t, tip, fees.gasTipCap)
+
+	// clamped to the cap
+	fees, err = computeDynamicFees(baseFee, tip, big.NewInt(150))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(150), fees.gasFeeCap)
+
+	// cap can't cover the current base fee and tip
+	_, err = computeDynamicFees(baseFee, tip, big.NewInt(105))
+	require.ErrorIs(t, err, errMaxFeeExceeded)
+}
+
+// TestComputeLegacyFees verifies chains without EIP-1559 use the gas price up to the configured cap
+func TestComputeLegacyFees(t *testing.T) {
+	fees, err := computeLegacyFees(big.NewInt(100), nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), fees.gasPrice)
+	require.Nil(t, fees.gasFeeCap)
+
+	auth := &bind.TransactOpts{}
+	fees.apply(auth)
+	require.Equal(t, big.NewInt(100), auth.GasPrice)
+	require.Nil(t, auth.GasFeeCap)
+
+	_, err = computeLegacyFees(big.NewInt(100), big.NewInt(99))
+	require.ErrorIs(t, err, errMaxFeeExceeded)
+}
@@ -0,0 +1,38 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var _ types.TxFetcher = (*Ethereum)(nil)
+
+// FetchTx returns the MessageSent events emitted by the message transmitter in the source tx
+func (e *Ethereum) FetchTx(ctx context.Context, txHash string) (*types.TxState, error) {
+	messageTransmitterABI := e.messageTransmitterABI
+	messageSent := messageTransmitterABI.Events["MessageSent"]
+	messageTransmitterAddress := common.HexToAddress(e.messageTransmitterAddress)
+
+	receipt, err := e.rpcClient.TransactionReceipt(ctx, common.HexToHash(txHash))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get receipt for tx %s: %w", txHash, err)
+	}
+
+	txState := &types.TxState{TxHash: receipt.TxHash.Hex()}
+	for _, log := range receipt.Logs {
+		if log.Address != messageTransmitterAddress || len(log.Topics) == 0 || log.Topics[0] != messageSent.ID {
+			continue
+		}
+		msg, err := types.EvmLogToMessageState(messageTransmitterABI, messageSent, log)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse MessageSent log %d: %w", log.Index, err)
+		}
+		txState.Msgs = append(txState.Msgs, msg)
+	}
+
+	return txState, nil
+}
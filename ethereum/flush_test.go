@@ -0,0 +1,16 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushBatches(t *testing.T) {
+	require.Equal(t, [][2]uint64{{100, 1100}, {1100, 2100}, {2100, 2500}}, flushBatches(100, 2500, 1000))
+
+	// a range smaller than the batch size is flushed at once
+	require.Equal(t, [][2]uint64{{100, 150}}, flushBatches(100, 150, 1000))
+
+	require.Empty(t, flushBatches(150, 150, 1000))
+}
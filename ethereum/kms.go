@@ -0,0 +1,149 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// kmsSignTimeout bounds a single KMS Sign request, bind's signer callback carries no context
+const kmsSignTimeout = 10 * time.Second
+
+// kmsAPI is the part of the KMS client used for signing
+type kmsAPI interface {
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+}
+
+// kmsSigner signs txs with an asymmetric ECC_SECG_P256K1 AWS KMS key. The private key never leaves KMS,
+// the sender address is derived from the key's public key.
+type kmsSigner struct {
+	client    kmsAPI
+	keyARN    string
+	publicKey *ecdsa.PublicKey
+	address   common.Address
+}
+
+// newKMSSigner creates a signer for the key ARN in the key's region. Credentials are resolved by the AWS SDK's
+// default chain, e.g. the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY env variables, a shared profile or an
+// instance role.
+func newKMSSigner(keyARN string) (*kmsSigner, error) {
+	// arn:aws:kms:<region>:<account>:key/<key-id>
+	parts := strings.Split(keyARN, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "kms" || parts[3] == "" {
+		return nil, fmt.Errorf("invalid kms key arn %q", keyARN)
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(parts[3]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load aws config: %w", err)
+	}
+
+	return &kmsSigner{
+		client: kms.NewFromConfig(cfg),
+		keyARN: keyARN,
+	}, nil
+}
+
+// init fetches the public key of the KMS key and derives the sender address
+func (k *kmsSigner) init(ctx context.Context) error {
+	res, err := k.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(k.keyARN)})
+	if err != nil {
+		return fmt.Errorf("kms GetPublicKey failed: %w", err)
+	}
+
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(res.PublicKey, &spki); err != nil {
+		return fmt.Errorf("unable to parse kms public key: %w", err)
+	}
+
+	publicKey, err := crypto.UnmarshalPubkey(spki.PublicKey.Bytes)
+	if err != nil {
+		return fmt.Errorf("kms key is not a secp256k1 key: %w", err)
+	}
+
+	k.publicKey = publicKey
+	k.address = crypto.PubkeyToAddress(*publicKey)
+	return nil
+}
+
+// signHash returns the 65 byte [R || S || V] signature of hash
+func (k *kmsSigner) signHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := k.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(k.keyARN),
+		Message:          hash,
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kms Sign failed: %w", err)
+	}
+
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(res.Signature, &sig); err != nil {
+		return nil, fmt.Errorf("unable to parse kms signature: %w", err)
+	}
+
+	// ethereum only accepts the lower of the two valid S values
+	n := crypto.S256().Params().N
+	if sig.S.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		sig.S = new(big.Int).Sub(n, sig.S)
+	}
+
+	signature := make([]byte, 65)
+	sig.R.FillBytes(signature[:32])
+	sig.S.FillBytes(signature[32:64])
+
+	// KMS doesn't return the recovery id, find the one that recovers our key
+	for v := byte(0); v < 2; v++ {
+		signature[64] = v
+		recovered, err := crypto.SigToPub(hash, signature)
+		if err == nil && crypto.PubkeyToAddress(*recovered) == k.address {
+			return signature, nil
+		}
+	}
+	return nil, errors.New("kms signature does not recover to the kms key address")
+}
+
+// transactOpts returns transact opts that sign txs through KMS
+func (k *kmsSigner) transactOpts(chainID *big.Int) *bind.TransactOpts {
+	signer := ethtypes.LatestSignerForChainID(chainID)
+	return &bind.TransactOpts{
+		From: k.address,
+		Signer: func(address common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			if address != k.address {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), kmsSignTimeout)
+			defer cancel()
+
+			signature, err := k.signHash(ctx, signer.Hash(tx).Bytes())
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(signer, signature)
+		},
+		Context: context.Background(),
+	}
+}
@@ -0,0 +1,95 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKMS signs with a local key, returning the high S value on every other signature
+type fakeKMS struct {
+	t     *testing.T
+	key   *ecdsa.PrivateKey
+	signs int
+}
+
+func (f *fakeKMS) GetPublicKey(_ context.Context, params *kms.GetPublicKeyInput, _ ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error) {
+	require.Equal(f.t, "arn:aws:kms:us-east-1:123456789012:key/test", aws.ToString(params.KeyId))
+
+	der, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}},
+		PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&f.key.PublicKey), BitLength: 65 * 8},
+	})
+	require.NoError(f.t, err)
+	return &kms.GetPublicKeyOutput{PublicKey: der}, nil
+}
+
+func (f *fakeKMS) Sign(_ context.Context, params *kms.SignInput, _ ...func(*kms.Options)) (*kms.SignOutput, error) {
+	require.Equal(f.t, kmstypes.MessageTypeDigest, params.MessageType)
+
+	sig, err := crypto.Sign(params.Message, f.key)
+	require.NoError(f.t, err)
+
+	s := new(big.Int).SetBytes(sig[32:64])
+	if f.signs++; f.signs%2 == 0 {
+		s.Sub(crypto.S256().Params().N, s)
+	}
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(sig[:32]), s})
+	require.NoError(f.t, err)
+	return &kms.SignOutput{Signature: der}, nil
+}
+
+// TestKMSSigner verifies txs signed through a fake KMS recover to the address of the KMS public key,
+// including when KMS returns the high S value
+func TestKMSSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signer := &kmsSigner{
+		client: &fakeKMS{t: t, key: key},
+		keyARN: "arn:aws:kms:us-east-1:123456789012:key/test",
+	}
+	require.NoError(t, signer.init(context.Background()))
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.address)
+
+	chainID := big.NewInt(1)
+	auth := signer.transactOpts(chainID)
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{ChainID: chainID, Nonce: nonce, To: &common.Address{}})
+		signed, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+
+		sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		require.Equal(t, signer.address, sender)
+	}
+
+	_, err = auth.Signer(common.Address{1}, ethtypes.NewTx(&ethtypes.DynamicFeeTx{ChainID: chainID}))
+	require.Error(t, err)
+}
+
+func TestNewKMSSigner(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	signer, err := newKMSSigner("arn:aws:kms:eu-west-1:123456789012:key/test")
+	require.NoError(t, err)
+	require.Equal(t, "eu-west-1", signer.client.(*kms.Client).Options().Region)
+
+	_, err = newKMSSigner("not-an-arn")
+	require.Error(t, err)
+}
@@ -1,11 +1,11 @@
 package ethereum
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
-	"os"
 	"time"
 
 	ethereum "github.com/ethereum/go-ethereum"
@@ -20,6 +20,13 @@ import (
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
+// defaultBackfillRangeSize is the number of blocks queried at once when getting history, as some websockets
+// only allow small history queries
+const defaultBackfillRangeSize = uint64(100)
+
+// defaultFlushBatchSize is the number of blocks a flush consumes before advancing the last flushed block
+const defaultFlushBatchSize = uint64(1000)
+
 // errSignal allows broadcasting an error value to multiple receivers.
 type errSignal struct {
 	Ready chan struct{}
@@ -28,27 +35,23 @@ type errSignal struct {
 // StartListener starts the ethereum websocket subscription, queries history pertaining to the lookback period,
 // and starts the reoccurring flush
 //
-// If an error occurs in websocket stream, this function will handle relevant sub routines and then re-run itself.
+// If an error occurs in websocket stream, this function will handle relevant sub routines, reconnect the
+// websocket with backoff and then re-run itself, backfilling the blocks missed while disconnected.
 func (e *Ethereum) StartListener(
 	ctx context.Context,
 	logger log.Logger,
 	processingQueue chan *types.TxState,
 	flushOnlyMode bool,
 	flushInterval time.Duration,
+	metrics *relayer.PromMetrics,
 ) {
 	logger = logger.With("chain", e.name, "chain_id", e.chainID, "domain", e.domain)
 
-	messageTransmitter, err := content.ReadFile("abi/MessageTransmitter.json")
-	if err != nil {
-		logger.Error("Unable to read MessageTransmitter abi", "err", err)
-		os.Exit(1)
-	}
-	messageTransmitterABI, err := abi.JSON(bytes.NewReader(messageTransmitter))
-	if err != nil {
-		logger.Error("Unable to parse MessageTransmitter abi", "err", err)
-		os.Exit(1)
-	}
+	e.metricsOnce.Do(func() {
+		e.metrics = metrics
+	})
 
+	messageTransmitterABI := e.messageTransmitterABI
 	messageSent := messageTransmitterABI.Events["MessageSent"]
 	messageTransmitterAddress := common.HexToAddress(e.messageTransmitterAddress)
 
@@ -56,6 +59,18 @@ func (e *Ethereum) StartListener(
 		Ready: make(chan struct{}),
 	}
 
+	// release buffered txs once they are confirmed, survives websocket restarts
+	if e.confirmations > 0 {
+		e.confirmationOnce.Do(func() {
+			go e.releaseConfirmed(ctx, logger, processingQueue)
+		})
+	}
+
+	// re-scan block ranges affected by reorgs, survives websocket restarts
+	e.rescanOnce.Do(func() {
+		go e.rescanReorgs(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI)
+	})
+
 	// FlushOnlyMode is used for the secondary, flush only relayer. When enabled, the main stream is not started.
 	if flushOnlyMode {
 		go e.flushMechanism(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, flushOnlyMode, flushInterval, sig)
@@ -64,7 +79,7 @@ func (e *Ethereum) StartListener(
 		stream, sub, history := e.startMainStream(ctx, logger, messageSent, messageTransmitterAddress)
 
 		go e.consumeStream(ctx, logger, processingQueue, messageSent, messageTransmitterABI, stream, sig)
-		consumeHistory(logger, history, processingQueue, messageSent, messageTransmitterABI)
+		e.consumeHistory(logger, history, processingQueue, messageSent, messageTransmitterABI)
 
 		// get history from (start block - lookback) up until latest block
 		latestBlock := e.LatestBlock()
@@ -75,8 +90,11 @@ func (e *Ethereum) StartListener(
 		startLookback := start - e.lookbackPeriod
 
 		logger.Info(fmt.Sprintf("Getting history from %d: starting at: %d looking back %d blocks", startLookback, start, e.lookbackPeriod))
-		e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, startLookback, latestBlock)
-		logger.Info("Finished getting history")
+		backfilled := e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, startLookback, latestBlock)
+		logger.Info("Finished getting history", "messages", backfilled)
+		if metrics != nil {
+			metrics.AddBackfilledMessages(e.name, fmt.Sprint(e.domain), backfilled)
+		}
 
 		if flushInterval > 0 {
 			go e.flushMechanism(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, flushOnlyMode, flushInterval, sig)
@@ -89,13 +107,23 @@ func (e *Ethereum) StartListener(
 		case <-ctx.Done():
 			return
 		case err := <-sub.Err():
-			logger.Error("Websocket disconnected. Reconnecting...", "err", err)
+			disconnectedAt := e.LatestBlock()
+			logger.Error("Websocket disconnected. Reconnecting...", "height", disconnectedAt, "err", err)
+			sub.Unsubscribe()
 			close(sig.Ready)
+			if metrics != nil {
+				metrics.IncWebsocketReconnects(e.name, fmt.Sprint(e.domain))
+			}
 
-			// restart
-			e.startBlock = e.lastFlushedBlock
-			time.Sleep(10 * time.Millisecond)
-			e.StartListener(ctx, logger, processingQueue, flushOnlyMode, flushInterval)
+			e.reconnectWebsocket(ctx, logger)
+			if ctx.Err() != nil {
+				return
+			}
+
+			// restart, backfilling from where the stream left off
+			e.startBlock = resumeBlock(disconnectedAt, e.lastFlushedBlock)
+			logger.Info(fmt.Sprintf("Backfilling blocks %d to %d missed while the websocket was disconnected", e.startBlock-e.lookbackPeriod, e.LatestBlock()))
+			e.StartListener(ctx, logger, processingQueue, flushOnlyMode, flushInterval, metrics)
 			return
 		}
 	}
@@ -109,8 +137,6 @@ func (e *Ethereum) startMainStream(
 ) (stream <-chan ethtypes.Log, sub ethereum.Subscription, history []ethtypes.Log) {
 	var err error
 
-	etherReader := etherstream.Reader{Backend: e.wsClient}
-
 	latestBlock := e.LatestBlock()
 
 	// start initial stream (start-block and lookback period handled separately)
@@ -123,14 +149,17 @@ func (e *Ethereum) startMainStream(
 	}
 
 	queryAttempt := 1
+	backoff := wsReconnectMinBackoff
 	for {
 		// websockets do not query history
 		// https://github.com/ethereum/go-ethereum/issues/15063
+		etherReader := etherstream.Reader{Backend: e.websocket()}
 		stream, sub, history, err = etherReader.QueryWithHistory(ctx, &query)
 		if err != nil {
 			logger.Error("Unable to subscribe to logs", "attempt", queryAttempt, "err", err)
 			queryAttempt++
-			time.Sleep(1 * time.Second)
+			time.Sleep(backoff)
+			backoff = nextBackoff(backoff)
 			continue
 		}
 		break
@@ -146,18 +175,18 @@ func (e *Ethereum) getAndConsumeHistory(
 	messageSent abi.Event,
 	messageTransmitterAddress common.Address,
 	messageTransmitterABI abi.ABI,
-	start, end uint64) {
+	start, end uint64) (consumed int) {
 	var toUnSub ethereum.Subscription
 	var history []ethtypes.Log
 	var err error
 
 	if start > end {
 		logger.Error(fmt.Sprintf("Unable to get history from %d to %d where the start block is greater than the end block", start, end))
-		return
+		return 0
 	}
 
 	// handle historical queries in chunks (some websockets only allow small history queries)
-	const chunkSize = uint64(100)
+	chunkSize := e.backfillRangeSize
 	chunk := 1
 	totalChunksNeeded := (end - start + chunkSize - 1) / chunkSize
 
@@ -170,8 +199,6 @@ func (e *Ethereum) getAndConsumeHistory(
 
 		logger.Debug(fmt.Sprintf("Looking back in chunks of %d: chunk: %d/%d start-block: %d end-block: %d", chunkSize, chunk, totalChunksNeeded, fromBlock, toBlock))
 
-		etherReader := etherstream.Reader{Backend: e.wsClient}
-
 		query := ethereum.FilterQuery{
 			Addresses: []common.Address{messageTransmitterAddress},
 			Topics:    [][]common.Hash{{messageSent.ID}},
@@ -180,8 +207,12 @@ func (e *Ethereum) getAndConsumeHistory(
 		}
 		queryAttempt := 1
 		for {
+			etherReader := etherstream.Reader{Backend: e.websocket()}
 			_, toUnSub, history, err = etherReader.QueryWithHistory(ctx, &query)
 			if err != nil {
+				if ctx.Err() != nil {
+					return consumed
+				}
 				// TODO: add metrics for this log
 				logger.Error(fmt.Sprintf("Unable to query history from %d to %d. attempt: %d", start, end, queryAttempt), "err", err)
 				queryAttempt++
@@ -191,32 +222,52 @@ func (e *Ethereum) getAndConsumeHistory(
 			break
 		}
 		toUnSub.Unsubscribe()
-		consumeHistory(logger, history, processingQueue, messageSent, messageTransmitterABI)
+		consumed += e.consumeHistory(logger, history, processingQueue, messageSent, messageTransmitterABI)
 
 		start += chunkSize
 		chunk++
 	}
+	return consumed
 }
 
 // consumeHistory consumes the history from a QueryWithHistory() go-ethereum call.
-// it passes messages to the processingQueue
-func consumeHistory(
+// it passes messages to the processingQueue once they have enough confirmations and returns how many it parsed
+func (e *Ethereum) consumeHistory(
 	logger log.Logger,
 	history []ethtypes.Log,
 	processingQueue chan *types.TxState,
 	messageSent abi.Event,
 	messageTransmitterABI abi.ABI,
-) {
+) (consumed int) {
 	for i := range history {
 		historicalLog := history[i]
 		parsedMsg, err := types.EvmLogToMessageState(messageTransmitterABI, messageSent, &historicalLog)
 		if err != nil {
-			logger.Error("Unable to parse history log into MessageState, skipping", "tx hash", historicalLog.TxHash.Hex(), "err", err)
+			e.skipUnparsedLog(logger, "history", &historicalLog, err)
 			continue
 		}
 		logger.Info(fmt.Sprintf("New historical msg from source domain %d with tx hash %s", parsedMsg.SourceDomain, parsedMsg.SourceTxHash))
 
-		processingQueue <- &types.TxState{TxHash: parsedMsg.SourceTxHash, Msgs: []*types.MessageState{parsedMsg}}
+		e.enqueue(logger, processingQueue, &types.TxState{TxHash: parsedMsg.SourceTxHash, Msgs: []*types.MessageState{parsedMsg}}, historicalLog.BlockNumber, historicalLog.BlockHash)
+		consumed++
+	}
+	return consumed
+}
+
+// skipUnparsedLog logs a MessageSent log that couldn't be parsed into a MessageState. Messages in a format the
+// relayer doesn't support are counted, so a new Circle message format is noticed.
+func (e *Ethereum) skipUnparsedLog(logger log.Logger, source string, l *ethtypes.Log, err error) {
+	var unparseable *types.UnparseableMessageError
+	if !errors.As(err, &unparseable) {
+		logger.Error(fmt.Sprintf("Unable to parse %s log into MessageState, skipping", source), "tx hash", l.TxHash.Hex(), "err", err)
+		return
+	}
+
+	logger.Error("Skipping message with an unparseable message body",
+		"tx hash", l.TxHash.Hex(), "block", l.BlockNumber, "log index", l.Index,
+		"source_domain", unparseable.SourceDomain, "body_length", unparseable.BodyLength)
+	if e.metrics != nil {
+		e.metrics.IncUnparseableMessages(fmt.Sprint(unparseable.SourceDomain), fmt.Sprint(unparseable.BodyLength))
 	}
 }
 
@@ -234,6 +285,8 @@ func (e *Ethereum) consumeStream(
 ) {
 	logger.Info("Starting consumption of incoming stream")
 	var txState *types.TxState
+	var txBlock uint64
+	var txBlockHash common.Hash
 	for {
 		select {
 		case <-ctx.Done():
@@ -244,7 +297,7 @@ func (e *Ethereum) consumeStream(
 		case streamLog := <-stream:
 			parsedMsg, err := types.EvmLogToMessageState(messageTransmitterABI, messageSent, &streamLog)
 			if err != nil {
-				logger.Error("Unable to parse ws log into MessageState, skipping", "source tx", streamLog.TxHash.Hex(), "err", err)
+				e.skipUnparsedLog(logger, "ws", &streamLog, err)
 				continue
 			}
 			logger.Info(fmt.Sprintf("New stream msg from %d with tx hash %s", parsedMsg.SourceDomain, parsedMsg.SourceTxHash))
@@ -253,20 +306,42 @@ func (e *Ethereum) consumeStream(
 			case txState == nil:
 				txState = &types.TxState{TxHash: parsedMsg.SourceTxHash, Msgs: []*types.MessageState{parsedMsg}}
 			case parsedMsg.SourceTxHash != txState.TxHash:
-				processingQueue <- txState
+				e.enqueue(logger, processingQueue, txState, txBlock, txBlockHash)
 				txState = &types.TxState{TxHash: parsedMsg.SourceTxHash, Msgs: []*types.MessageState{parsedMsg}}
 			default:
 				txState.Msgs = append(txState.Msgs, parsedMsg)
 			}
+			txBlock, txBlockHash = streamLog.BlockNumber, streamLog.BlockHash
 		default:
 			if txState != nil {
-				processingQueue <- txState
+				e.enqueue(logger, processingQueue, txState, txBlock, txBlockHash)
 				txState = nil
 			}
 		}
 	}
 }
 
+// rescanReorgs re-queries block ranges affected by a reorg so burns re-included in the new canonical chain
+// are picked up again.
+func (e *Ethereum) rescanReorgs(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	messageSent abi.Event,
+	messageTransmitterAddress common.Address,
+	messageTransmitterABI abi.ABI,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case r := <-e.reorgs.rescans:
+			logger.Info(fmt.Sprintf("Re-scanning blocks %d to %d after reorg", r.from, r.to))
+			e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, r.from, r.to)
+		}
+	}
+}
+
 // flushMechanism looks back over the chain history every specified flushInterval.
 //
 // Each chain is configured with a lookback period which signifies how many blocks to look back
@@ -325,11 +400,17 @@ func (e *Ethereum) flushMechanism(
 
 			logger.Info(fmt.Sprintf("Flush started from %d to %d (current height: %d, lookback period: %d)", startBlock, finishBlock, latestBlock, e.lookbackPeriod))
 
-			// consume from lastFlushedBlock to the finishBlock
-			e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, startBlock, finishBlock)
-
-			// update lastFlushedBlock to the last block it flushed
-			e.lastFlushedBlock = finishBlock
+			// consume from lastFlushedBlock to the finishBlock in batches, advancing lastFlushedBlock after each one
+			// so an interrupted flush resumes where it stopped
+			batches := flushBatches(startBlock, finishBlock, e.flushBatchSize)
+			for i, batch := range batches {
+				if ctx.Err() != nil {
+					return
+				}
+				consumed := e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, batch[0], batch[1])
+				e.lastFlushedBlock = batch[1]
+				logger.Info(fmt.Sprintf("Flushed batch %d/%d from %d to %d", i+1, len(batches), batch[0], batch[1]), "messages", consumed)
+			}
 
 			logger.Info("Flush complete")
 
@@ -345,6 +426,18 @@ func (e *Ethereum) flushMechanism(
 	}
 }
 
+// flushBatches splits the flush from start to end into ranges of at most size blocks. Like the history queries,
+// consecutive ranges share their boundary block.
+func flushBatches(start, end, size uint64) [][2]uint64 {
+	var batches [][2]uint64
+	for start < end {
+		batchEnd := min(start+size, end)
+		batches = append(batches, [2]uint64{start, batchEnd})
+		start = batchEnd
+	}
+	return batches
+}
+
 func (e *Ethereum) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) {
 	logger.With("routine", "TrackLatestBlockHeight", "chain", e.name, "domain", e.domain)
 
@@ -353,14 +446,21 @@ func (e *Ethereum) TrackLatestBlockHeight(ctx context.Context, logger log.Logger
 	// helper function to query latest height and set metric
 	queryHeightAndSetMetric := func() {
 		// first time
-		res, err := e.rpcClient.BlockNumber(ctx)
+		head, err := e.rpcClient.HeaderByNumber(ctx, nil)
+		e.rpcFailover.ReportActive(m, e.name, d)
 		if err != nil {
 			logger.Error("Unable to query latest height", "err", err)
 		} else {
+			res := head.Number.Uint64()
 			e.SetLatestBlock(res)
+			e.blockTime.Observe(res, time.Now())
 			if m != nil {
 				m.SetLatestHeight(e.name, d, int64(res))
+				if blockTime := e.BlockTime(); blockTime > 0 {
+					m.SetBlockTime(e.name, d, blockTime)
+				}
 			}
+			e.detectReorg(ctx, logger, m, head)
 		}
 	}
 
@@ -380,26 +480,58 @@ func (e *Ethereum) TrackLatestBlockHeight(ctx context.Context, logger log.Logger
 	}
 }
 
-func (e *Ethereum) WalletBalanceMetric(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) {
-	logger = logger.With("metric", "wallet balance", "chain", e.name, "domain", e.domain)
-	queryRate := 5 * time.Minute
+// WalletBalance returns the lowest balance of the minter wallets scaled by the metrics exponent
+func (e *Ethereum) WalletBalance(ctx context.Context) (float64, string, error) {
+	lowest := math.Inf(1)
+	for _, wallet := range e.wallets {
+		balance, err := e.walletBalance(ctx, wallet)
+		if err != nil {
+			return 0, "", err
+		}
+		lowest = math.Min(lowest, balance)
+	}
+	return lowest, e.MetricsDenom, nil
+}
+
+// MinBalanceAlert returns the configured min-balance-alert scaled by the metrics exponent
+func (e *Ethereum) MinBalanceAlert() float64 {
+	return float64(e.minBalanceAlert) / math.Pow10(e.MetricsExponent)
+}
 
-	account := common.HexToAddress(e.minterAddress)
+// walletBalance returns the balance of a minter wallet scaled by the metrics exponent
+func (e *Ethereum) walletBalance(ctx context.Context, wallet *minterWallet) (float64, error) {
+	balance, err := e.rpcClient.BalanceAt(ctx, common.HexToAddress(wallet.address), nil)
+	if err != nil {
+		return 0, err
+	}
 
 	exponent := big.NewInt(int64(e.MetricsExponent))                                      // ex: 18
 	scaleFactor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), exponent, nil)) // ex: 10^18
 
-	// helper function to query balance and set metric
+	balanceScaled, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), scaleFactor).Float64()
+	return balanceScaled, nil
+}
+
+func (e *Ethereum) WalletBalanceMetric(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) {
+	logger = logger.With("metric", "wallet balance", "chain", e.name, "domain", e.domain)
+	queryRate := 5 * time.Minute
+
+	// helper function to query the balance of each wallet and set metric
 	queryBalanceAndSetMetric := func() {
-		balance, err := e.rpcClient.BalanceAt(ctx, account, nil)
-		if err != nil {
-			logger.Error(fmt.Sprintf("Error querying balance. Will try again in %.2f sec", queryRate.Seconds()), "error", err)
-		} else {
-			balanceBigFloat := new(big.Float).SetInt(balance)
-			balanceScaled, _ := new(big.Float).Quo(balanceBigFloat, scaleFactor).Float64()
+		for _, wallet := range e.wallets {
+			balance, err := e.walletBalance(ctx, wallet)
+			if err != nil {
+				logger.Error(fmt.Sprintf("Error querying balance. Will try again in %.2f sec", queryRate.Seconds()), "address", wallet.address, "error", err)
+				continue
+			}
 
+			low := e.minBalanceAlert > 0 && balance < e.MinBalanceAlert()
+			if low {
+				logger.Error("Minter balance below min-balance-alert", "address", wallet.address, "balance", balance, "min_balance", e.MinBalanceAlert(), "denom", e.MetricsDenom)
+			}
 			if m != nil {
-				m.SetWalletBalance(e.name, e.minterAddress, e.MetricsDenom, balanceScaled)
+				m.SetWalletBalance(e.name, wallet.address, e.MetricsDenom, balance)
+				m.SetWalletBalanceLow(e.name, wallet.address, low)
 			}
 		}
 	}
@@ -0,0 +1,135 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+type pendingNonceReader interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// nonceManager hands out account nonces for broadcasts from a single minter wallet of an EVM chain. The next
+// nonce is tracked locally in the shared sequence map so concurrent mints don't race on the node's pending nonce.
+type nonceManager struct {
+	mu          sync.Mutex
+	domain      types.Domain
+	wallet      int
+	sequenceMap *types.SequenceMap
+	client      pendingNonceReader
+	account     common.Address
+
+	// nonces handed out that have not been sent or released yet
+	reserved map[uint64]struct{}
+	// nonces released by failed sends, reused before new nonces are handed out
+	released []uint64
+}
+
+func newNonceManager(domain types.Domain, wallet int, sequenceMap *types.SequenceMap, client pendingNonceReader, account common.Address) *nonceManager {
+	return &nonceManager{
+		domain:      domain,
+		wallet:      wallet,
+		sequenceMap: sequenceMap,
+		client:      client,
+		account:     account,
+		reserved:    make(map[uint64]struct{}),
+	}
+}
+
+// seed sets the next nonce from the node's pending nonce
+func (n *nonceManager) seed(ctx context.Context) error {
+	pending, err := n.client.PendingNonceAt(ctx, n.account)
+	if err != nil {
+		return fmt.Errorf("unable to query pending nonce: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sequenceMap.PutWallet(n.domain, n.wallet, pending)
+	n.released = nil
+	return nil
+}
+
+// reserve returns the next nonce to broadcast with. Released nonces are reused lowest first so no gap is left.
+func (n *nonceManager) reserve() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var nonce uint64
+	if len(n.released) > 0 {
+		nonce = n.released[0]
+		n.released = n.released[1:]
+	} else {
+		nonce = n.sequenceMap.NextWallet(n.domain, n.wallet)
+	}
+	n.reserved[nonce] = struct{}{}
+	return nonce
+}
+
+// sent marks a reserved nonce as used by a tx accepted by the node
+func (n *nonceManager) sent(nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.reserved, nonce)
+}
+
+// release returns a reserved nonce whose tx was never accepted so it can be handed out again
+func (n *nonceManager) release(nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.reserved[nonce]; !ok {
+		return
+	}
+	delete(n.reserved, nonce)
+
+	// the most recently handed out nonce can simply be rolled back
+	if nonce+1 == n.sequenceMap.GetWallet(n.domain, n.wallet) {
+		n.sequenceMap.PutWallet(n.domain, n.wallet, nonce)
+		return
+	}
+	n.released = append(n.released, nonce)
+	sort.Slice(n.released, func(i, j int) bool { return n.released[i] < n.released[j] })
+}
+
+// resync compares the local next nonce with the node's pending nonce. If the node is ahead, e.g. after a
+// "nonce too low" error, the local nonce jumps forward. If the node is behind with no reservations
+// outstanding, txs we counted never landed and the local nonce is reset to close the gap.
+func (n *nonceManager) resync(ctx context.Context) error {
+	pending, err := n.client.PendingNonceAt(ctx, n.account)
+	if err != nil {
+		return fmt.Errorf("unable to query pending nonce: %w", err)
+	}
+	n.sync(pending)
+	return nil
+}
+
+func (n *nonceManager) sync(pending uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// released nonces below the pending nonce were consumed elsewhere
+	kept := n.released[:0]
+	for _, nonce := range n.released {
+		if nonce >= pending {
+			kept = append(kept, nonce)
+		}
+	}
+	n.released = kept
+
+	next := n.sequenceMap.GetWallet(n.domain, n.wallet)
+	switch {
+	case pending > next:
+		n.sequenceMap.PutWallet(n.domain, n.wallet, pending)
+		n.released = nil
+	case pending < next && len(n.reserved) == 0:
+		n.sequenceMap.PutWallet(n.domain, n.wallet, pending)
+		n.released = nil
+	}
+}
@@ -0,0 +1,55 @@
+package ethereum
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+type fakeNonceReader uint64
+
+func (f *fakeNonceReader) PendingNonceAt(_ context.Context, _ common.Address) (uint64, error) {
+	return uint64(*f), nil
+}
+
+// TestNonceManager verifies nonces are handed out locally, released nonces are reused and gaps are reset
+func TestNonceManager(t *testing.T) {
+	pending := fakeNonceReader(10)
+	sequenceMap := types.NewSequenceMap()
+	n := newNonceManager(0, 0, sequenceMap, &pending, common.Address{})
+	require.NoError(t, n.seed(context.Background()))
+
+	a, b, c := n.reserve(), n.reserve(), n.reserve()
+	require.Equal(t, []uint64{10, 11, 12}, []uint64{a, b, c})
+
+	// releasing the latest nonce rolls back the sequence
+	n.release(c)
+	require.Equal(t, uint64(12), sequenceMap.Get(0))
+
+	// releasing an earlier nonce reuses it before handing out new ones
+	n.release(a)
+	require.Equal(t, uint64(10), n.reserve())
+	require.Equal(t, uint64(12), n.reserve())
+
+	// releasing an unreserved nonce is a no-op
+	n.release(42)
+	require.Equal(t, uint64(13), sequenceMap.Get(0))
+
+	// gap is only reset once nothing is outstanding
+	n.sync(11)
+	require.Equal(t, uint64(13), sequenceMap.Get(0))
+	n.sent(10)
+	n.sent(11)
+	n.sent(12)
+	n.sync(11)
+	require.Equal(t, uint64(11), sequenceMap.Get(0))
+
+	// node ahead of the local nonce
+	pending = 20
+	require.NoError(t, n.resync(context.Background()))
+	require.Equal(t, uint64(20), n.reserve())
+}
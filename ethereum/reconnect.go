@@ -0,0 +1,65 @@
+package ethereum
+
+import (
+	"context"
+	"time"
+
+	"cosmossdk.io/log"
+)
+
+const (
+	// wsReconnectMinBackoff is the delay before the first attempt to reconnect a disconnected websocket
+	wsReconnectMinBackoff = time.Second
+	// wsReconnectMaxBackoff caps the delay between attempts to reconnect a disconnected websocket
+	wsReconnectMaxBackoff = time.Minute
+)
+
+// reconnectWebsocket replaces the websocket client with a new connection to the ws or one of its fallbacks,
+// backing off between attempts until it connects or ctx is done.
+func (e *Ethereum) reconnectWebsocket(ctx context.Context, logger log.Logger) {
+	backoff := wsReconnectMinBackoff
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		client, err := e.dialWebsocket(ctx, logger)
+		if err != nil {
+			backoff = nextBackoff(backoff)
+			logger.Error("Unable to reconnect websocket", "attempt", attempt, "retry_in", backoff, "err", err)
+			continue
+		}
+
+		e.mu.Lock()
+		old := e.wsClient
+		e.wsClient = client
+		e.mu.Unlock()
+		if old != nil {
+			old.Close()
+		}
+		logger.Info("Reconnected websocket", "attempt", attempt)
+		return
+	}
+}
+
+// nextBackoff doubles the backoff, up to wsReconnectMaxBackoff
+func nextBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > wsReconnectMaxBackoff {
+		return wsReconnectMaxBackoff
+	}
+	return backoff
+}
+
+// resumeBlock returns the block history is queried from after a websocket reconnects: the height when it
+// disconnected, or the last flushed block if the flush hasn't reached it yet.
+func resumeBlock(disconnectedAt, lastFlushedBlock uint64) uint64 {
+	if lastFlushedBlock != 0 && lastFlushedBlock < disconnectedAt {
+		return lastFlushedBlock
+	}
+	return disconnectedAt
+}
@@ -0,0 +1,32 @@
+package ethereum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextBackoff(t *testing.T) {
+	backoff := wsReconnectMinBackoff
+	var backoffs []time.Duration
+	for i := 0; i < 8; i++ {
+		backoff = nextBackoff(backoff)
+		backoffs = append(backoffs, backoff)
+	}
+	require.Equal(t, []time.Duration{
+		2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 32 * time.Second,
+		time.Minute, time.Minute, time.Minute,
+	}, backoffs)
+}
+
+func TestResumeBlock(t *testing.T) {
+	// without the flush, history is queried from the height at the disconnect
+	require.Equal(t, uint64(1000), resumeBlock(1000, 0))
+
+	// the flush lags behind the latest height
+	require.Equal(t, uint64(980), resumeBlock(1000, 980))
+
+	// the flush-only relayer may have flushed past the height tracked at the disconnect
+	require.Equal(t, uint64(1000), resumeBlock(1000, 1010))
+}
@@ -0,0 +1,304 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// blockHashHistory is the number of recent block hashes kept for reorg detection
+const blockHashHistory = 128
+
+type headerReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+}
+
+type chainReader interface {
+	headerReader
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
+}
+
+// observedTx is a source tx observed in a block
+type observedTx struct {
+	number    uint64
+	blockHash common.Hash
+	txHash    string
+}
+
+// blockRange is an inclusive range of blocks that must be re-scanned after a reorg
+type blockRange struct {
+	from, to uint64
+}
+
+type blockHash struct {
+	number uint64
+	hash   common.Hash
+}
+
+// blockHashRing is a fixed size ring buffer of recently observed block hashes, ordered by insertion
+type blockHashRing struct {
+	entries []blockHash
+	next    int
+	count   int
+}
+
+func newBlockHashRing(size int) *blockHashRing {
+	return &blockHashRing{entries: make([]blockHash, size)}
+}
+
+func (r *blockHashRing) add(number uint64, hash common.Hash) {
+	r.entries[r.next] = blockHash{number: number, hash: hash}
+	r.next = (r.next + 1) % len(r.entries)
+	if r.count < len(r.entries) {
+		r.count++
+	}
+}
+
+// latest returns the most recently added entry
+func (r *blockHashRing) latest() (blockHash, bool) {
+	if r.count == 0 {
+		return blockHash{}, false
+	}
+	return r.entries[(r.next-1+len(r.entries))%len(r.entries)], true
+}
+
+// newestFirst returns the entries from most to least recently added
+func (r *blockHashRing) newestFirst() []blockHash {
+	out := make([]blockHash, 0, r.count)
+	for i := 1; i <= r.count; i++ {
+		out = append(out, r.entries[(r.next-i+len(r.entries))%len(r.entries)])
+	}
+	return out
+}
+
+// truncate drops all entries above the given block number
+func (r *blockHashRing) truncate(number uint64) {
+	kept := r.newestFirst()
+	r.next, r.count = 0, 0
+	for i := len(kept) - 1; i >= 0; i-- {
+		if kept[i].number <= number {
+			r.add(kept[i].number, kept[i].hash)
+		}
+	}
+}
+
+// reorgTracker detects reorgs from block hashes and remembers which source txs were observed in which blocks
+type reorgTracker struct {
+	mu          sync.Mutex
+	hashes      *blockHashRing
+	observedTxs map[uint64]map[string]common.Hash // block number -> tx hash -> hash of the block it was observed in
+	reorgedTxs  map[string]struct{}
+	rescans     chan blockRange
+}
+
+func newReorgTracker() *reorgTracker {
+	return &reorgTracker{
+		hashes:      newBlockHashRing(blockHashHistory),
+		observedTxs: make(map[uint64]map[string]common.Hash),
+		reorgedTxs:  make(map[string]struct{}),
+		rescans:     make(chan blockRange, 16),
+	}
+}
+
+// observe records a source tx seen in the block. A tx observed again is no longer considered reorged.
+func (t *reorgTracker) observe(blockNumber uint64, blockHash common.Hash, txHash string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.observedTxs[blockNumber] == nil {
+		t.observedTxs[blockNumber] = make(map[string]common.Hash)
+	}
+	t.observedTxs[blockNumber][txHash] = blockHash
+	delete(t.reorgedTxs, txHash)
+}
+
+func (t *reorgTracker) isReorged(txHash string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.reorgedTxs[txHash]
+	return ok
+}
+
+// check compares the new head against recorded block hashes. If a reorg is detected it returns the
+// last block known to still be canonical.
+func (t *reorgTracker) check(ctx context.Context, client headerReader, head *ethtypes.Header) (forkPoint uint64, reorged bool, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	headNumber := head.Number.Uint64()
+
+	last, ok := t.hashes.latest()
+	if !ok {
+		t.hashes.add(headNumber, head.Hash())
+		return 0, false, nil
+	}
+
+	switch {
+	case headNumber < last.number:
+		reorged = true
+	case headNumber == last.number:
+		reorged = head.Hash() != last.hash
+	case headNumber == last.number+1:
+		reorged = head.ParentHash != last.hash
+	default:
+		canonical, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(last.number))
+		if err != nil {
+			return 0, false, fmt.Errorf("unable to query header %d: %w", last.number, err)
+		}
+		reorged = canonical.Hash() != last.hash
+	}
+
+	if !reorged {
+		t.hashes.add(headNumber, head.Hash())
+		t.prune()
+		return 0, false, nil
+	}
+
+	// walk back through recorded hashes until one matches the canonical chain
+	forkPoint = 0
+	for _, entry := range t.hashes.newestFirst() {
+		if entry.number > headNumber {
+			continue
+		}
+		canonical, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(entry.number))
+		if err != nil {
+			return 0, false, fmt.Errorf("unable to query header %d: %w", entry.number, err)
+		}
+		if canonical.Hash() == entry.hash {
+			forkPoint = entry.number
+			break
+		}
+	}
+	if forkPoint == 0 {
+		// no common ancestor in history, assume the oldest tracked block
+		if entries := t.hashes.newestFirst(); len(entries) > 0 {
+			forkPoint = entries[len(entries)-1].number
+		}
+	}
+
+	t.hashes.truncate(forkPoint)
+	t.hashes.add(headNumber, head.Hash())
+
+	return forkPoint, true, nil
+}
+
+// txsAfter returns and stops tracking the observed txs in blocks above the fork point
+func (t *reorgTracker) txsAfter(forkPoint uint64) []observedTx {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var txs []observedTx
+	for blockNumber, hashes := range t.observedTxs {
+		if blockNumber <= forkPoint {
+			continue
+		}
+		for txHash, blockHash := range hashes {
+			txs = append(txs, observedTx{number: blockNumber, blockHash: blockHash, txHash: txHash})
+		}
+		delete(t.observedTxs, blockNumber)
+	}
+	return txs
+}
+
+// confirmReorged returns true if the canonical chain confirms the tx was removed: the canonical block at the height
+// the tx was observed at is another block and the tx has no receipt. An RPC lagging behind the height, or still
+// serving the block the tx was observed in, doesn't confirm it. A tx re-included in another block isn't removed.
+func confirmReorged(ctx context.Context, client chainReader, tx observedTx) (bool, error) {
+	canonical, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(tx.number))
+	if err != nil {
+		return false, fmt.Errorf("unable to query header %d: %w", tx.number, err)
+	}
+	if canonical == nil || canonical.Hash() == tx.blockHash {
+		return false, nil
+	}
+
+	_, err = client.TransactionReceipt(ctx, common.HexToHash(tx.txHash))
+	switch {
+	case errors.Is(err, ethereum.NotFound):
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("unable to query receipt: %w", err)
+	}
+	return false, nil
+}
+
+func (t *reorgTracker) markReorged(txHash string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.reorgedTxs[txHash] = struct{}{}
+}
+
+// prune drops observed txs older than the tracked block hash history
+func (t *reorgTracker) prune() {
+	entries := t.hashes.newestFirst()
+	if len(entries) < blockHashHistory {
+		return
+	}
+	oldest := entries[len(entries)-1].number
+	for blockNumber := range t.observedTxs {
+		if blockNumber < oldest {
+			delete(t.observedTxs, blockNumber)
+		}
+	}
+}
+
+// IsReorgedTx returns true if the source tx was removed from the canonical chain by a reorg
+func (e *Ethereum) IsReorgedTx(txHash string) bool {
+	return e.reorgs.isReorged(txHash)
+}
+
+// detectReorg checks the latest header for a reorg. Observed txs in reorged blocks that are no longer
+// on chain are invalidated and the affected range is queued for a re-scan by the listener.
+func (e *Ethereum) detectReorg(ctx context.Context, logger log.Logger, m *relayer.PromMetrics, head *ethtypes.Header) {
+	forkPoint, reorged, err := e.reorgs.check(ctx, e.rpcClient, head)
+	if err != nil {
+		logger.Error("Unable to check for reorg", "err", err)
+		return
+	}
+	if !reorged {
+		return
+	}
+
+	logger.Info("Reorg detected", "fork_point", forkPoint, "head", head.Number.Uint64())
+	if m != nil {
+		m.IncReorgs(e.name, fmt.Sprint(e.domain))
+	}
+
+	// a reorg may have removed burns from or mints to this chain, query their nonces again
+	if dropped := types.UsedNonces.Invalidate(e.domain); dropped > 0 {
+		logger.Info("Invalidated cached used nonces after reorg", "nonces", dropped)
+	}
+
+	for _, tx := range e.reorgs.txsAfter(forkPoint) {
+		reorged, err := confirmReorged(ctx, e.rpcClient, tx)
+		switch {
+		case reorged:
+			logger.Info("Source tx removed by reorg, invalidating", "tx", tx.txHash, "block", tx.number)
+			e.reorgs.markReorged(tx.txHash)
+		case err != nil:
+			logger.Error("Unable to confirm whether source tx was removed by reorg", "tx", tx.txHash, "block", tx.number, "err", err)
+			// keep tracking the tx so the next reorg check confirms it
+			e.reorgs.observe(tx.number, tx.blockHash, tx.txHash)
+		default:
+			// still on the canonical chain, or re-included in another block and re-observed by the rescan
+			e.reorgs.observe(tx.number, tx.blockHash, tx.txHash)
+		}
+	}
+
+	select {
+	case e.reorgs.rescans <- blockRange{from: forkPoint + 1, to: head.Number.Uint64()}:
+	default:
+		logger.Error("Reorg rescan queue full, relying on flush to re-scan", "from", forkPoint+1)
+	}
+}
@@ -0,0 +1,125 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHeaders map[uint64]*ethtypes.Header
+
+func (f fakeHeaders) HeaderByNumber(_ context.Context, number *big.Int) (*ethtypes.Header, error) {
+	return f[number.Uint64()], nil
+}
+
+func header(number uint64, parent common.Hash, extra byte) *ethtypes.Header {
+	return &ethtypes.Header{Number: new(big.Int).SetUint64(number), ParentHash: parent, Extra: []byte{extra}}
+}
+
+// TestBlockHashRing verifies the ring keeps only the most recent entries and truncates by block number
+func TestBlockHashRing(t *testing.T) {
+	r := newBlockHashRing(3)
+	for i := uint64(1); i <= 4; i++ {
+		r.add(i, common.BigToHash(new(big.Int).SetUint64(i)))
+	}
+
+	entries := r.newestFirst()
+	require.Len(t, entries, 3)
+	require.Equal(t, uint64(4), entries[0].number)
+	require.Equal(t, uint64(2), entries[2].number)
+
+	r.truncate(2)
+	latest, ok := r.latest()
+	require.True(t, ok)
+	require.Equal(t, uint64(2), latest.number)
+	require.Len(t, r.newestFirst(), 1)
+}
+
+// TestReorgTracker verifies a parent hash mismatch is detected and txs above the fork point are returned
+func TestReorgTracker(t *testing.T) {
+	ctx := context.Background()
+	tracker := newReorgTracker()
+
+	b1 := header(1, common.Hash{}, 0)
+	b2 := header(2, b1.Hash(), 0)
+	b3 := header(3, b2.Hash(), 0)
+	chain := fakeHeaders{1: b1, 2: b2, 3: b3}
+
+	for _, h := range []*ethtypes.Header{b1, b2, b3} {
+		_, reorged, err := tracker.check(ctx, chain, h)
+		require.NoError(t, err)
+		require.False(t, reorged)
+	}
+
+	tracker.observe(2, b2.Hash(), "kept")
+	tracker.observe(3, b3.Hash(), "dropped")
+
+	// block 3 replaced, new head builds on the replacement
+	b3b := header(3, b2.Hash(), 1)
+	b4 := header(4, b3b.Hash(), 0)
+	chain[3], chain[4] = b3b, b4
+
+	forkPoint, reorged, err := tracker.check(ctx, chain, b4)
+	require.NoError(t, err)
+	require.True(t, reorged)
+	require.Equal(t, uint64(2), forkPoint)
+	require.Equal(t, []observedTx{{number: 3, blockHash: b3.Hash(), txHash: "dropped"}}, tracker.txsAfter(forkPoint))
+
+	tracker.markReorged("dropped")
+	require.True(t, tracker.isReorged("dropped"))
+
+	// re-observed in the new canonical chain
+	tracker.observe(4, b4.Hash(), "dropped")
+	require.False(t, tracker.isReorged("dropped"))
+
+	// block number regression
+	_, reorged, err = tracker.check(ctx, chain, b2)
+	require.NoError(t, err)
+	require.True(t, reorged)
+}
+
+type fakeChain struct {
+	fakeHeaders
+	receipts map[common.Hash]*ethtypes.Receipt
+}
+
+func (f fakeChain) TransactionReceipt(_ context.Context, txHash common.Hash) (*ethtypes.Receipt, error) {
+	if receipt, ok := f.receipts[txHash]; ok {
+		return receipt, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+// TestConfirmReorged verifies a tx is only reorged once the canonical chain replaced its block and dropped the tx
+func TestConfirmReorged(t *testing.T) {
+	ctx := context.Background()
+	b3 := header(3, common.Hash{}, 0)
+	b3b := header(3, common.Hash{}, 1)
+	reincluded := common.HexToHash("0x02")
+	chain := fakeChain{
+		fakeHeaders: fakeHeaders{3: b3b},
+		receipts:    map[common.Hash]*ethtypes.Receipt{reincluded: {BlockNumber: big.NewInt(4)}},
+	}
+
+	reorged, err := confirmReorged(ctx, chain, observedTx{number: 3, blockHash: b3.Hash(), txHash: common.HexToHash("0x01").Hex()})
+	require.NoError(t, err)
+	require.True(t, reorged)
+
+	// re-included in a later block
+	reorged, err = confirmReorged(ctx, chain, observedTx{number: 3, blockHash: b3.Hash(), txHash: reincluded.Hex()})
+	require.NoError(t, err)
+	require.False(t, reorged)
+
+	// a lagging rpc still serves the block the tx was observed in, or hasn't reached its height yet
+	reorged, err = confirmReorged(ctx, chain, observedTx{number: 3, blockHash: b3b.Hash(), txHash: common.HexToHash("0x01").Hex()})
+	require.NoError(t, err)
+	require.False(t, reorged)
+	reorged, err = confirmReorged(ctx, chain, observedTx{number: 5, blockHash: b3.Hash(), txHash: common.HexToHash("0x01").Hex()})
+	require.NoError(t, err)
+	require.False(t, reorged)
+}
@@ -0,0 +1,189 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// stuckTxCheckInterval is how often submitted mints are checked for confirmation
+const stuckTxCheckInterval = 5 * time.Second
+
+// submittedTx is a mint that was accepted by the node but not yet confirmed
+type submittedTx struct {
+	nonce       uint64
+	tx          *ethtypes.Transaction
+	msg         *types.MessageState
+	attestation []byte
+	submittedAt time.Time
+}
+
+// submittedTxs tracks unconfirmed mints by account nonce
+type submittedTxs struct {
+	mu  sync.Mutex
+	txs map[uint64]*submittedTx
+}
+
+func newSubmittedTxs() *submittedTxs {
+	return &submittedTxs{txs: make(map[uint64]*submittedTx)}
+}
+
+func (s *submittedTxs) track(nonce uint64, tx *ethtypes.Transaction, msg *types.MessageState, attestation []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.txs[nonce] = &submittedTx{
+		nonce:       nonce,
+		tx:          tx,
+		msg:         msg,
+		attestation: attestation,
+		submittedAt: time.Now(),
+	}
+}
+
+// confirm drops all txs with a nonce below the account's mined nonce. Either the original tx or one of its
+// replacements was included.
+func (s *submittedTxs) confirm(minedNonce uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for nonce := range s.txs {
+		if nonce < minedNonce {
+			delete(s.txs, nonce)
+		}
+	}
+}
+
+func (s *submittedTxs) untrack(nonce uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.txs, nonce)
+}
+
+// stuck returns the txs submitted longer than timeout ago
+func (s *submittedTxs) stuck(timeout time.Duration) []*submittedTx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stuck []*submittedTx
+	for _, tx := range s.txs {
+		if time.Since(tx.submittedAt) >= timeout {
+			stuck = append(stuck, tx)
+		}
+	}
+	return stuck
+}
+
+func (s *submittedTxs) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.txs)
+}
+
+// bumpFees raises the max fee and tip by 12.5%, the minimum for a node to accept a replacement of a pending tx
+// with the same nonce. ok is false if the bumped max fee exceeds maxFeeCap, a replacement clamped to the cap would
+// be rejected as underpriced.
+func bumpFees(gasFeeCap, gasTipCap, maxFeeCap *big.Int) (fees txFees, ok bool) {
+	bump := func(v *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(v, big.NewInt(9))
+		bumped.Add(bumped, big.NewInt(7))
+		return bumped.Div(bumped, big.NewInt(8))
+	}
+
+	fees = txFees{gasFeeCap: bump(gasFeeCap), gasTipCap: bump(gasTipCap)}
+
+	if maxFeeCap != nil && maxFeeCap.Sign() > 0 && fees.gasFeeCap.Cmp(maxFeeCap) > 0 {
+		return txFees{}, false
+	}
+
+	return fees, true
+}
+
+// monitorStuckTxs re-broadcasts mints that are not confirmed within the stuck tx timeout, using the same
+// nonce with escalating fees until they confirm or the max fee cap is reached.
+func (e *Ethereum) monitorStuckTxs(ctx context.Context, logger log.Logger) {
+	logger = logger.With("chain", e.name, "chain_id", e.chainID, "domain", e.domain)
+	timeout := time.Duration(e.stuckTxTimeout) * time.Second
+
+	ticker := time.NewTicker(stuckTxCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, wallet := range e.wallets {
+				e.checkStuckTxs(ctx, logger, wallet, timeout)
+			}
+		}
+	}
+}
+
+// checkStuckTxs drops the confirmed mints of a wallet and re-broadcasts the ones that are stuck
+func (e *Ethereum) checkStuckTxs(ctx context.Context, logger log.Logger, wallet *minterWallet, timeout time.Duration) {
+	if wallet.submitted.len() == 0 {
+		return
+	}
+
+	minedNonce, err := e.rpcClient.NonceAt(ctx, common.HexToAddress(wallet.address), nil)
+	if err != nil {
+		logger.Error("Unable to query account nonce for stuck tx check", "minter", wallet.address, "err", err)
+		return
+	}
+	wallet.submitted.confirm(minedNonce)
+
+	for _, stuck := range wallet.submitted.stuck(timeout) {
+		if err := e.rebroadcast(ctx, logger, wallet, stuck); err != nil {
+			logger.Error("Unable to re-broadcast stuck tx", "nonce", stuck.nonce, "tx", stuck.tx.Hash().Hex(), "err", err)
+		}
+	}
+}
+
+// rebroadcast re-submits a stuck mint with the same nonce and bumped fees
+func (e *Ethereum) rebroadcast(ctx context.Context, logger log.Logger, wallet *minterWallet, stuck *submittedTx) error {
+	fees, ok := bumpFees(stuck.tx.GasFeeCap(), stuck.tx.GasTipCap(), e.maxFeePerGas())
+	if stuck.tx.Type() == ethtypes.LegacyTxType {
+		// the gas price of a legacy tx is its fee cap and tip
+		fees = txFees{gasPrice: fees.gasFeeCap}
+	}
+	if !ok {
+		logger.Error("Stuck tx can't be replaced without exceeding the max fee cap, no longer re-broadcasting",
+			"nonce", stuck.nonce, "tx", stuck.tx.Hash().Hex(), "max_fee_gwei", weiToGwei(e.maxFeePerGas()))
+		wallet.submitted.untrack(stuck.nonce)
+		return nil
+	}
+
+	auth, messageTransmitter, err := e.newTransactor(wallet)
+	if err != nil {
+		return err
+	}
+	auth.Context = ctx
+	auth.Nonce = new(big.Int).SetUint64(stuck.nonce)
+	fees.apply(auth)
+
+	wallet.mu.Lock()
+	defer wallet.mu.Unlock()
+
+	tx, err := messageTransmitter.ReceiveMessage(auth, stuck.msg.MsgSentBytes, stuck.attestation)
+	if err != nil {
+		return fmt.Errorf("unable to replace tx: %w", err)
+	}
+
+	logger.Info(fmt.Sprintf("Re-broadcast stuck tx %s as %s", stuck.tx.Hash().Hex(), tx.Hash().Hex()),
+		"nonce", stuck.nonce, "max_fee_gwei", weiToGwei(fees.maxFee()))
+
+	e.stateMu.Lock()
+	stuck.msg.DestTxHash = tx.Hash().Hex()
+	e.stateMu.Unlock()
+	wallet.submitted.track(stuck.nonce, tx, stuck.msg, stuck.attestation)
+
+	return nil
+}
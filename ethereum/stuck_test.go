@@ -0,0 +1,47 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBumpFees verifies fees are bumped by 12.5% and stop escalating once the bump would exceed the max fee cap
+func TestBumpFees(t *testing.T) {
+	fees, ok := bumpFees(big.NewInt(80), big.NewInt(8), nil)
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(90), fees.gasFeeCap)
+	require.Equal(t, big.NewInt(9), fees.gasTipCap)
+
+	// the bump fits the cap exactly
+	fees, ok = bumpFees(big.NewInt(80), big.NewInt(8), big.NewInt(90))
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(90), fees.gasFeeCap)
+
+	// a bump clamped to the cap would be an underpriced replacement
+	_, ok = bumpFees(big.NewInt(80), big.NewInt(8), big.NewInt(85))
+	require.False(t, ok)
+
+	// already at the cap
+	_, ok = bumpFees(big.NewInt(85), big.NewInt(8), big.NewInt(85))
+	require.False(t, ok)
+}
+
+// TestSubmittedTxs verifies txs are dropped once mined and reported as stuck after the timeout
+func TestSubmittedTxs(t *testing.T) {
+	s := newSubmittedTxs()
+	s.track(1, ethtypes.NewTx(&ethtypes.DynamicFeeTx{Nonce: 1}), nil, nil)
+	s.track(2, ethtypes.NewTx(&ethtypes.DynamicFeeTx{Nonce: 2}), nil, nil)
+
+	require.Empty(t, s.stuck(time.Hour))
+	require.Len(t, s.stuck(0), 2)
+
+	s.confirm(2)
+	require.Equal(t, 1, s.len())
+	stuck := s.stuck(0)
+	require.Len(t, stuck, 1)
+	require.Equal(t, uint64(2), stuck[0].nonce)
+}
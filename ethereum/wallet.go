@@ -0,0 +1,40 @@
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"strings"
+	"sync"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// minterWallet is one of the accounts mints are broadcast from. Each wallet has its own account nonces.
+type minterWallet struct {
+	index      int
+	privateKey *ecdsa.PrivateKey
+	// set instead of the private key when signing with an AWS KMS key
+	kms     *kmsSigner
+	address string
+
+	// serializes nonce reservation and sending so txs reach the node in nonce order
+	mu sync.Mutex
+
+	// locally tracked account nonces for broadcasts
+	nonces *nonceManager
+	// mints waiting to be confirmed, re-broadcast with more gas when stuck
+	submitted *submittedTxs
+}
+
+// walletFor returns the wallet to broadcast msg with. A msg whose destination caller is one of the minter
+// wallets can only be received by that wallet, any other msg goes to the next wallet of the pool.
+func (e *Ethereum) walletFor(msg *types.MessageState) *minterWallet {
+	if wallet := e.callerWallet(msg.DestinationCaller); wallet != nil {
+		return wallet
+	}
+	return e.wallets[e.walletPool.Next()]
+}
+
+// isInsufficientFunds returns true if the broadcast failed because the wallet can't pay for gas
+func isInsufficientFunds(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "insufficient funds")
+}
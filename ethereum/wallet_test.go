@@ -0,0 +1,36 @@
+package ethereum
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestWalletFor verifies msgs with a minter wallet as destination caller are broadcast by that wallet
+func TestWalletFor(t *testing.T) {
+	e, err := NewChain("ethereum", 0, 1, "", "", "", "", abi.ABI{}, 0, 0, []string{strings.Repeat("01", 32), strings.Repeat("02", 32)}, "",
+		0, 0, 0, "", 0, 0, 0, 0, 0, nil, nil, 0, 0)
+	require.NoError(t, err)
+
+	pinned := &types.MessageState{DestinationCaller: common.LeftPadBytes(common.HexToAddress(e.wallets[1].address).Bytes(), 32)}
+	anyCaller := &types.MessageState{DestinationCaller: make([]byte, 32)}
+
+	isCaller, _ := e.IsDestinationCaller(pinned.DestinationCaller)
+	require.True(t, isCaller)
+	isCaller, _ = e.IsDestinationCaller(common.LeftPadBytes([]byte{1}, 32))
+	require.False(t, isCaller)
+
+	require.Equal(t, e.wallets[1], e.walletFor(pinned))
+	require.Equal(t, e.wallets[0], e.walletFor(anyCaller))
+	require.Equal(t, e.wallets[1], e.walletFor(anyCaller))
+
+	// an unfunded wallet is skipped
+	e.walletPool.Failed(0)
+	require.Equal(t, e.wallets[1], e.walletFor(anyCaller))
+	require.Equal(t, e.wallets[1], e.walletFor(anyCaller))
+}
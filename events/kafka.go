@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// kafkaSender produces events to a Kafka topic, keyed by iris lookup id so every event for a message lands on the
+// same partition in order
+type kafkaSender struct {
+	client *kgo.Client
+}
+
+// newKafkaSender creates a producer for the comma separated seed brokers, e.g. broker-1:9092,broker-2:9092. The
+// client connects to the brokers on the first event.
+func newKafkaSender(brokers, topic string, useTLS bool) (*kafkaSender, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(strings.Split(brokers, ",")...),
+		kgo.DefaultProduceTopic(topic),
+		kgo.ClientID("noble-cctp-relayer"),
+	}
+	if useTLS {
+		opts = append(opts, kgo.DialTLSConfig(new(tls.Config)))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	return &kafkaSender{client: client}, nil
+}
+
+// kafkaRecord returns the record produced for the event
+func kafkaRecord(event Event) (*kgo.Record, error) {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return &kgo.Record{Key: []byte(event.IrisLookupID), Value: value}, nil
+}
+
+func (s *kafkaSender) send(ctx context.Context, event Event) error {
+	record, err := kafkaRecord(event)
+	if err != nil {
+		return err
+	}
+	if err := s.client.ProduceSync(ctx, record).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce to kafka: %w", err)
+	}
+	return nil
+}
+
+func (s *kafkaSender) close() error {
+	s.client.Close()
+	return nil
+}
@@ -0,0 +1,196 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	PublisherNATS  = "nats"
+	PublisherKafka = "kafka"
+
+	defaultTopic      = "cctp-relayer.events"
+	defaultBufferSize = 1000
+
+	// closeTimeout bounds how long Close waits for buffered events to be published
+	closeTimeout = 5 * time.Second
+)
+
+// Event is published when a message reaches a new status
+type Event struct {
+	IrisLookupID    string       `json:"iris_lookup_id"`
+	Status          string       `json:"status"`
+	PreviousStatus  string       `json:"previous_status,omitempty"`
+	SourceDomain    types.Domain `json:"source_domain"`
+	DestDomain      types.Domain `json:"dest_domain"`
+	Nonce           uint64       `json:"nonce"`
+	SourceTxHash    string       `json:"source_tx_hash"`
+	DestTxHash      string       `json:"dest_tx_hash,omitempty"`
+	Amount          string       `json:"amount,omitempty"`           // raw burn amount in the token's base units
+	AmountFormatted string       `json:"amount_formatted,omitempty"` // burn amount scaled by the token decimals
+	Reason          string       `json:"reason,omitempty"`           // why the message was filtered or failed, if known
+	Timestamp       time.Time    `json:"timestamp"`
+}
+
+// NewEvent creates an event for the current status of a message, must be called with the state locked
+func NewEvent(msg *types.MessageState, previousStatus string) Event {
+	event := Event{
+		IrisLookupID:   msg.IrisLookupID,
+		Status:         msg.Status,
+		PreviousStatus: previousStatus,
+		SourceDomain:   msg.SourceDomain,
+		DestDomain:     msg.DestDomain,
+		Nonce:          msg.Nonce,
+		SourceTxHash:   msg.SourceTxHash,
+		DestTxHash:     msg.DestTxHash,
+		Timestamp:      time.Now(),
+	}
+
+	if bm, err := new(types.BurnMessage).Parse(msg.MsgBody); err == nil {
+		event.Amount = bm.Amount.String()
+		event.AmountFormatted = msg.AmountFormatted
+	}
+	switch msg.Status {
+	case types.Filtered:
+		event.Reason = msg.FilterReason
+	case types.Failed:
+		event.Reason = msg.FailureReason
+	}
+
+	return event
+}
+
+// Publisher publishes relay lifecycle events to a message bus. Publish must never block the caller, delivery
+// failures are logged by the publisher.
+type Publisher interface {
+	Publish(event Event)
+	// Close publishes the buffered events, waiting at most a few seconds, and disconnects from the bus
+	Close() error
+}
+
+var _ Publisher = NopPublisher{}
+
+// NopPublisher discards events, used when no message bus is configured
+type NopPublisher struct{}
+
+func (NopPublisher) Publish(Event) {}
+
+func (NopPublisher) Close() error { return nil }
+
+// New returns the publisher for the configured message bus, a NopPublisher if none is configured
+func New(cfg types.EventsConfig, logger log.Logger) (Publisher, error) {
+	topic := cfg.Topic
+	if topic == "" {
+		topic = defaultTopic
+	}
+
+	switch cfg.Publisher {
+	case "":
+		return NopPublisher{}, nil
+	case PublisherNATS, PublisherKafka:
+	default:
+		return nil, fmt.Errorf("unknown event publisher %q, expected %s or %s", cfg.Publisher, PublisherNATS, PublisherKafka)
+	}
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("events url is required for the %s publisher", cfg.Publisher)
+	}
+
+	var s sender
+	var err error
+	if cfg.Publisher == PublisherNATS {
+		s, err = newNATSSender(cfg.URL, topic, cfg.TLS, logger)
+	} else {
+		s, err = newKafkaSender(cfg.URL, topic, cfg.TLS)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bufferSize := int(cfg.BufferSize)
+	if bufferSize == 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	logger = logger.With("component", "event-publisher", "publisher", cfg.Publisher, "topic", topic)
+	logger.Info("Publishing relay events")
+	return newAsyncPublisher(s, bufferSize, logger), nil
+}
+
+// sender delivers events to a message bus
+type sender interface {
+	send(ctx context.Context, event Event) error
+	close() error
+}
+
+// asyncPublisher buffers events and sends them in order from a background goroutine, dropping new events while
+// the buffer is full so a slow bus never holds up relaying
+type asyncPublisher struct {
+	sender sender
+	events chan Event
+	done   chan struct{}
+	logger log.Logger
+
+	// guards closing events, events published after Close are dropped
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newAsyncPublisher(s sender, bufferSize int, logger log.Logger) *asyncPublisher {
+	p := &asyncPublisher{
+		sender: s,
+		events: make(chan Event, bufferSize),
+		done:   make(chan struct{}),
+		logger: logger,
+	}
+	go p.run()
+	return p
+}
+
+func (p *asyncPublisher) Publish(event Event) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return
+	}
+
+	select {
+	case p.events <- event:
+	default:
+		p.logger.Error("Event buffer is full, dropping event", "tx", event.SourceTxHash, "nonce", event.Nonce, "status", event.Status)
+	}
+}
+
+func (p *asyncPublisher) run() {
+	defer close(p.done)
+	for event := range p.events {
+		ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+		if err := p.sender.send(ctx, event); err != nil {
+			p.logger.Error("Failed to publish event", "tx", event.SourceTxHash, "nonce", event.Nonce, "status", event.Status, "error", err)
+		}
+		cancel()
+	}
+}
+
+func (p *asyncPublisher) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.events)
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+	case <-time.After(closeTimeout):
+		p.logger.Error("Timed out publishing buffered events", "dropped", len(p.events))
+	}
+	return p.sender.close()
+}
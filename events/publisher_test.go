@@ -0,0 +1,154 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestNew verifies the configured publisher is returned and invalid configs are rejected
+func TestNew(t *testing.T) {
+	p, err := New(types.EventsConfig{}, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, NopPublisher{}, p)
+
+	_, err = New(types.EventsConfig{Publisher: "rabbitmq", URL: "amqp://localhost"}, log.NewNopLogger())
+	require.ErrorContains(t, err, "unknown event publisher")
+
+	_, err = New(types.EventsConfig{Publisher: PublisherNATS}, log.NewNopLogger())
+	require.ErrorContains(t, err, "url is required")
+}
+
+// TestNATSPublisher verifies events are published to the configured subject after the CONNECT handshake
+func TestNATSPublisher(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	connectOpts := make(chan map[string]any, 1)
+	published := make(chan string, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		_, _ = conn.Write([]byte("INFO {\"server_id\":\"test\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n"))
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				var opts map[string]any
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "CONNECT ")), &opts)
+				connectOpts <- opts
+			case strings.HasPrefix(line, "PING"):
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			case strings.HasPrefix(line, "PUB "):
+				fields := strings.Fields(line)
+				size, _ := strconv.Atoi(fields[2])
+				payload := make([]byte, size+2)
+				if _, err := r.Read(payload); err != nil {
+					return
+				}
+				published <- fields[1] + " " + string(payload[:size])
+			}
+		}
+	}()
+
+	p, err := New(types.EventsConfig{Publisher: PublisherNATS, URL: "nats://relayer:secret@" + ln.Addr().String(), Topic: "relays"}, log.NewNopLogger())
+	require.NoError(t, err)
+
+	p.Publish(Event{IrisLookupID: "abc", Status: types.Complete, Nonce: 7})
+
+	opts := <-connectOpts
+	require.Equal(t, "relayer", opts["user"])
+	require.Equal(t, "secret", opts["pass"])
+
+	select {
+	case msg := <-published:
+		subject, payload, _ := strings.Cut(msg, " ")
+		require.Equal(t, "relays", subject)
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(payload), &event))
+		require.Equal(t, "abc", event.IrisLookupID)
+		require.Equal(t, uint64(7), event.Nonce)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not published")
+	}
+	require.NoError(t, p.Close())
+}
+
+// TestKafkaRecord verifies events are produced keyed by iris lookup id
+func TestKafkaRecord(t *testing.T) {
+	record, err := kafkaRecord(Event{IrisLookupID: "abc", Status: types.Complete, Nonce: 7})
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(record.Key))
+
+	var event Event
+	require.NoError(t, json.Unmarshal(record.Value, &event))
+	require.Equal(t, types.Complete, event.Status)
+	require.Equal(t, uint64(7), event.Nonce)
+
+	s, err := newKafkaSender("127.0.0.1:1,127.0.0.1:2", "relays", false)
+	require.NoError(t, err)
+	require.NoError(t, s.close())
+}
+
+type blockingSender struct {
+	release chan struct{}
+	sent    chan Event
+}
+
+func (s *blockingSender) send(_ context.Context, event Event) error {
+	<-s.release
+	s.sent <- event
+	return nil
+}
+
+func (s *blockingSender) close() error { return nil }
+
+// TestAsyncPublisherDropsWhenFull verifies Publish never blocks on a slow bus and buffered events are sent on Close
+func TestAsyncPublisherDropsWhenFull(t *testing.T) {
+	s := &blockingSender{release: make(chan struct{}), sent: make(chan Event, 3)}
+	p := newAsyncPublisher(s, 1, log.NewNopLogger())
+
+	// the first event is taken by the sender, the second fills the buffer and the third is dropped
+	p.Publish(Event{Nonce: 1})
+	require.Eventually(t, func() bool { return len(p.events) == 0 }, time.Second, 10*time.Millisecond)
+	p.Publish(Event{Nonce: 2})
+	p.Publish(Event{Nonce: 3})
+
+	close(s.release)
+	require.NoError(t, p.Close())
+	require.Len(t, s.sent, 2)
+	require.Equal(t, uint64(1), (<-s.sent).Nonce)
+	require.Equal(t, uint64(2), (<-s.sent).Nonce)
+}
+
+// TestAsyncPublisherPublishAfterClose verifies events published after Close are dropped instead of panicking
+func TestAsyncPublisherPublishAfterClose(t *testing.T) {
+	s := &blockingSender{release: make(chan struct{}), sent: make(chan Event, 1)}
+	close(s.release)
+	p := newAsyncPublisher(s, 1, log.NewNopLogger())
+
+	require.NoError(t, p.Close())
+	require.NoError(t, p.Close())
+	require.NotPanics(t, func() { p.Publish(Event{Nonce: 1}) })
+	require.Empty(t, s.sent)
+}
@@ -3,7 +3,9 @@ package filters
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -17,13 +19,15 @@ import (
 
 const DefaultWhitelistRefreshInterval = 300 // 5 minutes
 
-// DepositorWhitelistFilter filters messages by depositor address (EVM chains only)
+// DepositorWhitelistFilter filters messages by depositor address (EVM chains only). The whitelist is the union of
+// the addresses of every configured source.
 type DepositorWhitelistFilter struct {
 	mu              sync.RWMutex
 	whitelist       map[string]bool
-	provider        types.DataProvider
-	kvKey           string
+	sources         []*whitelistSource
 	refreshInterval time.Duration
+	domainTypes     map[types.Domain]types.ChainType
+	nonEVMDomains   map[types.Domain]bool
 	logger          log.Logger
 	stopCh          chan struct{}
 }
@@ -42,41 +46,18 @@ func (f *DepositorWhitelistFilter) Name() string {
 func (f *DepositorWhitelistFilter) Initialize(ctx context.Context, config map[string]interface{}, logger log.Logger) error {
 	f.logger = logger
 
-	providerName, ok := config["provider"].(string)
-	if !ok {
-		return fmt.Errorf("depositor-whitelist filter requires 'provider' in config")
+	sourceConfigs, err := parseSourceConfigs(config)
+	if err != nil {
+		return err
 	}
-
-	providerConfig, ok := config["provider_config"].(map[string]interface{})
-	if !ok {
-		// yaml.v2 unmarshals nested maps as map[interface{}]interface{}
-		if rawMap, ok2 := config["provider_config"].(map[interface{}]interface{}); ok2 {
-			providerConfig = make(map[string]interface{}, len(rawMap))
-			for k, v := range rawMap {
-				providerConfig[fmt.Sprintf("%v", k)] = v
-			}
-		} else {
-			return fmt.Errorf("depositor-whitelist filter requires 'provider_config' in config")
+	for _, sourceConfig := range sourceConfigs {
+		source, err := newWhitelistSource(sourceConfig)
+		if err != nil {
+			return err
 		}
+		f.sources = append(f.sources, source)
 	}
 
-	switch providerName {
-	case "quicknode-kv":
-		f.provider = types.NewQuickNodeKVProvider()
-	default:
-		return fmt.Errorf("unknown provider: %s", providerName)
-	}
-
-	if err := f.provider.Initialize(providerConfig); err != nil {
-		return fmt.Errorf("failed to initialize provider: %w", err)
-	}
-
-	kvKey, ok := config["kv_key"].(string)
-	if !ok || kvKey == "" {
-		return fmt.Errorf("depositor-whitelist filter requires 'kv_key' in config")
-	}
-	f.kvKey = kvKey
-
 	refreshInterval := DefaultWhitelistRefreshInterval
 	// YAML unmarshals numbers as float64, not int
 	if val, ok := config["refresh_interval"].(float64); ok && val > 0 {
@@ -86,15 +67,32 @@ func (f *DepositorWhitelistFilter) Initialize(ctx context.Context, config map[st
 	}
 	f.refreshInterval = time.Duration(refreshInterval) * time.Second
 
+	if domainTypes, ok := config["domain_types"].(map[types.Domain]types.ChainType); ok {
+		f.domainTypes = domainTypes
+	}
+
+	nonEVMDomains, err := parseDomains(config["non_evm_domains"])
+	if err != nil {
+		return fmt.Errorf("invalid non_evm_domains: %w", err)
+	}
+	f.nonEVMDomains = make(map[types.Domain]bool, len(nonEVMDomains))
+	for _, domain := range nonEVMDomains {
+		f.nonEVMDomains[domain] = true
+	}
+
 	if err := f.refresh(ctx); err != nil {
 		f.logger.Error("Failed to fetch initial whitelist", "error", err)
 		return err
 	}
 
+	sourceNames := make([]string, len(f.sources))
+	for i, source := range f.sources {
+		sourceNames[i] = source.name()
+	}
 	f.logger.Info("Depositor whitelist filter initialized",
-		"provider", providerName,
-		"kv_key", f.kvKey,
+		"sources", sourceNames,
 		"refresh_interval", f.refreshInterval,
+		"non_evm_domains", nonEVMDomains,
 		"initial_count", f.Count())
 
 	go f.startRefresh(ctx)
@@ -102,13 +100,13 @@ func (f *DepositorWhitelistFilter) Initialize(ctx context.Context, config map[st
 }
 
 func (f *DepositorWhitelistFilter) Filter(ctx context.Context, msg *types.MessageState) (shouldFilter bool, reason string, err error) {
-	if !isEVMDomain(msg.SourceDomain) {
+	if !f.isEVMDomain(msg.SourceDomain) {
 		return false, "", nil
 	}
 
 	depositor, err := getDepositor(msg)
 	if err != nil {
-		f.logger.Error("Failed to extract depositor address", "tx", msg.SourceTxHash, "error", err)
+		types.LoggerFromContext(ctx, types.MessageLogger(f.logger, msg)).Error("Failed to extract depositor address", "tx", msg.SourceTxHash, "error", err)
 		return true, "failed to extract depositor address", nil
 	}
 
@@ -125,10 +123,13 @@ func (f *DepositorWhitelistFilter) Filter(ctx context.Context, msg *types.Messag
 // Close stops the background refresh and cleans up resources
 func (f *DepositorWhitelistFilter) Close() error {
 	close(f.stopCh)
-	if f.provider != nil {
-		return f.provider.Close()
+	var errs []error
+	for _, source := range f.sources {
+		if err := source.provider.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // startRefresh begins the periodic whitelist refresh
@@ -153,17 +154,22 @@ func (f *DepositorWhitelistFilter) startRefresh(ctx context.Context) {
 	}
 }
 
+// refresh fetches every source and replaces the whitelist with their union. A source that fails to fetch keeps
+// contributing the addresses of its last successful fetch. Returns an error only if every source failed.
 func (f *DepositorWhitelistFilter) refresh(ctx context.Context) error {
-	addresses, err := f.provider.FetchList(ctx, f.kvKey)
-	if err != nil {
-		return err
+	var errs []error
+	for _, source := range f.sources {
+		if err := source.refresh(ctx); err != nil {
+			f.logger.Error("Failed to refresh whitelist source, keeping its last fetched addresses",
+				"source", source.name(), "count", len(source.addresses), "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", source.name(), err))
+		}
 	}
 
-	newWhitelist := make(map[string]bool, len(addresses))
-	for _, addr := range addresses {
-		normalized := normalizeAddress(addr)
-		if normalized != "" {
-			newWhitelist[normalized] = true
+	newWhitelist := make(map[string]bool)
+	for _, source := range f.sources {
+		for addr := range source.addresses {
+			newWhitelist[addr] = true
 		}
 	}
 
@@ -174,6 +180,9 @@ func (f *DepositorWhitelistFilter) refresh(ctx context.Context) error {
 	if len(newWhitelist) == 0 {
 		f.logger.Info("Whitelist is empty after refresh")
 	}
+	if len(errs) == len(f.sources) {
+		return errors.Join(errs...)
+	}
 	return nil
 }
 
@@ -193,6 +202,107 @@ func (f *DepositorWhitelistFilter) Count() int {
 	return len(f.whitelist)
 }
 
+// whitelistSource is a list of whitelisted depositors stored under a key of a data provider
+type whitelistSource struct {
+	provider types.DataProvider
+	kvKey    string
+	// addresses of the last successful fetch
+	addresses map[string]bool
+}
+
+// newWhitelistSource creates a source from its provider, provider_config and kv_key config
+func newWhitelistSource(config map[string]interface{}) (*whitelistSource, error) {
+	providerName, ok := config["provider"].(string)
+	if !ok {
+		return nil, fmt.Errorf("depositor-whitelist filter requires 'provider' in config")
+	}
+
+	providerConfig, err := stringKeyedMap(config["provider_config"])
+	if err != nil {
+		return nil, fmt.Errorf("depositor-whitelist filter requires 'provider_config' in config")
+	}
+
+	var provider types.DataProvider
+	switch providerName {
+	case "quicknode-kv":
+		provider = types.NewQuickNodeKVProvider()
+	default:
+		return nil, fmt.Errorf("unknown provider: %s", providerName)
+	}
+
+	if err := provider.Initialize(providerConfig); err != nil {
+		return nil, fmt.Errorf("failed to initialize provider: %w", err)
+	}
+
+	kvKey, ok := config["kv_key"].(string)
+	if !ok || kvKey == "" {
+		return nil, fmt.Errorf("depositor-whitelist filter requires 'kv_key' in config")
+	}
+
+	return &whitelistSource{provider: provider, kvKey: kvKey}, nil
+}
+
+func (s *whitelistSource) name() string {
+	return s.provider.Name() + "/" + s.kvKey
+}
+
+// refresh replaces the addresses of the source, keeping the previous ones if the fetch fails
+func (s *whitelistSource) refresh(ctx context.Context) error {
+	addresses, err := s.provider.FetchList(ctx, s.kvKey)
+	if err != nil {
+		return err
+	}
+
+	fetched := make(map[string]bool, len(addresses))
+	for _, addr := range addresses {
+		normalized := normalizeAddress(addr)
+		if normalized != "" {
+			fetched[normalized] = true
+		}
+	}
+	s.addresses = fetched
+	return nil
+}
+
+// parseSourceConfigs returns the config of each whitelist source, from the sources list or, if it is not set,
+// the provider, provider_config and kv_key of the filter config itself
+func parseSourceConfigs(config map[string]interface{}) ([]map[string]interface{}, error) {
+	raw, ok := config["sources"]
+	if !ok {
+		return []map[string]interface{}{config}, nil
+	}
+
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("depositor-whitelist filter 'sources' must be a non-empty list")
+	}
+	sources := make([]map[string]interface{}, len(list))
+	for i, v := range list {
+		source, err := stringKeyedMap(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid depositor-whitelist source %d: %w", i, err)
+		}
+		sources[i] = source
+	}
+	return sources, nil
+}
+
+// stringKeyedMap converts a nested yaml map, which yaml.v2 unmarshals as map[interface{}]interface{}
+func stringKeyedMap(raw interface{}) (map[string]interface{}, error) {
+	switch m := raw.(type) {
+	case map[string]interface{}:
+		return m, nil
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			converted[fmt.Sprintf("%v", k)] = v
+		}
+		return converted, nil
+	default:
+		return nil, fmt.Errorf("expected a map, got %T", raw)
+	}
+}
+
 func normalizeAddress(address string) string {
 	address = strings.TrimSpace(address)
 	if !common.IsHexAddress(address) {
@@ -213,7 +323,18 @@ func getDepositor(msg *types.MessageState) (string, error) {
 	return "0x" + hex.EncodeToString(address), nil
 }
 
-func isEVMDomain(domain types.Domain) bool {
+// isEVMDomain reports whether messages from the domain carry an EVM depositor. Domains listed in
+// non_evm_domains take precedence, then the chain type of configured chains, then the known non-EVM domains.
+// Unknown domains are treated as EVM so their depositor must be whitelisted.
+func (f *DepositorWhitelistFilter) isEVMDomain(domain types.Domain) bool {
+	if f.nonEVMDomains[domain] {
+		return false
+	}
+
+	if chainType, ok := f.domainTypes[domain]; ok {
+		return chainType == types.ChainTypeEVM
+	}
+
 	switch domain {
 	case 4, 5, 15, 25: // Noble, Solana, Monad, Starknet Testnet
 		return false
@@ -221,3 +342,39 @@ func isEVMDomain(domain types.Domain) bool {
 		return true
 	}
 }
+
+// parseDomains parses a yaml list of domains, which may be decoded as any integer or float type
+func parseDomains(raw interface{}) ([]types.Domain, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list of domains, got %T", raw)
+	}
+
+	domains := make([]types.Domain, 0, len(list))
+	for _, v := range list {
+		var domain int64
+		switch n := v.(type) {
+		case int:
+			domain = int64(n)
+		case int64:
+			domain = n
+		case uint64:
+			domain = int64(n)
+		case float64:
+			if n != float64(int64(n)) {
+				return nil, fmt.Errorf("domain %v is not an integer", n)
+			}
+			domain = int64(n)
+		default:
+			return nil, fmt.Errorf("domain %v has invalid type %T", v, v)
+		}
+		if domain < 0 || domain > math.MaxUint32 {
+			return nil, fmt.Errorf("domain %d is out of range", domain)
+		}
+		domains = append(domains, types.Domain(domain))
+	}
+	return domains, nil
+}
@@ -3,6 +3,7 @@ package filters
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"os"
 	"testing"
@@ -19,12 +20,13 @@ const testAddr = "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"
 
 type MockDataProvider struct {
 	addresses []string
+	err       error
 }
 
 func (m *MockDataProvider) Name() string                                   { return "mock" }
 func (m *MockDataProvider) Initialize(config map[string]interface{}) error { return nil }
 func (m *MockDataProvider) FetchList(ctx context.Context, key string) ([]string, error) {
-	return m.addresses, nil
+	return m.addresses, m.err
 }
 func (m *MockDataProvider) Close() error { return nil }
 
@@ -43,8 +45,7 @@ func createBurnMessage(addr string) []byte {
 
 func setupFilter(addresses []string) *DepositorWhitelistFilter {
 	f := NewDepositorWhitelistFilter()
-	f.provider = &MockDataProvider{addresses: addresses}
-	f.kvKey = "test"
+	f.sources = []*whitelistSource{{provider: &MockDataProvider{addresses: addresses}, kvKey: "test"}}
 	f.refreshInterval = 300
 	f.logger = log.NewLogger(os.Stdout, log.LevelOption(zerolog.DebugLevel))
 	_ = f.refresh(context.Background())
@@ -122,3 +123,114 @@ func TestNormalizeAddress(t *testing.T) {
 		require.Equal(t, tt.want, normalizeAddress(tt.in))
 	}
 }
+
+// TestDepositorWhitelistFilter_NewNonEVMDomain verifies a newly added non-EVM domain is skipped once it is
+// known from the chain configs or the override list, and filtered while unknown
+func TestDepositorWhitelistFilter_NewNonEVMDomain(t *testing.T) {
+	const newDomain = types.Domain(30)
+	msg := &types.MessageState{
+		SourceDomain: newDomain,
+		DestDomain:   types.Domain(0),
+		SourceTxHash: "0x123",
+		MsgBody:      createBurnMessage(testAddr),
+	}
+
+	// unknown domains are treated as EVM, so a non-whitelisted depositor is filtered
+	filter := setupFilter([]string{})
+	filtered, reason, err := filter.Filter(context.Background(), msg)
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "non-whitelisted")
+
+	// chain type from the registered chain configs
+	filter = setupFilter([]string{})
+	filter.domainTypes = map[types.Domain]types.ChainType{newDomain: types.ChainTypeCosmos}
+	filtered, _, err = filter.Filter(context.Background(), msg)
+	require.NoError(t, err)
+	require.False(t, filtered)
+
+	// override list
+	filter = setupFilter([]string{})
+	filter.nonEVMDomains = map[types.Domain]bool{newDomain: true}
+	filtered, _, err = filter.Filter(context.Background(), msg)
+	require.NoError(t, err)
+	require.False(t, filtered)
+}
+
+// TestDepositorWhitelistFilter_ConfiguredEVMDomain verifies configured chain types take precedence over the known non-EVM domains
+func TestDepositorWhitelistFilter_ConfiguredEVMDomain(t *testing.T) {
+	filter := setupFilter([]string{})
+	filter.domainTypes = map[types.Domain]types.ChainType{15: types.ChainTypeEVM}
+
+	filtered, _, err := filter.Filter(context.Background(), &types.MessageState{
+		SourceDomain: types.Domain(15),
+		DestDomain:   types.Domain(0),
+		SourceTxHash: "0x123",
+		MsgBody:      createBurnMessage(testAddr),
+	})
+	require.NoError(t, err)
+	require.True(t, filtered)
+}
+
+// TestDepositorWhitelistFilter_MultipleSources verifies the whitelist is the union of every source and a source
+// that fails to refresh keeps its last fetched addresses
+func TestDepositorWhitelistFilter_MultipleSources(t *testing.T) {
+	const partnerAddr = "0x8589427373d6d84e98730d7795d8f6f8731fda16"
+	internal := &MockDataProvider{addresses: []string{testAddr}}
+	partner := &MockDataProvider{addresses: []string{partnerAddr}}
+
+	f := NewDepositorWhitelistFilter()
+	f.sources = []*whitelistSource{{provider: internal, kvKey: "internal"}, {provider: partner, kvKey: "partner"}}
+	f.logger = log.NewNopLogger()
+	require.NoError(t, f.refresh(context.Background()))
+	require.True(t, f.isWhitelisted(testAddr))
+	require.True(t, f.isWhitelisted(partnerAddr))
+
+	partner.err = errors.New("kv unavailable")
+	internal.addresses = nil
+	require.NoError(t, f.refresh(context.Background()))
+	require.False(t, f.isWhitelisted(testAddr))
+	require.True(t, f.isWhitelisted(partnerAddr))
+
+	// every source failing is reported, the last fetched addresses are still used
+	internal.err = errors.New("kv unavailable")
+	require.Error(t, f.refresh(context.Background()))
+	require.Equal(t, 1, f.Count())
+}
+
+func TestParseSourceConfigs(t *testing.T) {
+	legacy := map[string]interface{}{"provider": "quicknode-kv", "kv_key": "list"}
+	sources, err := parseSourceConfigs(legacy)
+	require.NoError(t, err)
+	require.Equal(t, []map[string]interface{}{legacy}, sources)
+
+	sources, err = parseSourceConfigs(map[string]interface{}{"sources": []interface{}{
+		map[interface{}]interface{}{"provider": "quicknode-kv", "kv_key": "internal"},
+		map[interface{}]interface{}{"provider": "quicknode-kv", "kv_key": "partner"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	require.Equal(t, "partner", sources[1]["kv_key"])
+
+	_, err = parseSourceConfigs(map[string]interface{}{"sources": []interface{}{}})
+	require.Error(t, err)
+}
+
+func TestParseDomains(t *testing.T) {
+	domains, err := parseDomains(nil)
+	require.NoError(t, err)
+	require.Empty(t, domains)
+
+	domains, err = parseDomains([]interface{}{30, float64(31), uint64(32)})
+	require.NoError(t, err)
+	require.Equal(t, []types.Domain{30, 31, 32}, domains)
+
+	_, err = parseDomains("30")
+	require.Error(t, err)
+
+	_, err = parseDomains([]interface{}{1.5})
+	require.Error(t, err)
+
+	_, err = parseDomains([]interface{}{-1})
+	require.Error(t, err)
+}
@@ -3,16 +3,19 @@ package filters
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"cosmossdk.io/log"
 
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
-// DestinationCallerFilter validates destination caller addresses
+// DestinationCallerFilter validates destination caller addresses. Messages with a destination caller other than the
+// minter are always filtered, messages without a destination caller only to the destination domains listed as
+// destination caller only.
 type DestinationCallerFilter struct {
 	registeredDomains     map[types.Domain]types.Chain
-	destinationCallerOnly bool
+	destinationCallerOnly types.DestinationCallerPolicy
 	logger                log.Logger
 }
 
@@ -36,25 +39,42 @@ func (f *DestinationCallerFilter) Initialize(ctx context.Context, config map[str
 	}
 	f.registeredDomains = domains
 
-	destCallerOnlyRaw, ok := config["destination_caller_only"]
-	if !ok {
-		f.destinationCallerOnly = false
-	} else {
-		destCallerOnly, ok := destCallerOnlyRaw.(bool)
-		if !ok {
-			return fmt.Errorf("destination_caller_only has invalid type")
-		}
+	// destination_caller_only is a bool for every destination domain, or set per destination domain
+	switch destCallerOnly := config["destination_caller_only"].(type) {
+	case nil:
+	case bool:
+		f.destinationCallerOnly = types.DestinationCallerPolicy{Default: destCallerOnly}
+	case map[types.Domain]bool:
+		f.destinationCallerOnly = types.DestinationCallerPolicy{PerDomain: destCallerOnly}
+	case types.DestinationCallerPolicy:
 		f.destinationCallerOnly = destCallerOnly
+	default:
+		return fmt.Errorf("destination_caller_only has invalid type")
 	}
 
-	mode := "permissionless"
-	if f.destinationCallerOnly {
-		mode = "destination-caller-only"
+	var callerOnlyDomains, permissionlessDomains []types.Domain
+	for domain, only := range f.destinationCallerOnly.PerDomain {
+		if only {
+			callerOnlyDomains = append(callerOnlyDomains, domain)
+		} else {
+			permissionlessDomains = append(permissionlessDomains, domain)
+		}
 	}
-	logger.Info("Destination caller filter initialized", "mode", mode)
+	slices.Sort(callerOnlyDomains)
+	slices.Sort(permissionlessDomains)
+	logger.Info("Destination caller filter initialized", "mode", policyMode(f.destinationCallerOnly.Default),
+		"destination_caller_only_domains", callerOnlyDomains, "permissionless_domains", permissionlessDomains)
 	return nil
 }
 
+// policyMode names the destination caller policy of the domains without a per domain setting
+func policyMode(callerOnly bool) string {
+	if callerOnly {
+		return "destination-caller-only"
+	}
+	return "permissionless"
+}
+
 func (f *DestinationCallerFilter) Filter(ctx context.Context, msg *types.MessageState) (bool, string, error) {
 	chain, ok := f.registeredDomains[msg.DestDomain]
 	if !ok {
@@ -62,12 +82,21 @@ func (f *DestinationCallerFilter) Filter(ctx context.Context, msg *types.Message
 		return true, reason, nil
 	}
 
+	callerOnly := f.destinationCallerOnly.For(msg.DestDomain)
+
+	// chains accept an empty destination caller, which lets anyone relay the message
+	if f.destinationCallerOnly.RequiresCaller(msg.DestDomain) && isZeroCaller(msg.DestinationCaller) {
+		reason := fmt.Sprintf("destination caller required: source_domain=%d dest_domain=%d has no destination caller",
+			msg.SourceDomain, msg.DestDomain)
+		return true, reason, nil
+	}
+
 	validCaller, address := chain.IsDestinationCaller(msg.DestinationCaller)
 	if validCaller {
 		return false, "", nil
 	}
 
-	shouldFilter := f.destinationCallerOnly || address != ""
+	shouldFilter := callerOnly || address != ""
 	if shouldFilter {
 		reason := fmt.Sprintf("destination caller mismatch: source_domain=%d dest_domain=%d caller=%s",
 			msg.SourceDomain, msg.DestDomain, address)
@@ -76,6 +105,16 @@ func (f *DestinationCallerFilter) Filter(ctx context.Context, msg *types.Message
 	return false, "", nil
 }
 
+// isZeroCaller returns true if the destination caller was left empty in the deposit for burn
+func isZeroCaller(destinationCaller []byte) bool {
+	for _, b := range destinationCaller {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (f *DestinationCallerFilter) Close() error {
 	return nil
 }
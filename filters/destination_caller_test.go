@@ -0,0 +1,102 @@
+package filters_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/filters"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// callerChain is a destination chain whose minter is the only valid destination caller besides an empty one
+type callerChain struct {
+	types.Chain
+	minter []byte
+}
+
+func (c callerChain) IsDestinationCaller(destinationCaller []byte) (bool, string) {
+	if bytes.Equal(destinationCaller, make([]byte, 32)) || bytes.Equal(destinationCaller, c.minter) {
+		return true, hex.EncodeToString(destinationCaller)
+	}
+	return false, hex.EncodeToString(destinationCaller)
+}
+
+func TestDestinationCallerFilterMixedPolicy(t *testing.T) {
+	minter := bytes.Repeat([]byte{1}, 32)
+	other := bytes.Repeat([]byte{2}, 32)
+	empty := make([]byte, 32)
+
+	f := filters.NewDestinationCallerFilter()
+	require.NoError(t, f.Initialize(context.Background(), map[string]interface{}{
+		"registered_domains": map[types.Domain]types.Chain{
+			0: callerChain{minter: minter},
+			5: callerChain{minter: minter},
+		},
+		"destination_caller_only": types.DestinationCallerPolicy{PerDomain: map[types.Domain]bool{5: true}},
+	}, log.NewNopLogger()))
+
+	tests := []struct {
+		destDomain types.Domain
+		caller     []byte
+		filtered   bool
+	}{
+		// solana requires the relayer as destination caller
+		{5, minter, false},
+		{5, empty, true},
+		{5, other, true},
+		// permissionless elsewhere, but another relayer's messages are never relayed
+		{0, minter, false},
+		{0, empty, false},
+		{0, other, true},
+	}
+	for _, tt := range tests {
+		filtered, reason, err := f.Filter(context.Background(), &types.MessageState{DestDomain: tt.destDomain, DestinationCaller: tt.caller})
+		require.NoError(t, err)
+		require.Equal(t, tt.filtered, filtered, "dest domain %d caller %x: %s", tt.destDomain, tt.caller, reason)
+	}
+}
+
+func TestDestinationCallerFilterGlobalPolicy(t *testing.T) {
+	domains := map[types.Domain]types.Chain{0: callerChain{}, 5: callerChain{}}
+
+	// a bool applies to every destination domain and keeps relaying messages without a destination caller
+	f := filters.NewDestinationCallerFilter()
+	require.NoError(t, f.Initialize(context.Background(), map[string]interface{}{
+		"registered_domains":      domains,
+		"destination_caller_only": true,
+	}, log.NewNopLogger()))
+	for domain := range domains {
+		filtered, reason, err := f.Filter(context.Background(), &types.MessageState{DestDomain: domain, DestinationCaller: make([]byte, 32)})
+		require.NoError(t, err)
+		require.False(t, filtered, reason)
+		filtered, reason, err = f.Filter(context.Background(), &types.MessageState{DestDomain: domain, DestinationCaller: bytes.Repeat([]byte{2}, 32)})
+		require.NoError(t, err)
+		require.True(t, filtered)
+		require.Contains(t, reason, "destination caller mismatch")
+	}
+
+	// a domain listed as destination caller only also drops messages without a destination caller
+	f = filters.NewDestinationCallerFilter()
+	require.NoError(t, f.Initialize(context.Background(), map[string]interface{}{
+		"registered_domains":      domains,
+		"destination_caller_only": map[types.Domain]bool{0: true},
+	}, log.NewNopLogger()))
+	filtered, reason, err := f.Filter(context.Background(), &types.MessageState{DestDomain: 0, DestinationCaller: make([]byte, 32)})
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "destination caller required")
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{DestDomain: 5, DestinationCaller: make([]byte, 32)})
+	require.NoError(t, err)
+	require.False(t, filtered)
+
+	require.Error(t, f.Initialize(context.Background(), map[string]interface{}{
+		"registered_domains":      domains,
+		"destination_caller_only": "yes",
+	}, log.NewNopLogger()))
+}
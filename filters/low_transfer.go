@@ -2,7 +2,9 @@ package filters
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"math/big"
 
 	cctptypes "github.com/circlefin/noble-cctp/x/cctp/types"
 
@@ -17,8 +19,10 @@ import (
 
 // LowTransferFilter filters transfers below minimum mint amounts
 type LowTransferFilter struct {
-	chains map[string]types.ChainConfig
-	logger log.Logger
+	chains     map[string]types.ChainConfig
+	minAmounts map[types.Domain]map[string]uint64 // dest domain -> burn token (32-byte hex) -> min amount
+	config     *types.Config                      // formats amounts with the token decimals in filter reasons, nil formats with 6 decimals
+	logger     log.Logger
 }
 
 func NewLowTransferFilter() *LowTransferFilter {
@@ -40,7 +44,30 @@ func (f *LowTransferFilter) Initialize(ctx context.Context, config map[string]in
 		return fmt.Errorf("chains has invalid type")
 	}
 	f.chains = chains
-	logger.Info("Low transfer filter initialized", "chain_count", len(chains))
+
+	if cfg, ok := config["config"].(*types.Config); ok {
+		f.config = cfg
+	}
+
+	f.minAmounts = make(map[types.Domain]map[string]uint64)
+	if minAmountsRaw, ok := config["min_amounts"]; ok && minAmountsRaw != nil {
+		minAmounts, ok := minAmountsRaw.(map[types.Domain]map[string]uint64)
+		if !ok {
+			return fmt.Errorf("min_amounts has invalid type")
+		}
+		for domain, tokens := range minAmounts {
+			f.minAmounts[domain] = make(map[string]uint64, len(tokens))
+			for token, amount := range tokens {
+				key, err := normalizeBurnToken(token)
+				if err != nil {
+					return fmt.Errorf("invalid token in min_amounts for domain %d: %w", domain, err)
+				}
+				f.minAmounts[domain][key] = amount
+			}
+		}
+	}
+
+	logger.Info("Low transfer filter initialized", "chain_count", len(chains), "token_minimums", len(f.minAmounts))
 	return nil
 }
 
@@ -51,46 +78,62 @@ func (f *LowTransferFilter) Filter(ctx context.Context, msg *types.MessageState)
 		return true, reason, nil
 	}
 
-	minBurnAmount := f.getMinMintAmount(msg.DestDomain)
+	minBurnAmount := f.getMinMintAmount(msg.DestDomain, bm.BurnToken)
 	if minBurnAmount == 0 {
 		return false, "", nil
 	}
 
 	if bm.Amount.LT(math.NewIntFromUint64(minBurnAmount)) {
-		reason := fmt.Sprintf("transfer amount too low: amount=%s min_amount=%d dest_domain=%d",
-			bm.Amount.String(), minBurnAmount, msg.DestDomain)
+		reason := fmt.Sprintf("transfer amount too low: amount=%s (%s) min_amount=%s (%d) dest_domain=%d",
+			f.formatAmount(bm.BurnToken, bm.Amount.BigInt()), bm.Amount.String(),
+			f.formatAmount(bm.BurnToken, new(big.Int).SetUint64(minBurnAmount)), minBurnAmount, msg.DestDomain)
 		return true, reason, nil
 	}
 
 	return false, "", nil
 }
 
+// formatAmount formats a raw amount of the burn token with the token decimals
+func (f *LowTransferFilter) formatAmount(burnToken []byte, amount *big.Int) string {
+	if f.config == nil {
+		return new(types.Config).FormatBurnAmount(burnToken, amount)
+	}
+	return f.config.FormatBurnAmount(burnToken, amount)
+}
+
 // Close cleans up filter resources
 func (f *LowTransferFilter) Close() error {
 	return nil
 }
 
-func (f *LowTransferFilter) getMinMintAmount(destDomain types.Domain) uint64 {
-	if destDomain == types.Domain(4) {
-		nobleCfg, ok := f.chains["noble"].(*noble.ChainConfig)
-		if !ok {
-			f.logger.Info("Chain named 'noble' not found in config")
-			return 0
+func (f *LowTransferFilter) getMinMintAmount(destDomain types.Domain, burnToken []byte) uint64 {
+	if tokens, ok := f.minAmounts[destDomain]; ok {
+		if amount, ok := tokens[hex.EncodeToString(burnToken)]; ok {
+			return amount
 		}
-		return nobleCfg.MinMintAmount
 	}
 
 	for _, chain := range f.chains {
+		if domain, _ := chain.DomainType(); domain != destDomain {
+			continue
+		}
 		switch c := chain.(type) {
+		case *noble.ChainConfig:
+			return c.MinMintAmount
 		case *ethereum.ChainConfig:
-			if c.Domain == destDomain {
-				return c.MinMintAmount
-			}
+			return c.MinMintAmount
 		case *solana.ChainConfig:
-			if c.Domain == destDomain {
-				return c.MinMintAmount
-			}
+			return c.MinMintAmount
 		}
 	}
 	return 0
 }
+
+// normalizeBurnToken converts a hex token address into the 32-byte form used in burn messages
+func normalizeBurnToken(token string) (string, error) {
+	padded, err := types.ParseBurnToken(token)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(padded), nil
+}
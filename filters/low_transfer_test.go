@@ -0,0 +1,145 @@
+package filters
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	usdcToken = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	eurcToken = "0x1aBaEA1f7C830bD89Acc67eC4af516284b1bC33c"
+)
+
+func createTokenBurnMessage(token string, amount int64) []byte {
+	tokenBytes, _ := hex.DecodeString(token[2:])
+	burnMsg := make([]byte, 132)
+	copy(burnMsg[4+(32-len(tokenBytes)):36], tokenBytes)
+	amt := big.NewInt(amount)
+	copy(burnMsg[68+(32-len(amt.Bytes())):100], amt.Bytes())
+	return burnMsg
+}
+
+func setupLowTransferFilter(t *testing.T) *LowTransferFilter {
+	t.Helper()
+	f := NewLowTransferFilter()
+	err := f.Initialize(context.Background(), map[string]interface{}{
+		"chains": map[string]types.ChainConfig{
+			"noble": &noble.ChainConfig{MinMintAmount: 100},
+		},
+		"min_amounts": map[types.Domain]map[string]uint64{
+			4: {eurcToken: 5000},
+		},
+	}, log.NewLogger(os.Stdout, log.LevelOption(zerolog.DebugLevel)))
+	require.NoError(t, err)
+	return f
+}
+
+// TestLowTransferFilter_TokenMinimum verifies a token with a configured minimum uses that minimum
+func TestLowTransferFilter_TokenMinimum(t *testing.T) {
+	f := setupLowTransferFilter(t)
+
+	filtered, reason, err := f.Filter(context.Background(), &types.MessageState{
+		DestDomain: 4,
+		MsgBody:    createTokenBurnMessage(eurcToken, 1000),
+	})
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "amount=0.001 (1000) min_amount=0.005 (5000)")
+
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{
+		DestDomain: 4,
+		MsgBody:    createTokenBurnMessage(eurcToken, 5000),
+	})
+	require.NoError(t, err)
+	require.False(t, filtered)
+}
+
+// TestLowTransferFilter_ChainFallback verifies tokens without a configured minimum use the chain minimum
+func TestLowTransferFilter_ChainFallback(t *testing.T) {
+	f := setupLowTransferFilter(t)
+
+	filtered, reason, err := f.Filter(context.Background(), &types.MessageState{
+		DestDomain: 4,
+		MsgBody:    createTokenBurnMessage(usdcToken, 50),
+	})
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "min_amount=0.0001 (100)")
+
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{
+		DestDomain: 4,
+		MsgBody:    createTokenBurnMessage(usdcToken, 1000),
+	})
+	require.NoError(t, err)
+	require.False(t, filtered)
+}
+
+// TestLowTransferFilter_TokenDecimals verifies amounts in the filter reason are formatted with the token decimals
+func TestLowTransferFilter_TokenDecimals(t *testing.T) {
+	f := NewLowTransferFilter()
+	err := f.Initialize(context.Background(), map[string]interface{}{
+		"chains": map[string]types.ChainConfig{},
+		"min_amounts": map[types.Domain]map[string]uint64{
+			4: {eurcToken: 2_000_000_000_000_000_000},
+		},
+		"config": &types.Config{TokenExponents: map[string]int{eurcToken: 18}},
+	}, log.NewNopLogger())
+	require.NoError(t, err)
+
+	filtered, reason, err := f.Filter(context.Background(), &types.MessageState{
+		DestDomain: 4,
+		MsgBody:    createTokenBurnMessage(eurcToken, 1_500_000_000_000_000_000),
+	})
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "amount=1.5 (1500000000000000000) min_amount=2 (2000000000000000000)")
+}
+
+// TestLowTransferFilter_InvalidToken verifies malformed token keys are rejected
+func TestLowTransferFilter_InvalidToken(t *testing.T) {
+	f := NewLowTransferFilter()
+	err := f.Initialize(context.Background(), map[string]interface{}{
+		"chains": map[string]types.ChainConfig{},
+		"min_amounts": map[types.Domain]map[string]uint64{
+			4: {"not-hex": 1},
+		},
+	}, log.NewNopLogger())
+	require.Error(t, err)
+}
+
+// TestLowTransferFilter_NobleDomain verifies the noble minimum follows the configured noble domain
+func TestLowTransferFilter_NobleDomain(t *testing.T) {
+	nobleDomain := types.Domain(104)
+	f := NewLowTransferFilter()
+	err := f.Initialize(context.Background(), map[string]interface{}{
+		"chains": map[string]types.ChainConfig{
+			"noble": &noble.ChainConfig{Domain: &nobleDomain, MinMintAmount: 100},
+		},
+	}, log.NewNopLogger())
+	require.NoError(t, err)
+
+	filtered, _, err := f.Filter(context.Background(), &types.MessageState{
+		DestDomain: 104,
+		MsgBody:    createTokenBurnMessage(usdcToken, 50),
+	})
+	require.NoError(t, err)
+	require.True(t, filtered)
+
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{
+		DestDomain: 4,
+		MsgBody:    createTokenBurnMessage(usdcToken, 50),
+	})
+	require.NoError(t, err)
+	require.False(t, filtered)
+}
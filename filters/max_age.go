@@ -0,0 +1,116 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// MaxAgeFilter filters newly discovered messages whose source tx is older than the max age configured for their
+// source domain, so a wide backfill doesn't replay ancient burns that were likely already relayed. The age is
+// measured from the source block time when the listener recorded it, otherwise it is estimated from the blocks
+// since the source block and the chain's observed block time. Messages whose age can't be determined are not
+// filtered.
+type MaxAgeFilter struct {
+	maxAges           map[types.Domain]time.Duration // source domain -> max age
+	defaultMaxAge     time.Duration                  // max age of domains not in maxAges, 0 to not filter them
+	registeredDomains map[types.Domain]types.Chain
+	logger            log.Logger
+}
+
+func NewMaxAgeFilter() *MaxAgeFilter {
+	return &MaxAgeFilter{}
+}
+
+func (f *MaxAgeFilter) Name() string {
+	return "max-age"
+}
+
+func (f *MaxAgeFilter) Initialize(ctx context.Context, config map[string]interface{}, logger log.Logger) error {
+	f.logger = logger
+
+	if domains, ok := config["registered_domains"].(map[types.Domain]types.Chain); ok {
+		f.registeredDomains = domains
+	}
+
+	f.maxAges = make(map[types.Domain]time.Duration)
+	if raw, ok := config["max_age"]; ok && raw != nil {
+		maxAges, err := stringKeyedMap(raw)
+		if err != nil {
+			return fmt.Errorf("invalid max-age filter max_age: %w", err)
+		}
+		for key, value := range maxAges {
+			domain, err := strconv.ParseUint(key, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid domain %q in max_age: %w", key, err)
+			}
+			seconds := intConfig(maxAges, key, 0)
+			if seconds == 0 {
+				return fmt.Errorf("max age of domain %d must be a positive number of seconds, got %v", domain, value)
+			}
+			f.maxAges[types.Domain(domain)] = time.Duration(seconds) * time.Second
+		}
+	}
+	f.defaultMaxAge = time.Duration(intConfig(config, "default_max_age", 0)) * time.Second
+
+	if len(f.maxAges) == 0 && f.defaultMaxAge == 0 {
+		return fmt.Errorf("max-age filter requires 'max_age' or 'default_max_age' in config")
+	}
+
+	f.logger.Info("Max age filter initialized", "domains", len(f.maxAges), "default_max_age", f.defaultMaxAge)
+	return nil
+}
+
+// Filter filters msg if it was just discovered and its source tx is older than the max age of its source domain
+func (f *MaxAgeFilter) Filter(ctx context.Context, msg *types.MessageState) (bool, string, error) {
+	// only judge messages when they are discovered, a message waiting on its attestation ages legitimately
+	if msg.Status != types.Created {
+		return false, "", nil
+	}
+
+	maxAge, ok := f.maxAges[msg.SourceDomain]
+	if !ok {
+		maxAge = f.defaultMaxAge
+	}
+	if maxAge == 0 {
+		return false, "", nil
+	}
+
+	age, ok := f.sourceAge(msg)
+	if !ok {
+		types.LoggerFromContext(ctx, types.MessageLogger(f.logger, msg)).Debug("Unable to determine source tx age, not filtering")
+		return false, "", nil
+	}
+	if age <= maxAge {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("source tx is %s old, exceeding the max age of %s for domain %d",
+		age.Round(time.Second), maxAge, msg.SourceDomain), nil
+}
+
+// sourceAge returns how long ago msg was emitted on its source chain, false if it can't be determined
+func (f *MaxAgeFilter) sourceAge(msg *types.MessageState) (time.Duration, bool) {
+	if !msg.SourceTime.IsZero() {
+		return time.Since(msg.SourceTime), true
+	}
+
+	chain, ok := f.registeredDomains[msg.SourceDomain]
+	if !ok || msg.SourceBlock == 0 {
+		return 0, false
+	}
+	latest, blockTime := chain.LatestBlock(), chain.BlockTime()
+	if blockTime == 0 || latest < msg.SourceBlock {
+		return 0, false
+	}
+	return time.Duration(latest-msg.SourceBlock) * blockTime, true
+}
+
+func (f *MaxAgeFilter) Close() error {
+	return nil
+}
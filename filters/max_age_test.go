@@ -0,0 +1,77 @@
+package filters_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/filters"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// headChain is a source chain at a fixed height with a fixed observed block time
+type headChain struct {
+	types.Chain
+	latest    uint64
+	blockTime time.Duration
+}
+
+func (c headChain) LatestBlock() uint64      { return c.latest }
+func (c headChain) BlockTime() time.Duration { return c.blockTime }
+
+func TestMaxAgeFilter(t *testing.T) {
+	f := filters.NewMaxAgeFilter()
+	require.NoError(t, f.Initialize(context.Background(), map[string]interface{}{
+		"registered_domains": map[types.Domain]types.Chain{
+			0: headChain{latest: 10_000, blockTime: 12 * time.Second},
+			6: headChain{latest: 10_000}, // block time not observed yet
+		},
+		"max_age": map[interface{}]interface{}{0: 3600, 4: 86400, 6: 3600},
+	}, log.NewNopLogger()))
+
+	// evm age is estimated from the blocks since the source block: 400 blocks * 12s = 80m
+	filtered, reason, err := f.Filter(context.Background(), &types.MessageState{Status: types.Created, SourceDomain: 0, SourceBlock: 9_600})
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "1h20m0s old")
+	require.Contains(t, reason, "domain 0")
+
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{Status: types.Created, SourceDomain: 0, SourceBlock: 9_900})
+	require.NoError(t, err)
+	require.False(t, filtered)
+
+	// cosmos age is measured from the source block time
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{Status: types.Created, SourceDomain: 4, SourceTime: time.Now().Add(-48 * time.Hour)})
+	require.NoError(t, err)
+	require.True(t, filtered)
+
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{Status: types.Created, SourceDomain: 4, SourceTime: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	require.False(t, filtered)
+
+	// messages of unknown age, without a max age, or already being relayed are let through
+	for _, msg := range []*types.MessageState{
+		{Status: types.Created, SourceDomain: 6, SourceBlock: 1},
+		{Status: types.Created, SourceDomain: 0},
+		{Status: types.Created, SourceDomain: 5, SourceTime: time.Now().Add(-48 * time.Hour)},
+		{Status: types.Pending, SourceDomain: 4, SourceTime: time.Now().Add(-48 * time.Hour)},
+	} {
+		filtered, _, err = f.Filter(context.Background(), msg)
+		require.NoError(t, err)
+		require.False(t, filtered)
+	}
+}
+
+func TestMaxAgeFilter_InvalidConfig(t *testing.T) {
+	require.Error(t, filters.NewMaxAgeFilter().Initialize(context.Background(), map[string]interface{}{}, log.NewNopLogger()))
+	require.Error(t, filters.NewMaxAgeFilter().Initialize(context.Background(), map[string]interface{}{
+		"max_age": map[interface{}]interface{}{"noble": 3600},
+	}, log.NewNopLogger()))
+	require.Error(t, filters.NewMaxAgeFilter().Initialize(context.Background(), map[string]interface{}{
+		"max_age": map[interface{}]interface{}{4: "1h"},
+	}, log.NewNopLogger()))
+}
@@ -0,0 +1,70 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// MintRecipientFilter filters messages whose mint recipient can't receive the mint on the destination chain, e.g. a
+// Solana wallet address instead of a token account. Only destination chains that implement
+// types.RecipientValidator are checked.
+type MintRecipientFilter struct {
+	registeredDomains map[types.Domain]types.Chain
+	logger            log.Logger
+}
+
+func NewMintRecipientFilter() *MintRecipientFilter {
+	return &MintRecipientFilter{}
+}
+
+func (f *MintRecipientFilter) Name() string {
+	return "mint-recipient"
+}
+
+func (f *MintRecipientFilter) Initialize(ctx context.Context, config map[string]interface{}, logger log.Logger) error {
+	f.logger = logger
+	domainsRaw, ok := config["registered_domains"]
+	if !ok {
+		return fmt.Errorf("mint-recipient filter requires 'registered_domains' in config")
+	}
+	domains, ok := domainsRaw.(map[types.Domain]types.Chain)
+	if !ok {
+		return fmt.Errorf("registered_domains has invalid type")
+	}
+	f.registeredDomains = domains
+
+	var validated int
+	for _, chain := range domains {
+		if _, ok := chain.(types.RecipientValidator); ok {
+			validated++
+		}
+	}
+	logger.Info("Mint recipient filter initialized", "validated_chains", validated)
+	return nil
+}
+
+// Filter filters msg if its destination chain reports the mint recipient is invalid. Recipients that can't be
+// checked, e.g. because the rpc is unavailable, are let through and checked again when the message is requeued.
+func (f *MintRecipientFilter) Filter(ctx context.Context, msg *types.MessageState) (bool, string, error) {
+	validator, ok := f.registeredDomains[msg.DestDomain].(types.RecipientValidator)
+	if !ok {
+		return false, "", nil
+	}
+
+	reason, err := validator.ValidateMintRecipient(ctx, msg)
+	if err != nil {
+		return false, "", err
+	}
+	if reason != "" {
+		return true, fmt.Sprintf("invalid mint recipient: %s dest_domain=%d", reason, msg.DestDomain), nil
+	}
+	return false, "", nil
+}
+
+func (f *MintRecipientFilter) Close() error {
+	return nil
+}
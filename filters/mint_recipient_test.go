@@ -0,0 +1,55 @@
+package filters_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/filters"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// recipientChain is a destination chain that reports a fixed mint recipient validation result
+type recipientChain struct {
+	types.Chain
+	reason string
+	err    error
+}
+
+func (c recipientChain) ValidateMintRecipient(context.Context, *types.MessageState) (string, error) {
+	return c.reason, c.err
+}
+
+func TestMintRecipientFilter(t *testing.T) {
+	f := filters.NewMintRecipientFilter()
+	require.NoError(t, f.Initialize(context.Background(), map[string]interface{}{
+		"registered_domains": map[types.Domain]types.Chain{
+			5: recipientChain{reason: "does not exist"},   // invalid recipient
+			6: recipientChain{err: errors.New("timeout")}, // rpc unavailable
+			7: recipientChain{},                           // valid recipient
+		},
+	}, log.NewNopLogger()))
+
+	filtered, reason, err := f.Filter(context.Background(), &types.MessageState{DestDomain: 5})
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "does not exist")
+
+	// recipients that can't be checked are let through
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{DestDomain: 6})
+	require.Error(t, err)
+	require.False(t, filtered)
+
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{DestDomain: 7})
+	require.NoError(t, err)
+	require.False(t, filtered)
+
+	// unregistered destinations are left to the destination-caller filter
+	filtered, _, err = f.Filter(context.Background(), &types.MessageState{DestDomain: 9})
+	require.NoError(t, err)
+	require.False(t, filtered)
+}
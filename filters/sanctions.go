@@ -0,0 +1,238 @@
+package filters
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	DefaultSanctionsCacheTTL = 3600 // 1 hour
+	DefaultSanctionsTimeout  = 10   // seconds
+
+	defaultSanctionsMatchField = "match"
+
+	// maxCachedSanctions bounds the screening cache, expired and then the soonest expiring results are evicted
+	maxCachedSanctions = 10000
+)
+
+// SanctionsFilter filters messages whose depositor or mint recipient is flagged by a sanctions screening API.
+// The API is queried with a GET request to url, with {address} replaced by the address, and must respond with a
+// JSON object whose match_field is true for sanctioned addresses. Results are cached for cache_ttl seconds.
+// Messages that can't be screened are held and requeued until they can be, unless fail_open is set.
+type SanctionsFilter struct {
+	url        string
+	headers    map[string]string
+	matchField string
+	cacheTTL   time.Duration
+	failOpen   bool
+	client     *http.Client
+	logger     log.Logger
+
+	mu    sync.Mutex
+	cache map[string]sanctionsResult
+}
+
+type sanctionsResult struct {
+	sanctioned bool
+	expires    time.Time
+}
+
+func NewSanctionsFilter() *SanctionsFilter {
+	return &SanctionsFilter{
+		cache: make(map[string]sanctionsResult),
+	}
+}
+
+func (f *SanctionsFilter) Name() string {
+	return "sanctions"
+}
+
+func (f *SanctionsFilter) Initialize(ctx context.Context, config map[string]interface{}, logger log.Logger) error {
+	f.logger = logger
+
+	screeningURL, ok := config["url"].(string)
+	if !ok || !strings.Contains(screeningURL, "{address}") {
+		return fmt.Errorf("sanctions filter requires 'url' containing {address} in config")
+	}
+	f.url = screeningURL
+
+	f.headers = make(map[string]string)
+	if raw, ok := config["headers"]; ok {
+		headers, err := stringKeyedMap(raw)
+		if err != nil {
+			return fmt.Errorf("invalid sanctions filter headers: %w", err)
+		}
+		for k, v := range headers {
+			f.headers[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	f.matchField = defaultSanctionsMatchField
+	if field, ok := config["match_field"].(string); ok && field != "" {
+		f.matchField = field
+	}
+
+	f.cacheTTL = time.Duration(intConfig(config, "cache_ttl", DefaultSanctionsCacheTTL)) * time.Second
+	f.client = &http.Client{Timeout: time.Duration(intConfig(config, "timeout", DefaultSanctionsTimeout)) * time.Second}
+
+	if failOpen, ok := config["fail_open"].(bool); ok {
+		f.failOpen = failOpen
+	}
+
+	f.logger.Info("Sanctions filter initialized",
+		"match_field", f.matchField,
+		"cache_ttl", f.cacheTTL,
+		"fail_open", f.failOpen)
+	return nil
+}
+
+// Filter filters msg if its depositor or mint recipient is sanctioned, naming the matched address in the reason
+func (f *SanctionsFilter) Filter(ctx context.Context, msg *types.MessageState) (bool, string, error) {
+	depositor, err := getDepositor(msg)
+	if err != nil {
+		return true, "failed to extract depositor address", nil
+	}
+	recipient, err := getMintRecipient(msg)
+	if err != nil {
+		return true, "failed to extract mint recipient address", nil
+	}
+
+	for _, party := range []struct{ role, address string }{
+		{"depositor", depositor},
+		{"mint recipient", recipient},
+	} {
+		sanctioned, err := f.screen(ctx, party.address)
+		if err != nil {
+			if f.failOpen {
+				return false, "", fmt.Errorf("failed to screen %s %s: %w", party.role, party.address, err)
+			}
+			return false, "", fmt.Errorf("%w: failed to screen %s %s: %w", types.ErrHoldMessage, party.role, party.address, err)
+		}
+		if sanctioned {
+			reason := fmt.Sprintf("sanctioned %s: %s (source_domain=%d, dest_domain=%d)",
+				party.role, party.address, msg.SourceDomain, msg.DestDomain)
+			return true, reason, nil
+		}
+	}
+
+	return false, "", nil
+}
+
+func (f *SanctionsFilter) Close() error {
+	f.client.CloseIdleConnections()
+	return nil
+}
+
+// screen returns whether the screening API flags the address, from the cache if it was screened within the TTL
+func (f *SanctionsFilter) screen(ctx context.Context, address string) (bool, error) {
+	f.mu.Lock()
+	cached, ok := f.cache[address]
+	f.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.sanctioned, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.ReplaceAll(f.url, "{address}", url.PathEscape(address)), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range f.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("screening api returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	sanctioned, ok := result[f.matchField].(bool)
+	if !ok {
+		return false, fmt.Errorf("response has no boolean %q field", f.matchField)
+	}
+
+	f.mu.Lock()
+	now := time.Now()
+	if _, ok := f.cache[address]; !ok && len(f.cache) >= maxCachedSanctions {
+		f.evict(now)
+	}
+	f.cache[address] = sanctionsResult{sanctioned: sanctioned, expires: now.Add(f.cacheTTL)}
+	f.mu.Unlock()
+	return sanctioned, nil
+}
+
+// evict drops the expired results, or the soonest expiring one if none expired. The caller must hold the lock.
+func (f *SanctionsFilter) evict(now time.Time) {
+	var soonest string
+	var soonestExpires time.Time
+	for address, result := range f.cache {
+		if now.After(result.expires) {
+			delete(f.cache, address)
+			continue
+		}
+		if soonestExpires.IsZero() || result.expires.Before(soonestExpires) {
+			soonest, soonestExpires = address, result.expires
+		}
+	}
+	if len(f.cache) >= maxCachedSanctions {
+		delete(f.cache, soonest)
+	}
+}
+
+// getMintRecipient returns the mint recipient of a burn message. Recipients left padded to 32 bytes are EVM
+// addresses and returned like getDepositor, others, e.g. Solana token accounts, as the full 32 bytes in hex.
+func getMintRecipient(msg *types.MessageState) (string, error) {
+	burnMsg, err := new(types.BurnMessage).Parse(msg.MsgBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse burn message: %w", err)
+	}
+	if len(burnMsg.MintRecipient) != 32 {
+		return "", fmt.Errorf("invalid MintRecipient length: %d", len(burnMsg.MintRecipient))
+	}
+	for _, b := range burnMsg.MintRecipient[:12] {
+		if b != 0 {
+			return "0x" + hex.EncodeToString(burnMsg.MintRecipient), nil
+		}
+	}
+	return "0x" + hex.EncodeToString(burnMsg.MintRecipient[12:]), nil
+}
+
+// intConfig reads a positive integer from config, which yaml may decode as int or float64
+func intConfig(config map[string]interface{}, key string, defaultValue int) int {
+	switch val := config[key].(type) {
+	case int:
+		if val > 0 {
+			return val
+		}
+	case float64:
+		if val > 0 {
+			return int(val)
+		}
+	}
+	return defaultValue
+}
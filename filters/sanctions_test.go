@@ -0,0 +1,128 @@
+package filters
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const sanctionedAddr = "0x8589427373d6d84e98730d7795d8f6f8731fda16"
+
+func createBurnMessageWithRecipient(depositor, recipient string) []byte {
+	burnMsg := createBurnMessage(depositor)
+	recipientBytes, _ := hex.DecodeString(strings.TrimPrefix(recipient, "0x"))
+	copy(burnMsg[68-len(recipientBytes):68], recipientBytes)
+	return burnMsg
+}
+
+func setupSanctionsFilter(t *testing.T, failOpen bool) (*SanctionsFilter, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		address := strings.TrimPrefix(r.URL.Path, "/v1/address/")
+		switch address {
+		case "0xdeadbeef00000000000000000000000000000000":
+			w.WriteHeader(http.StatusInternalServerError)
+		case sanctionedAddr:
+			_, _ = w.Write([]byte(`{"sanctioned": true}`))
+		default:
+			_, _ = w.Write([]byte(`{"sanctioned": false}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	f := NewSanctionsFilter()
+	require.NoError(t, f.Initialize(context.Background(), map[string]interface{}{
+		"url":         server.URL + "/v1/address/{address}",
+		"headers":     map[interface{}]interface{}{"X-Api-Key": "secret"},
+		"match_field": "sanctioned",
+		"fail_open":   failOpen,
+	}, log.NewNopLogger()))
+	return f, &requests
+}
+
+func TestSanctionsFilter(t *testing.T) {
+	f, requests := setupSanctionsFilter(t, false)
+
+	clean := &types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: createBurnMessageWithRecipient(testAddr, testAddr)}
+	filtered, _, err := f.Filter(context.Background(), clean)
+	require.NoError(t, err)
+	require.False(t, filtered)
+	require.Equal(t, int32(1), requests.Load(), "depositor and recipient are the same address, screened once")
+
+	// cached results don't query the api again
+	_, _, err = f.Filter(context.Background(), clean)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), requests.Load())
+
+	filtered, reason, err := f.Filter(context.Background(), &types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: createBurnMessageWithRecipient(testAddr, sanctionedAddr)})
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "sanctioned mint recipient: "+sanctionedAddr)
+
+	filtered, reason, err = f.Filter(context.Background(), &types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: createBurnMessageWithRecipient(sanctionedAddr, testAddr)})
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Contains(t, reason, "sanctioned depositor: "+sanctionedAddr)
+}
+
+func TestSanctionsFilter_APIError(t *testing.T) {
+	unscreenable := &types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: createBurnMessageWithRecipient(testAddr, "0xdeadbeef00000000000000000000000000000000")}
+
+	// fail closed by default, the message is held until it can be screened
+	f, _ := setupSanctionsFilter(t, false)
+	filtered, _, err := f.Filter(context.Background(), unscreenable)
+	require.ErrorIs(t, err, types.ErrHoldMessage)
+	require.False(t, filtered)
+
+	f, _ = setupSanctionsFilter(t, true)
+	filtered, _, err = f.Filter(context.Background(), unscreenable)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, types.ErrHoldMessage)
+	require.False(t, filtered)
+}
+
+func TestSanctionsFilter_CacheEviction(t *testing.T) {
+	f, requests := setupSanctionsFilter(t, false)
+	now := time.Now()
+	for i := 0; i < maxCachedSanctions; i++ {
+		f.cache[fmt.Sprint(i)] = sanctionsResult{expires: now.Add(time.Hour + time.Duration(i)*time.Second)}
+	}
+	f.cache["expired"] = sanctionsResult{expires: now.Add(-time.Second)}
+
+	// caching a new result drops the expired one and the soonest expiring one
+	_, err := f.screen(context.Background(), testAddr)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), requests.Load())
+	require.Len(t, f.cache, maxCachedSanctions)
+	require.NotContains(t, f.cache, "expired")
+	require.NotContains(t, f.cache, "0")
+	require.Contains(t, f.cache, testAddr)
+}
+
+func TestGetMintRecipient(t *testing.T) {
+	recipient, err := getMintRecipient(&types.MessageState{MsgBody: createBurnMessageWithRecipient(testAddr, testAddr)})
+	require.NoError(t, err)
+	require.Equal(t, strings.ToLower(testAddr), recipient)
+
+	solanaAccount := "0x" + strings.Repeat("ab", 32)
+	recipient, err = getMintRecipient(&types.MessageState{MsgBody: createBurnMessageWithRecipient(testAddr, solanaAccount)})
+	require.NoError(t, err)
+	require.Equal(t, solanaAccount, recipient)
+}
@@ -2,11 +2,12 @@ package noble
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	nobletypes "github.com/circlefin/noble-cctp/x/cctp/types"
@@ -16,6 +17,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	xauthsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	xauthtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
@@ -28,20 +30,44 @@ import (
 
 var (
 	regexAccountSequenceMismatchErr = regexp.MustCompile(`expected (\d+), got (\d+)`)
+
+	// errBatchSimulation is returned when a tx with multiple msgs fails simulation
+	errBatchSimulation = errors.New("batched tx simulation failed")
+
+	// errInsufficientFunds is returned when the minter wallet can't pay the tx fee
+	errInsufficientFunds = errors.New("minter wallet has insufficient funds")
+
+	// errInsufficientFee is returned when the tx fee is below the minimum gas prices of the node, the fee is bumped
+	// on the next attempt
+	errInsufficientFee = errors.New("insufficient fee")
+
+	// feeBumpFactor raises the fee of a tx each time it is rejected for an insufficient fee
+	feeBumpFactor = sdk.NewDecWithPrec(15, 1)
+
+	// errSequenceMismatch is returned when a tx was signed with a stale account sequence. The sequence is refreshed
+	// before returning so the next attempt uses the on-chain sequence.
+	errSequenceMismatch = errors.New("account sequence mismatch")
 )
 
+// accountQuerier queries the on-chain account number and sequence of an address
+type accountQuerier interface {
+	AccountInfo(ctx context.Context, address string) (uint64, uint64, error)
+}
+
 func (n *Noble) InitializeBroadcaster(
 	ctx context.Context,
 	logger log.Logger,
 	sequenceMap *types.SequenceMap,
 ) error {
-	accountNumber, accountSequence, err := n.AccountInfo(ctx)
-	if err != nil {
-		return fmt.Errorf("unable to get account info for noble: %w", err)
-	}
+	for _, wallet := range n.wallets {
+		accountNumber, accountSequence, err := n.AccountInfo(ctx, wallet.address)
+		if err != nil {
+			return fmt.Errorf("unable to get account info for noble minter %s: %w", wallet.address, err)
+		}
 
-	n.accountNumber = accountNumber
-	sequenceMap.Put(n.Domain(), accountSequence)
+		wallet.accountNumber = accountNumber
+		sequenceMap.PutAccount(n.Domain(), wallet.index, wallet.address, accountSequence)
+	}
 
 	return nil
 }
@@ -53,6 +79,97 @@ func (n *Noble) Broadcast(
 	sequenceMap *types.SequenceMap,
 	m *relayer.PromMetrics,
 ) error {
+	msgs = rejectInvalidRecipients(logger, msgs)
+	msgs = pairForwards(rejectForwards(logger, msgs, n.forwardingChannels))
+
+	var broadcastErrors error
+	for _, group := range n.groupByWallet(msgs) {
+		for _, batch := range batchMsgs(group, n.maxMsgsPerTx) {
+			if err := n.broadcastBatch(ctx, logger, batch, sequenceMap, m); err != nil {
+				broadcastErrors = errors.Join(broadcastErrors, err)
+			}
+		}
+	}
+	return broadcastErrors
+}
+
+// rejectInvalidRecipients marks burn messages whose mint recipient isn't a valid noble address as failed, instead of
+// wasting a broadcast on a mint that fails on-chain. Returns the msgs that can be broadcast.
+func rejectInvalidRecipients(logger log.Logger, msgs []*types.MessageState) []*types.MessageState {
+	valid := make([]*types.MessageState, 0, len(msgs))
+	for _, msg := range msgs {
+		burnMessage, err := new(types.BurnMessage).Parse(msg.MsgBody)
+		if err != nil {
+			// not a burn, e.g. a forward, nothing to validate
+			valid = append(valid, msg)
+			continue
+		}
+
+		if _, err := decodeMintRecipient(burnMessage); err != nil {
+			types.MessageLogger(logger, msg).Error("Invalid mint recipient, not broadcasting message", "error", err, "src-tx", msg.SourceTxHash)
+			msg.Status = types.Failed
+			msg.FailureReason = err.Error()
+			msg.Updated = time.Now()
+			continue
+		}
+		valid = append(valid, msg)
+	}
+	return valid
+}
+
+// sourceTxHashPlaceholder in the configured tx memo is replaced with the burn tx hashes of the minted messages
+const sourceTxHashPlaceholder = "{source_tx_hash}"
+
+// txMemo returns the memo of a tx minting msgs, with the source tx hashes substituted for traceability
+func txMemo(memo string, msgs []*types.MessageState) string {
+	if !strings.Contains(memo, sourceTxHashPlaceholder) {
+		return memo
+	}
+
+	var hashes []string
+	seen := make(map[string]bool)
+	for _, msg := range msgs {
+		if !seen[msg.SourceTxHash] {
+			seen[msg.SourceTxHash] = true
+			hashes = append(hashes, msg.SourceTxHash)
+		}
+	}
+	return strings.ReplaceAll(memo, sourceTxHashPlaceholder, strings.Join(hashes, ","))
+}
+
+// batchMsgs splits msgs into batches of at most maxMsgsPerTx. A non-positive max puts all msgs in one batch. A forward
+// following the burn it forwards is kept in the batch of the burn, even if that exceeds the max.
+func batchMsgs(msgs []*types.MessageState, maxMsgsPerTx int) [][]*types.MessageState {
+	if maxMsgsPerTx <= 0 {
+		maxMsgsPerTx = len(msgs)
+	}
+
+	var batches [][]*types.MessageState
+	for start := 0; start < len(msgs); {
+		end := min(start+maxMsgsPerTx, len(msgs))
+		if end < len(msgs) && forwardsBurn(msgs[end], msgs[end-1]) {
+			end++
+		}
+		batches = append(batches, msgs[start:end])
+		start = end
+	}
+	return batches
+}
+
+// broadcastBatch signs and broadcasts msgs in a single tx. If a tx with multiple msgs fails simulation,
+// e.g. because one of the mints is invalid, each msg is broadcast on its own instead.
+func (n *Noble) broadcastBatch(
+	ctx context.Context,
+	logger log.Logger,
+	msgs []*types.MessageState,
+	sequenceMap *types.SequenceMap,
+	m *relayer.PromMetrics,
+) error {
+	// a tx with a single msg is logged as part of that transfer
+	if len(msgs) == 1 {
+		logger = types.MessageLogger(logger, msgs[0])
+	}
+
 	// set up sdk context
 	interfaceRegistry := codectypes.NewInterfaceRegistry()
 	nobletypes.RegisterInterfaces(interfaceRegistry)
@@ -65,19 +182,52 @@ func (n *Noble) Broadcast(
 	txBuilder := sdkContext.TxConfig.NewTxBuilder()
 
 	// sign and broadcast txn
+	feeBumps := 0
 	for attempt := 1; attempt <= n.maxRetries; attempt++ {
-		err := n.attemptBroadcast(ctx, logger, msgs, sequenceMap, sdkContext, txBuilder)
+		wallet := n.walletFor(msgs)
+		err := n.attemptBroadcast(ctx, logger, msgs, wallet, sequenceMap, sdkContext, txBuilder, feeBumps, m)
 		if err == nil {
+			n.walletPool.Succeeded(wallet.index)
+			if m != nil {
+				for _, msg := range msgs {
+					if isForward(msg) && msg.Status == types.Complete {
+						m.IncForwards(fmt.Sprint(msg.SourceDomain), msg.Channel)
+					}
+				}
+			}
 			return nil
 		}
 
+		if errors.Is(err, errBatchSimulation) {
+			logger.Info("Batched tx failed simulation, broadcasting messages individually", "error", err, "batch_size", len(msgs))
+
+			var broadcastErrors error
+			for _, msg := range msgs {
+				if err := n.broadcastBatch(ctx, logger, []*types.MessageState{msg}, sequenceMap, m); err != nil {
+					broadcastErrors = errors.Join(broadcastErrors, err)
+				}
+			}
+			return broadcastErrors
+		}
+
+		// skip the wallet until it is funded again so the retry uses the next one
+		if errors.Is(err, errInsufficientFunds) {
+			logger.Error("Minter wallet has insufficient funds, skipping it", "address", wallet.address)
+			n.walletPool.Failed(wallet.index)
+		}
+
+		if errors.Is(err, errInsufficientFee) {
+			feeBumps++
+			logger.Info("Tx fee below the minimum gas prices, bumping the fee", "gas_prices", n.gasPrices.String(), "fee_bumps", feeBumps)
+		}
+
 		// Log retry information
 		logger.Error(fmt.Sprintf("Broadcasting to noble failed. Attempt %d/%d Retrying...", attempt, n.maxRetries), "error", err, "interval_seconds", n.retryIntervalSeconds, "src-tx", msgs[0].SourceTxHash)
 		time.Sleep(time.Duration(n.retryIntervalSeconds) * time.Second)
 	}
 
 	for _, msg := range msgs {
-		if msg.Status != types.Complete {
+		if msg.Status != types.Complete && msg.Status != types.AlreadyMinted {
 			msg.Status = types.Failed
 		}
 	}
@@ -91,11 +241,15 @@ func (n *Noble) attemptBroadcast(
 	ctx context.Context,
 	logger log.Logger,
 	msgs []*types.MessageState,
+	wallet *minterWallet,
 	sequenceMap *types.SequenceMap,
 	sdkContext sdkclient.Context,
 	txBuilder sdkclient.TxBuilder,
+	feeBumps int,
+	m *relayer.PromMetrics,
 ) error {
 	var receiveMsgs []sdk.Msg
+	var minted []*types.MessageState
 	for _, msg := range msgs {
 		used, err := n.cc.QueryUsedNonce(ctx, msg.SourceDomain, msg.Nonce)
 		if err != nil {
@@ -103,28 +257,33 @@ func (n *Noble) attemptBroadcast(
 		}
 
 		if used {
-			msg.Status = types.Complete
-			logger.Info(fmt.Sprintf("Noble cctp minter nonce %d already used.", msg.Nonce), "src-tx", msg.SourceTxHash)
+			msg.Status = types.AlreadyMinted
+			types.MessageLogger(logger, msg).Info(fmt.Sprintf("Noble cctp minter nonce %d already used.", msg.Nonce), "src-tx", msg.SourceTxHash)
 			continue
 		}
 
 		// check if another worker already broadcasted tx due to flush
-		if msg.Status == types.Complete {
+		if msg.Status == types.Complete || msg.Status == types.AlreadyMinted {
 			continue
 		}
 
-		attestationBytes, err := hex.DecodeString(msg.Attestation[2:])
+		attestation, err := types.NormalizeAttestation(msg.Attestation)
+		if err != nil {
+			return fmt.Errorf("invalid message attestation: %w", err)
+		}
+		attestationBytes, err := types.DecodeAttestation(attestation)
 		if err != nil {
-			return fmt.Errorf("unable to decode message attestation")
+			return fmt.Errorf("unable to decode message attestation: %w", err)
 		}
 
 		receiveMsgs = append(receiveMsgs, nobletypes.NewMsgReceiveMessage(
-			n.minterAddress,
+			wallet.address,
 			msg.MsgSentBytes,
 			attestationBytes,
 		))
+		minted = append(minted, msg)
 
-		logger.Info(fmt.Sprintf(
+		types.MessageLogger(logger, msg).Info(fmt.Sprintf(
 			"Broadcasting message from %d to %d: with source tx hash %s",
 			msg.SourceDomain,
 			msg.DestDomain,
@@ -139,17 +298,100 @@ func (n *Noble) attemptBroadcast(
 		return fmt.Errorf("failed to set messages on tx: %w", err)
 	}
 
-	txBuilder.SetGasLimit(n.gasLimit)
+	txBuilder.SetMemo(txMemo(n.txMemo, minted))
+	txBuilder.SetFeeGranter(n.feeGranter)
+
+	// gas-limit is configured per mint, without it the gas is estimated by simulation
+	gasLimit := n.gasLimit * uint64(len(receiveMsgs))
+	txBuilder.SetGasLimit(gasLimit)
+	txBuilder.SetFeeAmount(txFee(n.gasPrices, gasLimit, feeBumps))
+
+	wallet.mu.Lock()
+	defer wallet.mu.Unlock()
+
+	accountSequence := sequenceMap.NextWallet(n.Domain(), wallet.index)
+
+	txBytes, err := n.signTx(sdkContext, txBuilder, wallet, accountSequence)
+	if err != nil {
+		return err
+	}
+
+	// a batch is simulated first so a single bad mint doesn't fail the whole tx
+	if len(receiveMsgs) > 1 || n.gasLimit == 0 {
+		gasUsed, err := n.cc.SimulateTx(ctx, txBytes)
+		if err != nil {
+			if isSequenceMismatch(0, err.Error()) {
+				return n.recoverSequence(ctx, logger, wallet, sequenceMap, accountSequence, err.Error(), m)
+			}
+			sequenceMap.PutWallet(n.Domain(), wallet.index, accountSequence)
+			if len(receiveMsgs) > 1 {
+				return fmt.Errorf("%w: %w", errBatchSimulation, err)
+			}
+			return fmt.Errorf("failed to simulate tx: %w", err)
+		}
 
-	txBuilder.SetMemo(n.txMemo)
+		if n.gasLimit == 0 {
+			gasLimit = adjustGas(gasUsed, n.gasAdjustment)
+			txBuilder.SetGasLimit(gasLimit)
+			txBuilder.SetFeeAmount(txFee(n.gasPrices, gasLimit, feeBumps))
 
-	n.mu.Lock()
-	defer n.mu.Unlock()
+			if txBytes, err = n.signTx(sdkContext, txBuilder, wallet, accountSequence); err != nil {
+				return err
+			}
+		}
+	}
 
-	accountSequence := sequenceMap.Next(n.Domain())
+	rpcResponse, err := n.cc.RPCClient.BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		if isSequenceMismatch(0, err.Error()) {
+			return n.recoverSequence(ctx, logger, wallet, sequenceMap, accountSequence, err.Error(), m)
+		}
+		return err
+	}
+
+	if isSequenceMismatch(rpcResponse.Code, rpcResponse.Log) {
+		return n.recoverSequence(ctx, logger, wallet, sequenceMap, accountSequence, rpcResponse.Log, m)
+	}
+
+	// the rejected tx didn't use its sequence
+	if rpcResponse.Code == sdkerrors.ErrInsufficientFunds.ABCICode() {
+		sequenceMap.PutWallet(n.Domain(), wallet.index, accountSequence)
+		return fmt.Errorf("%w: %d - %s", errInsufficientFunds, rpcResponse.Code, rpcResponse.Log)
+	}
+
+	if rpcResponse.Code == sdkerrors.ErrInsufficientFee.ABCICode() {
+		sequenceMap.PutWallet(n.Domain(), wallet.index, accountSequence)
+		return fmt.Errorf("%w: %d - %s", errInsufficientFee, rpcResponse.Code, rpcResponse.Log)
+	}
 
+	if rpcResponse.Code != 0 {
+		return fmt.Errorf("received non-zero: %d - %s", rpcResponse.Code, rpcResponse.Log)
+	}
+
+	if m != nil {
+		m.ObserveBroadcastBatchSize(n.Name(), fmt.Sprint(n.Domain()), len(receiveMsgs))
+	}
+
+	// Tx was successfully broadcast
+	for _, msg := range minted {
+		msg.DestTxHash = rpcResponse.Hash.String()
+		msg.Status = types.Complete
+	}
+
+	logger.Info(fmt.Sprintf("Successfully broadcast %s to Noble.  Tx hash: %s", minted[0].SourceTxHash, minted[0].DestTxHash), "minter", wallet.address)
+
+	return nil
+}
+
+// signTx signs the tx being built with the wallet at accountSequence and returns its encoded bytes
+func (n *Noble) signTx(
+	sdkContext sdkclient.Context,
+	txBuilder sdkclient.TxBuilder,
+	wallet *minterWallet,
+	accountSequence uint64,
+) ([]byte, error) {
 	sigV2 := signing.SignatureV2{
-		PubKey: n.privateKey.PubKey(),
+		PubKey: wallet.privateKey.PubKey(),
 		Data: &signing.SingleSignatureData{
 			SignMode:  sdkContext.TxConfig.SignModeHandler().DefaultMode(),
 			Signature: nil,
@@ -159,80 +401,112 @@ func (n *Noble) attemptBroadcast(
 
 	signerData := xauthsigning.SignerData{
 		ChainID:       n.chainID,
-		AccountNumber: n.accountNumber,
+		AccountNumber: wallet.accountNumber,
 		Sequence:      accountSequence,
 	}
 
 	err := txBuilder.SetSignatures(sigV2)
 	if err != nil {
-		return fmt.Errorf("failed to set signatures: %w", err)
+		return nil, fmt.Errorf("failed to set signatures: %w", err)
 	}
 
 	sigV2, err = clientTx.SignWithPrivKey(
 		sdkContext.TxConfig.SignModeHandler().DefaultMode(),
 		signerData,
 		txBuilder,
-		n.privateKey,
+		wallet.privateKey,
 		sdkContext.TxConfig,
 		accountSequence,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to sign tx: %w", err)
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
 	}
 
 	if err := txBuilder.SetSignatures(sigV2); err != nil {
-		return fmt.Errorf("failed to set signatures: %w", err)
+		return nil, fmt.Errorf("failed to set signatures: %w", err)
 	}
 
 	// Generated Protobuf-encoded bytes.
 	txBytes, err := sdkContext.TxConfig.TxEncoder()(txBuilder.GetTx())
 	if err != nil {
-		return fmt.Errorf("failed to proto encode tx: %w", err)
+		return nil, fmt.Errorf("failed to proto encode tx: %w", err)
 	}
+	return txBytes, nil
+}
 
-	rpcResponse, err := n.cc.RPCClient.BroadcastTxSync(ctx, txBytes)
-	if err != nil {
-		return err
-	}
+// adjustGas scales the simulated gas of a tx by the gas adjustment to leave headroom for state changes between the
+// simulation and inclusion
+func adjustGas(gasUsed uint64, gasAdjustment float64) uint64 {
+	return uint64(math.Ceil(float64(gasUsed) * gasAdjustment))
+}
 
-	if rpcResponse.Code == 32 {
-		newAccountSequence := n.extractAccountSequence(ctx, logger, rpcResponse.Log)
-		logger.Debug(fmt.Sprintf("retrying with new account sequence: %d", newAccountSequence))
-		sequenceMap.Put(n.Domain(), newAccountSequence)
-	}
+// txFee returns the fee of a tx with gasLimit at gasPrices, raised by feeBumpFactor for each time the tx was
+// rejected for an insufficient fee. No gas prices pays no fee.
+func txFee(gasPrices sdk.DecCoins, gasLimit uint64, feeBumps int) sdk.Coins {
+	bump := feeBumpFactor.Power(uint64(feeBumps))
 
-	if rpcResponse.Code != 0 {
-		return fmt.Errorf("received non-zero: %d - %s", rpcResponse.Code, rpcResponse.Log)
-	}
-
-	// Tx was successfully broadcast
-	for _, msg := range msgs {
-		msg.DestTxHash = rpcResponse.Hash.String()
-		msg.Status = types.Complete
+	fee := sdk.NewCoins()
+	for _, price := range gasPrices {
+		amount := price.Amount.MulInt64(int64(gasLimit)).Mul(bump).Ceil().TruncateInt()
+		fee = fee.Add(sdk.NewCoin(price.Denom, amount))
 	}
+	return fee
+}
 
-	logger.Info(fmt.Sprintf("Successfully broadcast %s to Noble.  Tx hash: %s", msgs[0].SourceTxHash, msgs[0].DestTxHash))
-
-	return nil
+// isSequenceMismatch returns true if a broadcast failed because the tx was signed with a stale account sequence
+func isSequenceMismatch(code uint32, log string) bool {
+	return code == sdkerrors.ErrWrongSequence.ABCICode() || strings.Contains(log, "account sequence mismatch")
 }
 
-// extractAccountSequence attempts to extract the account sequence number from the RPC response logs when
-// account sequence mismatch errors are encountered. If the account sequence number cannot be extracted from the logs,
-// it is retrieved by making a request to the API endpoint.
-func (n *Noble) extractAccountSequence(ctx context.Context, logger log.Logger, rpcResponseLog string) uint64 {
-	match := regexAccountSequenceMismatchErr.FindStringSubmatch(rpcResponseLog)
+// recoverSequence refreshes the sequence of wallet after a broadcast signed with accountSequence failed with an
+// account sequence mismatch, e.g. because another process broadcast from the minter account. Returns
+// errSequenceMismatch so the broadcast is retried with the refreshed sequence.
+func (n *Noble) recoverSequence(
+	ctx context.Context,
+	logger log.Logger,
+	wallet *minterWallet,
+	sequenceMap *types.SequenceMap,
+	accountSequence uint64,
+	errLog string,
+	m *relayer.PromMetrics,
+) error {
+	newAccountSequence, err := refreshSequence(ctx, n, n.Domain(), wallet, sequenceMap, errLog)
+	if err != nil {
+		// leave the sequence as it was before the failed attempt
+		sequenceMap.PutWallet(n.Domain(), wallet.index, accountSequence)
+		return fmt.Errorf("%w: unable to refresh account sequence: %w", errSequenceMismatch, err)
+	}
 
-	if len(match) == 3 {
-		// Extract the numbers from the match.
-		newAccountSequence, _ := strconv.ParseUint(match[1], 10, 64)
-		return newAccountSequence
+	logger.Info(fmt.Sprintf("Account sequence mismatch, retrying with new account sequence: %d", newAccountSequence),
+		"minter", wallet.address, "sequence", accountSequence)
+	if m != nil {
+		m.IncSequenceMismatches(n.Name(), fmt.Sprint(n.Domain()))
 	}
 
-	// Otherwise, just request the account sequence
-	_, newAccountSequence, err := n.AccountInfo(ctx)
+	return fmt.Errorf("%w: %s", errSequenceMismatch, errLog)
+}
+
+// refreshSequence sets the sequence of wallet to its on-chain account sequence. If the query fails, the sequence is
+// extracted from the "expected N, got M" account sequence mismatch error log instead.
+func refreshSequence(
+	ctx context.Context,
+	querier accountQuerier,
+	domain types.Domain,
+	wallet *minterWallet,
+	sequenceMap *types.SequenceMap,
+	errLog string,
+) (uint64, error) {
+	_, accountSequence, err := querier.AccountInfo(ctx, wallet.address)
 	if err != nil {
-		logger.Error("unable to retrieve account sequence")
+		match := regexAccountSequenceMismatchErr.FindStringSubmatch(errLog)
+		if len(match) != 3 {
+			return 0, err
+		}
+		if accountSequence, err = strconv.ParseUint(match[1], 10, 64); err != nil {
+			return 0, err
+		}
 	}
 
-	return newAccountSequence
+	sequenceMap.PutWallet(domain, wallet.index, accountSequence)
+	return accountSequence, nil
 }
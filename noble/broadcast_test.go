@@ -0,0 +1,195 @@
+package noble
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestBatchMsgs verifies msgs are split into batches no larger than the configured max
+func TestBatchMsgs(t *testing.T) {
+	msgs := make([]*types.MessageState, 5)
+	for i := range msgs {
+		msgs[i] = &types.MessageState{Nonce: uint64(i)}
+	}
+
+	batches := batchMsgs(msgs, 2)
+	require.Len(t, batches, 3)
+	require.Len(t, batches[0], 2)
+	require.Len(t, batches[2], 1)
+	require.Equal(t, uint64(4), batches[2][0].Nonce)
+
+	// no max puts everything in one tx
+	require.Len(t, batchMsgs(msgs, 0), 1)
+	require.Empty(t, batchMsgs(nil, 2))
+}
+
+// fakeAccountQuerier returns a fixed account sequence, or err if set
+type fakeAccountQuerier struct {
+	sequence uint64
+	err      error
+}
+
+func (q fakeAccountQuerier) AccountInfo(context.Context, string) (uint64, uint64, error) {
+	return 1, q.sequence, q.err
+}
+
+func TestIsSequenceMismatch(t *testing.T) {
+	require.True(t, isSequenceMismatch(32, ""))
+	require.True(t, isSequenceMismatch(0, "account sequence mismatch, expected 12, got 10: incorrect account sequence"))
+	require.False(t, isSequenceMismatch(5, "insufficient funds"))
+	require.False(t, isSequenceMismatch(0, ""))
+}
+
+// TestRefreshSequence verifies the sequence of a wallet is refreshed from the chain after a mismatch, falling back
+// to the expected sequence in the error log
+func TestRefreshSequence(t *testing.T) {
+	ctx := context.Background()
+	wallet := &minterWallet{index: 1, address: "noble1minter"}
+	errLog := "account sequence mismatch, expected 12, got 10: incorrect account sequence"
+
+	sequenceMap := types.NewSequenceMap()
+	sequenceMap.PutWallet(4, 1, 10)
+
+	seq, err := refreshSequence(ctx, fakeAccountQuerier{sequence: 15}, 4, wallet, sequenceMap, errLog)
+	require.NoError(t, err)
+	require.EqualValues(t, 15, seq)
+	require.EqualValues(t, 15, sequenceMap.GetWallet(4, 1))
+	require.Zero(t, sequenceMap.GetWallet(4, 0))
+
+	unreachable := fakeAccountQuerier{err: errors.New("connection refused")}
+	seq, err = refreshSequence(ctx, unreachable, 4, wallet, sequenceMap, errLog)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, seq)
+	require.EqualValues(t, 12, sequenceMap.GetWallet(4, 1))
+
+	_, err = refreshSequence(ctx, unreachable, 4, wallet, sequenceMap, "account sequence mismatch")
+	require.Error(t, err)
+	require.EqualValues(t, 12, sequenceMap.GetWallet(4, 1))
+}
+
+// burnMessageBody returns a burn message body minting to the 32 byte mintRecipient
+func burnMessageBody(mintRecipient []byte) []byte {
+	body := make([]byte, 132)
+	copy(body[36:68], mintRecipient)
+	return body
+}
+
+func TestDecodeMintRecipient(t *testing.T) {
+	address := make([]byte, 32)
+	for i := 12; i < 32; i++ {
+		address[i] = byte(i)
+	}
+
+	burnMessage, err := new(types.BurnMessage).Parse(burnMessageBody(address))
+	require.NoError(t, err)
+	recipient, err := decodeMintRecipient(burnMessage)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("noble", address[12:]), recipient)
+
+	// 32 byte address, only the last 20 bytes would be minted to
+	unpadded := append([]byte{}, address...)
+	unpadded[0] = 1
+	burnMessage, err = new(types.BurnMessage).Parse(burnMessageBody(unpadded))
+	require.NoError(t, err)
+	_, err = decodeMintRecipient(burnMessage)
+	require.ErrorContains(t, err, "not a left padded 20 byte address")
+
+	burnMessage, err = new(types.BurnMessage).Parse(burnMessageBody(make([]byte, 32)))
+	require.NoError(t, err)
+	_, err = decodeMintRecipient(burnMessage)
+	require.ErrorContains(t, err, "zero address")
+
+	_, err = decodeMintRecipient(&types.BurnMessage{MintRecipient: address[12:]})
+	require.ErrorContains(t, err, "expected 32")
+}
+
+// TestRejectInvalidRecipients verifies burns to malformed recipients are failed with a reason and not broadcast
+func TestRejectInvalidRecipients(t *testing.T) {
+	valid := make([]byte, 32)
+	valid[31] = 1
+	invalid := make([]byte, 32)
+	invalid[0] = 1
+
+	msgs := []*types.MessageState{
+		{Nonce: 1, Status: types.Attested, MsgBody: burnMessageBody(valid)},
+		{Nonce: 2, Status: types.Attested, MsgBody: burnMessageBody(invalid)},
+		{Nonce: 3, Status: types.Attested, MsgBody: make([]byte, 140)}, // not a burn message
+	}
+
+	broadcast := rejectInvalidRecipients(log.NewNopLogger(), msgs)
+	require.Equal(t, []*types.MessageState{msgs[0], msgs[2]}, broadcast)
+	require.Equal(t, types.Attested, msgs[0].Status)
+	require.Equal(t, types.Failed, msgs[1].Status)
+	require.Contains(t, msgs[1].FailureReason, "mint recipient")
+	require.Empty(t, msgs[0].FailureReason)
+}
+
+func TestTxMemo(t *testing.T) {
+	msgs := []*types.MessageState{
+		{SourceTxHash: "0xaa"},
+		{SourceTxHash: "0xbb"},
+		{SourceTxHash: "0xaa"},
+	}
+
+	require.Equal(t, "Relayed by Strangelove", txMemo("Relayed by Strangelove", msgs))
+	require.Equal(t, "cctp 0xaa,0xbb", txMemo("cctp {source_tx_hash}", msgs))
+	require.Empty(t, txMemo("", msgs))
+}
+
+func TestFeeGranter(t *testing.T) {
+	key := strings.Repeat("01", 32)
+	granter := sdk.MustBech32ifyAddressBytes("noble", make([]byte, 20))
+
+	n, err := NewChain("", "noble-1", DefaultDomain, []string{key}, 0, 0, 0, 0, "", 0, 0, 0, 0, 0, granter, 0, "", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, sdk.AccAddress(make([]byte, 20)), n.feeGranter)
+
+	n, err = NewChain("", "noble-1", DefaultDomain, []string{key}, 0, 0, 0, 0, "", 0, 0, 0, 0, 0, "", 0, "", nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, n.feeGranter)
+
+	_, err = NewChain("", "noble-1", DefaultDomain, []string{key}, 0, 0, 0, 0, "", 0, 0, 0, 0, 0, "cosmos1invalid", 0, "", nil, nil)
+	require.Error(t, err)
+}
+
+func TestTxFee(t *testing.T) {
+	gasPrices, err := sdk.ParseDecCoins("0.1uusdc")
+	require.NoError(t, err)
+
+	require.Empty(t, txFee(nil, 200000, 0))
+	require.Equal(t, "20000uusdc", txFee(gasPrices, 200000, 0).String())
+	// each bump raises the fee by half
+	require.Equal(t, "30000uusdc", txFee(gasPrices, 200000, 1).String())
+	require.Equal(t, "45000uusdc", txFee(gasPrices, 200000, 2).String())
+	// fractional fees are rounded up
+	require.Equal(t, "1uusdc", txFee(gasPrices, 1, 0).String())
+}
+
+func TestAdjustGas(t *testing.T) {
+	require.EqualValues(t, 150000, adjustGas(100000, 1.5))
+	require.EqualValues(t, 2, adjustGas(1, 1.1))
+}
+
+func TestGasSettings(t *testing.T) {
+	key := strings.Repeat("01", 32)
+
+	n, err := NewChain("", "noble-1", DefaultDomain, []string{key}, 0, 0, 0, 0, "", 0, 0, 0, 0, 0, "", 0, "0.1uusdc", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, defaultGasAdjustment, n.gasAdjustment)
+	require.Equal(t, "0.100000000000000000uusdc", n.gasPrices.String())
+
+	_, err = NewChain("", "noble-1", DefaultDomain, []string{key}, 0, 0, 0, 0, "", 0, 0, 0, 0, 0, "", 0, "uusdc", nil, nil)
+	require.Error(t, err)
+
+	_, err = NewChain("", "noble-1", DefaultDomain, []string{key}, 0, 0, 0, 0, "", 0, 0, 0, 0, 0, "", -1, "", nil, nil)
+	require.Error(t, err)
+}
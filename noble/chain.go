@@ -6,7 +6,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -20,14 +22,15 @@ import (
 )
 
 var _ types.Chain = (*Noble)(nil)
+var _ types.ReachabilityChecker = (*Noble)(nil)
+var _ types.Resumer = (*Noble)(nil)
 
 type Noble struct {
 	// from config
 	chainID               string
+	domain                types.Domain
 	rpcURL                string
-	privateKey            *secp256k1.PrivKey
-	minterAddress         string
-	accountNumber         uint64
+	rpcFailover           *types.RPCFailover // nil without rpc fallbacks
 	startBlock            uint64
 	lookbackPeriod        uint64
 	workers               uint32
@@ -37,6 +40,15 @@ type Noble struct {
 	retryIntervalSeconds  int
 	blockQueueChannelSize uint64
 	minAmount             uint64
+	maxMsgsPerTx          int
+	feeGranter            sdk.AccAddress // nil when the minter pays its own fees
+	gasAdjustment         float64
+	gasPrices             sdk.DecCoins
+	forwardingChannels    map[string]bool // channels mints may be forwarded over, any channel when empty
+
+	// minter accounts mints are broadcast from, picked round-robin by the wallet pool
+	wallets    []*minterWallet
+	walletPool *types.WalletPool
 
 	mu sync.Mutex
 
@@ -44,12 +56,14 @@ type Noble struct {
 
 	latestBlock      uint64
 	lastFlushedBlock uint64
+	blockTime        types.BlockTimeTracker
 }
 
 func NewChain(
 	rpcURL string,
 	chainID string,
-	privateKey string,
+	domain types.Domain,
+	privateKeys []string,
 	startBlock uint64,
 	lookbackPeriod uint64,
 	workers uint32,
@@ -59,37 +73,93 @@ func NewChain(
 	retryIntervalSeconds int,
 	blockQueueChannelSize uint64,
 	minAmount uint64,
+	maxMsgsPerTx int,
+	feeGranter string,
+	gasAdjustment float64,
+	gasPrices string,
+	forwardingChannels []string,
+	rpcFallbacks []string,
 ) (*Noble, error) {
-	keyBz, err := hex.DecodeString(privateKey)
+	wallets := make([]*minterWallet, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		keyBz, err := hex.DecodeString(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse noble private key: %w", err)
+		}
+
+		privKey := secp256k1.PrivKey{Key: keyBz}
+
+		address := privKey.PubKey().Address()
+		wallets[i] = &minterWallet{
+			index:      i,
+			privateKey: &privKey,
+			address:    sdk.MustBech32ifyAddressBytes("noble", address),
+		}
+	}
+
+	var feeGranterAddress sdk.AccAddress
+	if feeGranter != "" {
+		bz, err := sdk.GetFromBech32(feeGranter, "noble")
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee granter %s: %w", feeGranter, err)
+		}
+		feeGranterAddress = bz
+	}
+
+	if gasAdjustment < 0 {
+		return nil, fmt.Errorf("gas-adjustment must not be negative, got %f", gasAdjustment)
+	}
+	if gasAdjustment == 0 {
+		gasAdjustment = defaultGasAdjustment
+	}
+
+	parsedGasPrices, err := sdk.ParseDecCoins(gasPrices)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse noble private key: %w", err)
+		return nil, fmt.Errorf("invalid gas prices %s: %w", gasPrices, err)
 	}
 
-	privKey := secp256k1.PrivKey{Key: keyBz}
+	allowedChannels, err := parseForwardingChannels(forwardingChannels)
+	if err != nil {
+		return nil, err
+	}
 
-	address := privKey.PubKey().Address()
-	minterAddress := sdk.MustBech32ifyAddressBytes("noble", address)
+	var rpcFailover *types.RPCFailover
+	if len(rpcFallbacks) > 0 {
+		// comet's default transport dials the configured address regardless of the request
+		transport := &http.Transport{DisableCompression: true, Proxy: http.ProxyFromEnvironment}
+		if rpcFailover, err = types.NewRPCFailover(rpcURL, rpcFallbacks, transport); err != nil {
+			return nil, err
+		}
+	}
 
 	return &Noble{
 		chainID:               chainID,
+		domain:                domain,
 		rpcURL:                rpcURL,
+		rpcFailover:           rpcFailover,
 		startBlock:            startBlock,
 		lookbackPeriod:        lookbackPeriod,
 		workers:               workers,
-		privateKey:            &privKey,
-		minterAddress:         minterAddress,
 		gasLimit:              gasLimit,
 		txMemo:                txMemo,
 		maxRetries:            maxRetries,
 		retryIntervalSeconds:  retryIntervalSeconds,
 		blockQueueChannelSize: blockQueueChannelSize,
 		minAmount:             minAmount,
+		maxMsgsPerTx:          maxMsgsPerTx,
+		feeGranter:            feeGranterAddress,
+		gasAdjustment:         gasAdjustment,
+		gasPrices:             parsedGasPrices,
+		forwardingChannels:    allowedChannels,
+		wallets:               wallets,
+		walletPool:            types.NewWalletPool(len(wallets), types.DefaultWalletCooldown),
 	}, nil
 }
 
-func (n *Noble) AccountInfo(ctx context.Context) (uint64, uint64, error) {
+// AccountInfo returns the account number and sequence of a minter address
+func (n *Noble) AccountInfo(ctx context.Context, address string) (uint64, uint64, error) {
 	res, err := authtypes.NewQueryClient(n.cc).Account(ctx, &authtypes.QueryAccountRequest{
-		Address: n.minterAddress,
+		Address: address,
 	})
 	if err != nil {
 		return 0, 0, fmt.Errorf("unable to query account for noble: %w", err)
@@ -107,7 +177,7 @@ func (n *Noble) Name() string {
 }
 
 func (n *Noble) Domain() types.Domain {
-	return 4
+	return n.domain
 }
 
 func (n *Noble) LatestBlock() uint64 {
@@ -123,10 +193,20 @@ func (n *Noble) SetLatestBlock(block uint64) {
 	n.mu.Unlock()
 }
 
+// BlockTime returns the average block time observed while tracking the latest height, 0 if not known yet
+func (n *Noble) BlockTime() time.Duration {
+	return n.blockTime.Average()
+}
+
 func (n *Noble) LastFlushedBlock() uint64 {
 	return n.lastFlushedBlock
 }
 
+// Resume makes the listener backfill from block on startup, if it is past the configured start block
+func (n *Noble) Resume(block uint64) {
+	n.startBlock = max(n.startBlock, block)
+}
+
 func (n *Noble) IsDestinationCaller(destinationCaller []byte) (isCaller bool, readableAddress string) {
 	zeroByteArr := make([]byte, 32)
 
@@ -139,7 +219,7 @@ func (n *Noble) IsDestinationCaller(destinationCaller []byte) (isCaller bool, re
 		return false, bech32DestinationCaller
 	}
 
-	return bech32DestinationCaller == n.minterAddress, bech32DestinationCaller
+	return n.callerWallet(bech32DestinationCaller) != nil, bech32DestinationCaller
 }
 
 // DecodeDestinationCaller transforms an encoded Noble cctp address into a noble bech32 address
@@ -155,15 +235,51 @@ func decodeDestinationCaller(input []byte) (string, error) {
 	return output, nil
 }
 
+// decodeMintRecipient extracts the noble bech32 address mints of a burn message are sent to. The mint recipient is
+// left padded to 32 bytes, so anything other than a zero padded, non-empty 20 byte address can't be minted to.
+func decodeMintRecipient(burnMessage *types.BurnMessage) (string, error) {
+	mintRecipient := burnMessage.MintRecipient
+	if len(mintRecipient) != 32 {
+		return "", fmt.Errorf("mint recipient is %d bytes, expected 32", len(mintRecipient))
+	}
+	if !bytes.Equal(mintRecipient[:12], make([]byte, 12)) {
+		return "", fmt.Errorf("mint recipient 0x%x is not a left padded 20 byte address", mintRecipient)
+	}
+	if bytes.Equal(mintRecipient[12:], make([]byte, 20)) {
+		return "", errors.New("mint recipient is the zero address")
+	}
+
+	address, err := decodeDestinationCaller(mintRecipient)
+	if err != nil {
+		return "", err
+	}
+	if _, err := sdk.GetFromBech32(address, "noble"); err != nil {
+		return "", fmt.Errorf("mint recipient %s is not a valid noble address: %w", address, err)
+	}
+	return address, nil
+}
+
 func (n *Noble) InitializeClients(ctx context.Context, logger log.Logger) error {
 	var err error
-	n.cc, err = cosmos.NewProvider(n.rpcURL)
+	if n.rpcFailover != nil {
+		n.cc, err = cosmos.NewProviderWithTransport(n.rpcURL, n.rpcFailover)
+	} else {
+		n.cc, err = cosmos.NewProvider(n.rpcURL)
+	}
 	if err != nil {
 		return fmt.Errorf("unable to build cosmos provider for noble: %w", err)
 	}
 	return nil
 }
 
+// CheckReachability queries the node status over rpc
+func (n *Noble) CheckReachability(ctx context.Context) error {
+	if _, err := n.cc.RPCClient.Status(ctx); err != nil {
+		return fmt.Errorf("rpc %s is unreachable: %w", n.rpcURL, err)
+	}
+	return nil
+}
+
 func (n *Noble) CloseClients() error {
 	if n.cc != nil && n.cc.RPCClient.IsRunning() {
 		err := n.cc.RPCClient.Stop()
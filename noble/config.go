@@ -1,10 +1,6 @@
 package noble
 
 import (
-	"fmt"
-	"os"
-	"strings"
-
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
@@ -12,42 +8,69 @@ var _ types.ChainConfig = (*ChainConfig)(nil)
 
 const defaultBlockQueueChannelSize = 1000000
 
+// defaultGasAdjustment multiplies the simulated gas of a tx when no gas-adjustment is configured
+const defaultGasAdjustment = 1.5
+
+// DefaultDomain is the CCTP domain of noble, used when the config predates the domain field
+const DefaultDomain types.Domain = 4
+
 type ChainConfig struct {
-	RPC     string `yaml:"rpc"`
-	ChainID string `yaml:"chain-id"`
+	RPC       string          `yaml:"rpc"`
+	ChainID   string          `yaml:"chain-id"`
+	Domain    *types.Domain   `yaml:"domain"`     // defaults to 4
+	ChainType types.ChainType `yaml:"chain-type"` // defaults to cosmos
+
+	// tried in order when the rpc fails repeatedly, the rpc is rechecked periodically
+	RPCFallbacks []string `yaml:"rpc-fallbacks"`
 
 	StartBlock     uint64 `yaml:"start-block"`
 	LookbackPeriod uint64 `yaml:"lookback-period"`
 	Workers        uint32 `yaml:"workers"`
 
-	TxMemo                 string `yaml:"tx-memo"`
-	GasLimit               uint64 `yaml:"gas-limit"`
-	BroadcastRetries       int    `yaml:"broadcast-retries"`
-	BroadcastRetryInterval int    `yaml:"broadcast-retry-interval"`
+	TxMemo                 string  `yaml:"tx-memo"`        // {source_tx_hash} is replaced with the burn tx hashes
+	FeeGranter             string  `yaml:"fee-granter"`    // noble address granting the fees of mints, optional
+	GasLimit               uint64  `yaml:"gas-limit"`      // per mint, 0 estimates the gas by simulation
+	GasAdjustment          float64 `yaml:"gas-adjustment"` // multiplies the simulated gas, defaults to 1.5
+	GasPrices              string  `yaml:"gas-prices"`     // e.g. 0.1uusdc, no fee is paid when empty
+	BroadcastRetries       int     `yaml:"broadcast-retries"`
+	BroadcastRetryInterval int     `yaml:"broadcast-retry-interval"`
+	MaxMsgsPerTx           int     `yaml:"max-msgs-per-tx"`
+
+	// channels mints may be forwarded over, e.g. channel-0. Any channel is allowed when empty.
+	ForwardingChannels []string `yaml:"forwarding-channels"`
 
 	BlockQueueChannelSize uint64 `yaml:"block-queue-channel-size"`
 
 	MinMintAmount uint64 `yaml:"min-mint-amount"`
 
-	MinterPrivateKey string `yaml:"minter-private-key"`
+	MinterPrivateKey  string   `yaml:"minter-private-key"`
+	MinterPrivateKeys []string `yaml:"minter-private-keys"` // additional minter wallets broadcasts are spread across
+}
+
+func (c *ChainConfig) DomainType() (types.Domain, types.ChainType) {
+	domain := DefaultDomain
+	if c.Domain != nil {
+		domain = *c.Domain
+	}
+	if c.ChainType == "" {
+		return domain, types.ChainTypeCosmos
+	}
+	return domain, c.ChainType
 }
 
 func (c *ChainConfig) Chain(name string) (types.Chain, error) {
-	envKey := strings.ToUpper(name) + "_PRIV_KEY"
-	privKey := os.Getenv(envKey)
-
-	if len(c.MinterPrivateKey) == 0 || len(privKey) != 0 {
-		if len(privKey) == 0 {
-			return nil, fmt.Errorf("env variable %s is empty, priv key not found for chain %s", envKey, name)
-		} else {
-			c.MinterPrivateKey = privKey
-		}
+	privateKeys, err := types.MinterPrivateKeys(name, c.MinterPrivateKey, c.MinterPrivateKeys)
+	if err != nil {
+		return nil, err
 	}
 
+	domain, _ := c.DomainType()
+
 	return NewChain(
 		c.RPC,
 		c.ChainID,
-		c.MinterPrivateKey,
+		domain,
+		privateKeys,
 		c.StartBlock,
 		c.LookbackPeriod,
 		c.Workers,
@@ -57,5 +80,11 @@ func (c *ChainConfig) Chain(name string) (types.Chain, error) {
 		c.BroadcastRetryInterval,
 		c.BlockQueueChannelSize,
 		c.MinMintAmount,
+		c.MaxMsgsPerTx,
+		c.FeeGranter,
+		c.GasAdjustment,
+		c.GasPrices,
+		c.ForwardingChannels,
+		c.RPCFallbacks,
 	)
 }
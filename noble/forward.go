@@ -0,0 +1,104 @@
+package noble
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var regexChannelID = regexp.MustCompile(`^channel-\d+$`)
+
+// parseForwardingChannels validates the channels mints may be forwarded over
+func parseForwardingChannels(channels []string) (map[string]bool, error) {
+	allowed := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		if !regexChannelID.MatchString(channel) {
+			return nil, fmt.Errorf("invalid forwarding channel %q, expected channel-<n>", channel)
+		}
+		allowed[channel] = true
+	}
+	return allowed, nil
+}
+
+// isForward returns true if msg is a metadata message forwarding the mint of a burn over IBC
+func isForward(msg *types.MessageState) bool {
+	return msg.Metadata != nil
+}
+
+// forwardsBurn returns true if forward is the metadata message forwarding the mint of burn. The metadata message
+// refers to the burn by its nonce.
+func forwardsBurn(forward, burn *types.MessageState) bool {
+	return isForward(forward) && !isForward(burn) &&
+		forward.SourceDomain == burn.SourceDomain &&
+		forward.SourceTxHash == burn.SourceTxHash &&
+		forward.Metadata.Nonce == burn.Nonce
+}
+
+// rejectForwards marks forwards over a channel that isn't allowed as failed, along with the burns they forward so the
+// USDC isn't minted without being forwarded. Any channel is allowed when allowed is empty. Returns the msgs that can
+// be broadcast.
+func rejectForwards(logger log.Logger, msgs []*types.MessageState, allowed map[string]bool) []*types.MessageState {
+	if len(allowed) == 0 {
+		return msgs
+	}
+
+	rejected := make(map[*types.MessageState]string)
+	for _, forward := range msgs {
+		if !isForward(forward) || allowed[forward.Channel] {
+			continue
+		}
+		reason := fmt.Sprintf("forwarding channel %s is not allowed", forward.Channel)
+		rejected[forward] = reason
+		for _, burn := range msgs {
+			if forwardsBurn(forward, burn) {
+				rejected[burn] = reason
+			}
+		}
+	}
+
+	valid := make([]*types.MessageState, 0, len(msgs))
+	for _, msg := range msgs {
+		reason, ok := rejected[msg]
+		if !ok {
+			valid = append(valid, msg)
+			continue
+		}
+		types.MessageLogger(logger, msg).Error("Forward over a channel that isn't allowed, not broadcasting message",
+			"channel", msg.Channel, "src-tx", msg.SourceTxHash)
+		msg.Status = types.Failed
+		msg.FailureReason = reason
+		msg.Updated = time.Now()
+	}
+	return valid
+}
+
+// pairForwards orders each forward right after the burn it forwards, so the burn is minted and forwarded in the same
+// tx. Forwards of burns not in msgs keep their position.
+func pairForwards(msgs []*types.MessageState) []*types.MessageState {
+	paired := make(map[*types.MessageState]bool)
+	for _, forward := range msgs {
+		for _, burn := range msgs {
+			if forwardsBurn(forward, burn) {
+				paired[forward] = true
+			}
+		}
+	}
+
+	ordered := make([]*types.MessageState, 0, len(msgs))
+	for _, msg := range msgs {
+		if paired[msg] {
+			continue
+		}
+		ordered = append(ordered, msg)
+		for _, forward := range msgs {
+			if paired[forward] && forwardsBurn(forward, msg) {
+				ordered = append(ordered, forward)
+			}
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,64 @@
+package noble
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// newForward returns a burn and the metadata message forwarding its mint over channel
+func newForward(txHash string, nonce uint64, channel string) (burn, forward *types.MessageState) {
+	burn = &types.MessageState{SourceTxHash: txHash, Nonce: nonce}
+	forward = &types.MessageState{
+		SourceTxHash: txHash,
+		Nonce:        nonce + 1,
+		Channel:      channel,
+		Metadata:     &types.MetadataMessage{Nonce: nonce},
+	}
+	return burn, forward
+}
+
+func TestParseForwardingChannels(t *testing.T) {
+	allowed, err := parseForwardingChannels([]string{"channel-0", "channel-12"})
+	require.NoError(t, err)
+	require.True(t, allowed["channel-12"])
+	require.False(t, allowed["channel-1"])
+
+	_, err = parseForwardingChannels([]string{"transfer/channel-0"})
+	require.Error(t, err)
+}
+
+func TestRejectForwards(t *testing.T) {
+	burn, forward := newForward("0x01", 1, "channel-0")
+	deniedBurn, deniedForward := newForward("0x02", 5, "channel-9")
+	mint := &types.MessageState{SourceTxHash: "0x03", Nonce: 9}
+	msgs := []*types.MessageState{burn, forward, deniedBurn, deniedForward, mint}
+
+	// any channel is allowed without a config
+	require.Len(t, rejectForwards(log.NewNopLogger(), msgs, nil), 5)
+
+	valid := rejectForwards(log.NewNopLogger(), msgs, map[string]bool{"channel-0": true})
+	require.Equal(t, []*types.MessageState{burn, forward, mint}, valid)
+	require.Equal(t, types.Failed, deniedBurn.Status)
+	require.Equal(t, types.Failed, deniedForward.Status)
+	require.Contains(t, deniedForward.FailureReason, "channel-9")
+}
+
+func TestPairForwards(t *testing.T) {
+	burn, forward := newForward("0x01", 1, "channel-0")
+	otherBurn, otherForward := newForward("0x02", 1, "channel-0")
+	mint := &types.MessageState{SourceTxHash: "0x03", Nonce: 9}
+
+	ordered := pairForwards([]*types.MessageState{forward, mint, otherForward, burn, otherBurn})
+	require.Equal(t, []*types.MessageState{mint, burn, forward, otherBurn, otherForward}, ordered)
+
+	// a forward is kept in the batch of its burn
+	batches := batchMsgs(ordered, 2)
+	require.Len(t, batches, 2)
+	require.Equal(t, []*types.MessageState{mint, burn, forward}, batches[0])
+	require.Equal(t, []*types.MessageState{otherBurn, otherForward}, batches[1])
+}
@@ -19,6 +19,7 @@ func (n *Noble) StartListener(
 	processingQueue chan *types.TxState,
 	flushOnlyMode bool,
 	flushInterval_ time.Duration,
+	metrics *relayer.PromMetrics,
 ) {
 	logger = logger.With("chain", n.Name(), "chain_id", n.chainID, "domain", n.Domain())
 
@@ -32,18 +33,27 @@ func (n *Noble) StartListener(
 		n.startBlock,
 		n.lookbackPeriod))
 
-	accountNumber, _, err := n.AccountInfo(ctx)
-	if err != nil {
-		panic(fmt.Errorf("unable to get account info for noble: %w", err))
-	}
+	for _, wallet := range n.wallets {
+		accountNumber, _, err := n.AccountInfo(ctx, wallet.address)
+		if err != nil {
+			panic(fmt.Errorf("unable to get account info for noble: %w", err))
+		}
 
-	n.accountNumber = accountNumber
+		wallet.accountNumber = accountNumber
+	}
 
 	// enqueue block heights
 	currentBlock := n.startBlock
 	lookback := n.lookbackPeriod
 	chainTip := n.LatestBlock()
 
+	// blocks up to the chain tip at startup are backfilled history
+	backfillTip := chainTip
+	if flushOnlyMode {
+		backfillTip = 0
+	}
+	d := fmt.Sprint(n.Domain())
+
 	if n.blockQueueChannelSize == 0 {
 		n.blockQueueChannelSize = defaultBlockQueueChannelSize
 	}
@@ -101,16 +111,26 @@ func (n *Noble) StartListener(
 						continue
 					}
 
+					var blockTime time.Time
 					for _, tx := range res.Txs {
 						parsedMsgs, err := txToMessageState(tx)
 						if err != nil {
 							logger.Error("Unable to parse Noble log to message state", "err", err.Error())
 							continue
 						}
+						if len(parsedMsgs) > 0 && blockTime.IsZero() {
+							blockTime = n.blockHeaderTime(ctx, logger, int64(block))
+						}
+						for _, parsedMsg := range parsedMsgs {
+							parsedMsg.SourceTime = blockTime
+						}
 						for _, parsedMsg := range parsedMsgs {
 							logger.Info(fmt.Sprintf("New stream msg with nonce %d from %d with tx hash %s", parsedMsg.Nonce, parsedMsg.SourceDomain, parsedMsg.SourceTxHash))
 						}
-						processingQueue <- &types.TxState{TxHash: tx.Hash.String(), Msgs: parsedMsgs}
+						if block <= backfillTip && metrics != nil {
+							metrics.AddBackfilledMessages(n.Name(), d, len(parsedMsgs))
+						}
+						types.Enqueue(logger, processingQueue, &types.TxState{TxHash: tx.Hash.String(), Msgs: parsedMsgs}, n.Name(), n.Domain())
 					}
 				}
 			}
@@ -210,12 +230,17 @@ func (n *Noble) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m
 	// inner function to update block height
 	updateBlockHeight := func() {
 		res, err := n.cc.RPCClient.Status(ctx)
+		n.rpcFailover.ReportActive(m, n.Name(), d)
 		if err != nil {
 			logger.Error("Unable to query Nobles latest height", "err", err)
 		} else {
 			n.SetLatestBlock(uint64(res.SyncInfo.LatestBlockHeight))
+			n.blockTime.Observe(uint64(res.SyncInfo.LatestBlockHeight), time.Now())
 			if m != nil {
 				m.SetLatestHeight(n.Name(), d, res.SyncInfo.LatestBlockHeight)
+				if blockTime := n.BlockTime(); blockTime > 0 {
+					m.SetBlockTime(n.Name(), d, blockTime)
+				}
 			}
 		}
 	}
@@ -239,3 +264,13 @@ func (n *Noble) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m
 func (n *Noble) WalletBalanceMetric(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) {
 	// Relaying is free. No need to track noble balance.
 }
+
+// blockHeaderTime returns the time of the block at the given height, zero if it can't be queried
+func (n *Noble) blockHeaderTime(ctx context.Context, logger log.Logger, height int64) time.Time {
+	res, err := n.cc.RPCClient.Header(ctx, &height)
+	if err != nil || res == nil || res.Header == nil {
+		logger.Debug(fmt.Sprintf("Unable to query Noble block %d header", height), "error:", err)
+		return time.Time{}
+	}
+	return res.Header.Time
+}
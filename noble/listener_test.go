@@ -27,7 +27,7 @@ func TestStartListener(t *testing.T) {
 
 	processingQueue := make(chan *types.TxState, 10000)
 
-	go n.StartListener(ctx, a.Logger, processingQueue, false, 0)
+	go n.StartListener(ctx, a.Logger, processingQueue, false, 0, nil)
 
 	time.Sleep(20 * time.Second)
 
@@ -59,6 +59,7 @@ func txToMessageState(tx *ctypes.ResultTx) ([]*types.MessageState, error) {
 						MsgSentBytes:      rawMessageSentBytes,
 						MsgBody:           msg.MessageBody,
 						DestinationCaller: msg.DestinationCaller,
+						SourceBlock:       uint64(tx.Height),
 						Created:           now,
 						Updated:           now,
 					}
@@ -0,0 +1,66 @@
+package noble
+
+import (
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// minterWallet is one of the accounts mints are broadcast from. Each wallet has its own account sequence.
+type minterWallet struct {
+	index         int
+	privateKey    *secp256k1.PrivKey
+	address       string
+	accountNumber uint64
+
+	// serializes sequence use so txs reach the node in sequence order
+	mu sync.Mutex
+}
+
+// callerWallet returns the minter wallet with the bech32 address, nil if it matches none
+func (n *Noble) callerWallet(address string) *minterWallet {
+	for _, wallet := range n.wallets {
+		if wallet.address == address {
+			return wallet
+		}
+	}
+	return nil
+}
+
+// pinnedWallet returns the minter wallet a msg's destination caller refers to, nil if it can be received by any wallet
+func (n *Noble) pinnedWallet(msg *types.MessageState) *minterWallet {
+	caller, err := decodeDestinationCaller(msg.DestinationCaller)
+	if err != nil {
+		return nil
+	}
+	return n.callerWallet(caller)
+}
+
+// groupByWallet splits msgs by the minter wallet their destination caller pins them to, keeping their order.
+// Msgs any wallet can receive are grouped together.
+func (n *Noble) groupByWallet(msgs []*types.MessageState) [][]*types.MessageState {
+	var groups [][]*types.MessageState
+	index := make(map[*minterWallet]int)
+	for _, msg := range msgs {
+		wallet := n.pinnedWallet(msg)
+		i, ok := index[wallet]
+		if !ok {
+			i = len(groups)
+			index[wallet] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], msg)
+	}
+	return groups
+}
+
+// walletFor returns the wallet to broadcast a batch with, the wallet its msgs are pinned to or else the next
+// wallet of the pool
+func (n *Noble) walletFor(msgs []*types.MessageState) *minterWallet {
+	if wallet := n.pinnedWallet(msgs[0]); wallet != nil {
+		return wallet
+	}
+	return n.wallets[n.walletPool.Next()]
+}
@@ -0,0 +1,42 @@
+package noble
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestGroupByWallet verifies msgs pinned to a minter wallet by their destination caller are broadcast by that wallet
+func TestGroupByWallet(t *testing.T) {
+	n, err := NewChain("", "noble-1", DefaultDomain, []string{strings.Repeat("01", 32), strings.Repeat("02", 32)},
+		0, 0, 0, 0, "", 0, 0, 0, 0, 0, "", 0, "", nil, nil)
+	require.NoError(t, err)
+
+	_, addressBz, err := bech32.DecodeAndConvert(n.wallets[1].address)
+	require.NoError(t, err)
+	pinnedCaller := append(make([]byte, 12), addressBz...)
+
+	isCaller, _ := n.IsDestinationCaller(pinnedCaller)
+	require.True(t, isCaller)
+
+	msgs := []*types.MessageState{
+		{Nonce: 0, DestinationCaller: make([]byte, 32)},
+		{Nonce: 1, DestinationCaller: pinnedCaller},
+		{Nonce: 2, DestinationCaller: make([]byte, 32)},
+	}
+
+	groups := n.groupByWallet(msgs)
+	require.Len(t, groups, 2)
+	require.Equal(t, []*types.MessageState{msgs[0], msgs[2]}, groups[0])
+	require.Equal(t, []*types.MessageState{msgs[1]}, groups[1])
+
+	// pinned msgs always go to their wallet, the rest round-robin
+	require.Equal(t, n.wallets[1], n.walletFor(groups[1]))
+	require.Equal(t, n.wallets[1], n.walletFor(groups[1]))
+	require.Equal(t, n.wallets[0], n.walletFor(groups[0]))
+	require.Equal(t, n.wallets[1], n.walletFor(groups[0]))
+}
@@ -0,0 +1,163 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// BalanceMonitor fires low balance events when a minter wallet drops below its configured threshold. Chains
+// without a threshold in the notifications config fall back to their own min-balance-alert. A chain alerts
+// once per drop and again only after its balance recovers. Dropping below the hard floor
+// is a critical condition that is resolved once the balance recovers.
+type BalanceMonitor struct {
+	notifier    Notifier
+	logger      log.Logger
+	chains      map[string]types.BalanceReporter
+	thresholds  map[string]float64
+	floors      map[string]float64
+	interval    time.Duration
+	low         map[string]bool
+	belowFloors map[string]bool
+}
+
+func NewBalanceMonitor(cfg types.NotificationsConfig, notifier Notifier, chains map[types.Domain]types.Chain, logger log.Logger) *BalanceMonitor {
+	interval := cfg.BalanceCheckInterval
+	if interval == 0 {
+		interval = 300
+	}
+
+	thresholds := make(map[string]float64, len(cfg.LowBalanceThresholds))
+	for chain, threshold := range cfg.LowBalanceThresholds {
+		thresholds[chain] = threshold
+	}
+
+	m := &BalanceMonitor{
+		notifier:    notifier,
+		logger:      logger.With("component", "balance-monitor"),
+		chains:      make(map[string]types.BalanceReporter),
+		thresholds:  thresholds,
+		floors:      cfg.Critical.BalanceFloors,
+		interval:    time.Duration(interval) * time.Second,
+		low:         make(map[string]bool),
+		belowFloors: make(map[string]bool),
+	}
+
+	for _, chain := range chains {
+		reporter, isReporter := chain.(types.BalanceReporter)
+		if _, ok := thresholds[chain.Name()]; !ok && isReporter && reporter.MinBalanceAlert() > 0 {
+			thresholds[chain.Name()] = reporter.MinBalanceAlert()
+		}
+
+		_, hasThreshold := thresholds[chain.Name()]
+		_, hasFloor := cfg.Critical.BalanceFloors[chain.Name()]
+		if !hasThreshold && !hasFloor {
+			continue
+		}
+		if isReporter {
+			m.chains[chain.Name()] = reporter
+		} else {
+			m.logger.Info("Chain does not report a wallet balance, ignoring balance thresholds", "chain", chain.Name())
+		}
+	}
+
+	return m
+}
+
+func (m *BalanceMonitor) Start(ctx context.Context) {
+	m.logger.Info("Starting low balance monitoring", "interval", m.interval)
+	m.checkBalances(ctx)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkBalances(ctx)
+		}
+	}
+}
+
+func (m *BalanceMonitor) checkBalances(ctx context.Context) {
+	for name, chain := range m.chains {
+		balance, denom, err := chain.WalletBalance(ctx)
+		if err != nil {
+			m.logger.Error("Failed to query wallet balance", "chain", name, "error", err)
+			continue
+		}
+		m.update(ctx, name, balance, denom)
+	}
+}
+
+// update records the latest balance for a chain and notifies if it crossed the threshold or floor
+func (m *BalanceMonitor) update(ctx context.Context, chain string, balance float64, denom string) {
+	if floor, ok := m.floors[chain]; ok {
+		m.updateFloor(ctx, chain, balance, floor, denom)
+	}
+
+	threshold, ok := m.thresholds[chain]
+	if !ok {
+		return
+	}
+	if balance >= threshold {
+		m.low[chain] = false
+		return
+	}
+	if m.low[chain] {
+		return
+	}
+	m.low[chain] = true
+
+	m.logger.Info("Minter balance below threshold", "chain", chain, "balance", balance, "threshold", threshold, "denom", denom)
+	m.notifier.Notify(ctx, Event{
+		Type:      EventLowBalance,
+		Chain:     chain,
+		Balance:   balance,
+		Threshold: threshold,
+		Denom:     denom,
+		Timestamp: time.Now(),
+	})
+}
+
+func (m *BalanceMonitor) updateFloor(ctx context.Context, chain string, balance, floor float64, denom string) {
+	below := balance < floor
+	if below == m.belowFloors[chain] {
+		return
+	}
+	m.belowFloors[chain] = below
+
+	if below {
+		m.logger.Error("Minter balance below hard floor", "chain", chain, "balance", balance, "floor", floor, "denom", denom)
+	}
+	m.notifier.Notify(ctx, Event{
+		Type:      EventBalanceFloor,
+		Resolved:  !below,
+		Chain:     chain,
+		Balance:   balance,
+		Threshold: floor,
+		Denom:     denom,
+		Timestamp: time.Now(),
+	})
+}
+
+// StartBalanceMonitor starts background balance monitoring if a notifier and thresholds or floors are configured.
+// Returns nil if disabled, otherwise returns monitor instance running in background goroutine.
+func StartBalanceMonitor(ctx context.Context, cfg types.NotificationsConfig, notifier Notifier, chains map[types.Domain]types.Chain, logger log.Logger) *BalanceMonitor {
+	if notifier == nil {
+		return nil
+	}
+
+	monitor := NewBalanceMonitor(cfg, notifier, chains, logger)
+	if len(monitor.chains) == 0 {
+		return nil
+	}
+
+	go monitor.Start(ctx)
+	return monitor
+}
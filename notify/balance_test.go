@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+type recordingNotifier struct {
+	events []Event
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, event Event) {
+	r.events = append(r.events, event)
+}
+
+// TestBalanceMonitor verifies a low balance alerts once per drop below the threshold
+func TestBalanceMonitor(t *testing.T) {
+	notifier := &recordingNotifier{}
+	m := NewBalanceMonitor(types.NotificationsConfig{
+		LowBalanceThresholds: map[string]float64{"ethereum": 1},
+	}, notifier, nil, log.NewNopLogger())
+
+	ctx := context.Background()
+	m.update(ctx, "ethereum", 2, "ETH")
+	require.Empty(t, notifier.events)
+
+	m.update(ctx, "ethereum", 0.5, "ETH")
+	m.update(ctx, "ethereum", 0.4, "ETH")
+	require.Len(t, notifier.events, 1)
+	require.Equal(t, EventLowBalance, notifier.events[0].Type)
+	require.Equal(t, 0.5, notifier.events[0].Balance)
+
+	// recovered then dropped again
+	m.update(ctx, "ethereum", 1.5, "ETH")
+	m.update(ctx, "ethereum", 0.1, "ETH")
+	require.Len(t, notifier.events, 2)
+}
+
+// TestBalanceMonitor_Floor verifies dropping below the hard floor is critical and resolves on recovery
+func TestBalanceMonitor_Floor(t *testing.T) {
+	notifier := &recordingNotifier{}
+	m := NewBalanceMonitor(types.NotificationsConfig{
+		Critical: types.CriticalConfig{BalanceFloors: map[string]float64{"ethereum": 0.1}},
+	}, notifier, nil, log.NewNopLogger())
+
+	ctx := context.Background()
+	m.update(ctx, "ethereum", 0.05, "ETH")
+	m.update(ctx, "ethereum", 0.04, "ETH")
+	require.Len(t, notifier.events, 1)
+	require.Equal(t, EventBalanceFloor, notifier.events[0].Type)
+	require.False(t, notifier.events[0].Resolved)
+
+	m.update(ctx, "ethereum", 1, "ETH")
+	require.Len(t, notifier.events, 2)
+	require.True(t, notifier.events[1].Resolved)
+}
+
+// balanceChain is a chain reporting a fixed balance, only the methods used by the monitor are implemented
+type balanceChain struct {
+	types.Chain
+	name       string
+	minBalance float64
+}
+
+func (c *balanceChain) Name() string { return c.name }
+
+func (c *balanceChain) WalletBalance(_ context.Context) (float64, string, error) {
+	return 0.5, "ETH", nil
+}
+
+func (c *balanceChain) MinBalanceAlert() float64 { return c.minBalance }
+
+// TestBalanceMonitor_ChainMinBalance verifies a chain's min-balance-alert is used unless notifications configure a threshold
+func TestBalanceMonitor_ChainMinBalance(t *testing.T) {
+	notifier := &recordingNotifier{}
+	m := NewBalanceMonitor(types.NotificationsConfig{
+		LowBalanceThresholds: map[string]float64{"arbitrum": 0.1},
+	}, notifier, map[types.Domain]types.Chain{
+		0: &balanceChain{name: "ethereum", minBalance: 1},
+		3: &balanceChain{name: "arbitrum", minBalance: 1},
+		6: &balanceChain{name: "base"},
+	}, log.NewNopLogger())
+
+	require.Len(t, m.chains, 2)
+	require.Equal(t, map[string]float64{"ethereum": 1, "arbitrum": 0.1}, m.thresholds)
+
+	m.checkBalances(context.Background())
+	require.Len(t, notifier.events, 1)
+	require.Equal(t, "ethereum", notifier.events[0].Chain)
+	require.Equal(t, 1.0, notifier.events[0].Threshold)
+}
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// circleCheckInterval is how often the Circle API failure count is checked
+const circleCheckInterval = 30 * time.Second
+
+// CriticalMonitor tracks conditions that need on-call attention. An event is fired when a condition
+// becomes critical and a resolved event once it clears.
+type CriticalMonitor struct {
+	notifier           Notifier
+	logger             log.Logger
+	broadcastThreshold int
+	circleThreshold    int64
+
+	mu                sync.Mutex
+	broadcastFailures map[types.Domain]int
+	active            map[string]bool
+}
+
+func NewCriticalMonitor(cfg types.CriticalConfig, notifier Notifier, logger log.Logger) *CriticalMonitor {
+	broadcastThreshold := cfg.BroadcastErrorThreshold
+	if broadcastThreshold == 0 {
+		broadcastThreshold = 3
+	}
+	circleThreshold := cfg.CircleFailureThreshold
+	if circleThreshold == 0 {
+		circleThreshold = 5
+	}
+
+	return &CriticalMonitor{
+		notifier:           notifier,
+		logger:             logger.With("component", "critical-monitor"),
+		broadcastThreshold: int(broadcastThreshold),
+		circleThreshold:    int64(circleThreshold),
+		broadcastFailures:  make(map[types.Domain]int),
+		active:             make(map[string]bool),
+	}
+}
+
+// BroadcastResult records the outcome of a broadcast to a chain
+func (c *CriticalMonitor) BroadcastResult(ctx context.Context, chain string, domain types.Domain, err error) {
+	c.mu.Lock()
+	if err != nil {
+		c.broadcastFailures[domain]++
+	} else {
+		c.broadcastFailures[domain] = 0
+	}
+	failures := c.broadcastFailures[domain]
+	c.mu.Unlock()
+
+	event := Event{
+		Type:       EventBroadcastFailing,
+		Chain:      chain,
+		DestDomain: domain,
+		Failures:   failures,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	c.set(ctx, fmt.Sprintf("%s/%d", EventBroadcastFailing, domain), failures >= c.broadcastThreshold, event)
+}
+
+// Start periodically checks whether the Circle API is reachable
+func (c *CriticalMonitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(circleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.circleFailures(ctx, circle.ConsecutiveAPIFailures())
+		}
+	}
+}
+
+func (c *CriticalMonitor) circleFailures(ctx context.Context, failures int64) {
+	c.set(ctx, EventCircleUnreachable, failures >= c.circleThreshold, Event{
+		Type:     EventCircleUnreachable,
+		Failures: int(failures),
+	})
+}
+
+// set notifies when the condition identified by key becomes critical or clears
+func (c *CriticalMonitor) set(ctx context.Context, key string, critical bool, event Event) {
+	c.mu.Lock()
+	changed := c.active[key] != critical
+	c.active[key] = critical
+	c.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if critical {
+		c.logger.Error("Critical condition detected", "condition", key, "failures", event.Failures)
+	} else {
+		c.logger.Info("Critical condition resolved", "condition", key)
+	}
+
+	event.Resolved = !critical
+	event.Timestamp = time.Now()
+	c.notifier.Notify(ctx, event)
+}
+
+// StartCriticalMonitor starts background critical condition monitoring if a notifier is configured.
+// Returns nil if disabled, otherwise returns monitor instance running in background goroutine.
+func StartCriticalMonitor(ctx context.Context, cfg types.CriticalConfig, notifier Notifier, logger log.Logger) *CriticalMonitor {
+	if notifier == nil {
+		return nil
+	}
+
+	monitor := NewCriticalMonitor(cfg, notifier, logger)
+	go monitor.Start(ctx)
+	return monitor
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestCriticalMonitor verifies conditions fire once when they become critical and resolve once they clear
+func TestCriticalMonitor(t *testing.T) {
+	notifier := &recordingNotifier{}
+	c := NewCriticalMonitor(types.CriticalConfig{BroadcastErrorThreshold: 2, CircleFailureThreshold: 3}, notifier, log.NewNopLogger())
+	ctx := context.Background()
+
+	c.BroadcastResult(ctx, "ethereum", 0, errors.New("boom"))
+	require.Empty(t, notifier.events)
+
+	c.BroadcastResult(ctx, "ethereum", 0, errors.New("boom"))
+	c.BroadcastResult(ctx, "ethereum", 0, errors.New("boom"))
+	require.Len(t, notifier.events, 1)
+	require.Equal(t, EventBroadcastFailing, notifier.events[0].Type)
+	require.False(t, notifier.events[0].Resolved)
+	require.Equal(t, "boom", notifier.events[0].Error)
+
+	// other chains are tracked separately
+	c.BroadcastResult(ctx, "avalanche", 1, nil)
+	require.Len(t, notifier.events, 1)
+
+	c.BroadcastResult(ctx, "ethereum", 0, nil)
+	require.Len(t, notifier.events, 2)
+	require.True(t, notifier.events[1].Resolved)
+
+	c.circleFailures(ctx, 3)
+	c.circleFailures(ctx, 4)
+	c.circleFailures(ctx, 0)
+	require.Len(t, notifier.events, 4)
+	require.Equal(t, EventCircleUnreachable, notifier.events[2].Type)
+	require.True(t, notifier.events[3].Resolved)
+}
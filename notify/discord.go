@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var _ Notifier = (*DiscordNotifier)(nil)
+
+// DiscordNotifier posts alerts for failed relays and low balances to a Discord webhook
+type DiscordNotifier struct {
+	webhookURL     string
+	events         map[string]struct{}
+	explorerTxURLs map[types.Domain]string
+	client         *http.Client
+	logger         log.Logger
+}
+
+func NewDiscordNotifier(cfg types.DiscordConfig, logger log.Logger) *DiscordNotifier {
+	return &DiscordNotifier{
+		webhookURL:     cfg.WebhookURL,
+		events:         stringSet(cfg.Events, alertEvents...),
+		explorerTxURLs: cfg.ExplorerTxURLs,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		logger:         logger.With("component", "discord-notifier"),
+	}
+}
+
+// Notify posts the alert in the background if its event is enabled
+func (d *DiscordNotifier) Notify(ctx context.Context, event Event) {
+	if _, ok := d.events[alertEvent(event)]; !ok {
+		return
+	}
+
+	go func() {
+		if err := d.post(context.WithoutCancel(ctx), d.format(event)); err != nil {
+			d.logger.Error("Failed to send Discord alert", "type", event.Type, "tx", event.SourceTxHash, "error", err)
+		}
+	}()
+}
+
+// format renders the event as Discord markdown
+func (d *DiscordNotifier) format(event Event) string {
+	if event.Type == EventLowBalance {
+		return fmt.Sprintf(":warning: **Low minter balance on %s**: %g %s is below the %g %s threshold",
+			event.Chain, event.Balance, event.Denom, event.Threshold, event.Denom)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** from domain %d to %d\n", alertTitle(event), event.SourceDomain, event.DestDomain)
+	fmt.Fprintf(&b, "Nonce: %d", event.Nonce)
+	if event.AmountFormatted != "" {
+		fmt.Fprintf(&b, ", amount: %s", event.AmountFormatted)
+	} else if event.Amount != "" {
+		fmt.Fprintf(&b, ", amount: %s", event.Amount)
+	}
+	if url := explorerTxURL(d.explorerTxURLs, event.SourceDomain, event.SourceTxHash); url != "" {
+		fmt.Fprintf(&b, "\nSource tx: [%s](<%s>)", event.SourceTxHash, url)
+	} else {
+		fmt.Fprintf(&b, "\nSource tx: `%s`", event.SourceTxHash)
+	}
+	return b.String()
+}
+
+func (d *DiscordNotifier) post(ctx context.Context, content string) error {
+	body, err := json.Marshal(map[string]any{
+		"content": content,
+		// alerts never ping users or roles
+		"allowed_mentions": map[string][]string{"parse": {}},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// webhooks answer 204 No Content, or 200 when called with ?wait=true
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
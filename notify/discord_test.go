@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestDiscordNotifier verifies only enabled events are posted, with the source tx linked to the explorer
+func TestDiscordNotifier(t *testing.T) {
+	received := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Content string `json:"content"`
+		}
+		if json.NewDecoder(r.Body).Decode(&payload) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- payload.Content
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewDiscordNotifier(types.DiscordConfig{
+		WebhookURL:     server.URL,
+		Events:         []string{AlertEventFailed},
+		ExplorerTxURLs: map[types.Domain]string{0: "https://etherscan.io/tx/{tx}"},
+	}, log.NewNopLogger())
+
+	msg := &types.MessageState{Status: types.Failed, SourceDomain: 0, DestDomain: 4, Nonce: 7, SourceTxHash: "0xabc"}
+
+	// stuck pending alerts are not enabled
+	event := NewEvent(msg)
+	event.Type = EventStuckPending
+	notifier.Notify(context.Background(), event)
+
+	notifier.Notify(context.Background(), NewEvent(msg))
+
+	select {
+	case content := <-received:
+		require.Contains(t, content, "**Relay failed** from domain 0 to 4")
+		require.Contains(t, content, "Nonce: 7")
+		require.Contains(t, content, "[0xabc](<https://etherscan.io/tx/0xabc>)")
+	case <-time.After(5 * time.Second):
+		t.Fatal("discord webhook was not called")
+	}
+
+	select {
+	case content := <-received:
+		t.Fatalf("unexpected alert: %s", content)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
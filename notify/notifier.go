@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	cctptypes "github.com/circlefin/noble-cctp/x/cctp/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// Event types
+const (
+	EventStatus            = "status"             // a message reached a new status
+	EventReattestExhausted = "reattest-exhausted" // a message failed after exhausting re-attestation retries
+	EventLowBalance        = "low-balance"        // a minter wallet balance dropped below its threshold
+	EventStuckPending      = "stuck-pending"      // a message stayed pending past the stuck pending threshold
+	EventUnknownAttester   = "unknown-attester"   // a message was held because an attester outside the set signed it
+
+	// critical conditions, followed by a resolved event once the condition clears
+	EventBroadcastFailing  = "broadcast-failing"  // broadcasts to a chain repeatedly failed
+	EventCircleUnreachable = "circle-unreachable" // the Circle API repeatedly failed to respond
+	EventBalanceFloor      = "balance-floor"      // a minter wallet balance dropped below its hard floor
+)
+
+// Alert events posted by the chat notifiers (Slack, Discord and Telegram)
+const (
+	AlertEventFailed            = "failed"
+	AlertEventReattestExhausted = EventReattestExhausted
+	AlertEventLowBalance        = EventLowBalance
+	AlertEventStuckPending      = EventStuckPending
+	AlertEventUnknownAttester   = EventUnknownAttester
+)
+
+// alertEvents are the alert events enabled when a chat notifier doesn't configure any
+var alertEvents = []string{
+	AlertEventFailed, AlertEventReattestExhausted, AlertEventLowBalance, AlertEventStuckPending, AlertEventUnknownAttester,
+}
+
+// Event describes a relay lifecycle event or critical condition. Only the fields relevant to the
+// event type are set.
+type Event struct {
+	Type            string       `json:"type"`
+	Resolved        bool         `json:"resolved,omitempty"`
+	Status          string       `json:"status,omitempty"`
+	SourceDomain    types.Domain `json:"source_domain"`
+	DestDomain      types.Domain `json:"dest_domain"`
+	Nonce           uint64       `json:"nonce"`
+	SourceTxHash    string       `json:"source_tx_hash,omitempty"`
+	DestTxHash      string       `json:"dest_tx_hash,omitempty"`
+	Amount          string       `json:"amount,omitempty"`           // raw burn amount in the token's base units
+	AmountFormatted string       `json:"amount_formatted,omitempty"` // burn amount scaled by the token decimals
+
+	Chain     string  `json:"chain,omitempty"`
+	Balance   float64 `json:"balance,omitempty"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Denom     string  `json:"denom,omitempty"`
+
+	Failures int    `json:"failures,omitempty"`
+	Error    string `json:"error,omitempty"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewEvent creates a status event from the current state of a message
+func NewEvent(msg *types.MessageState) Event {
+	event := Event{
+		Type:         EventStatus,
+		Status:       msg.Status,
+		SourceDomain: msg.SourceDomain,
+		DestDomain:   msg.DestDomain,
+		Nonce:        msg.Nonce,
+		SourceTxHash: msg.SourceTxHash,
+		DestTxHash:   msg.DestTxHash,
+		Timestamp:    time.Now(),
+	}
+
+	if bm, err := new(cctptypes.BurnMessage).Parse(msg.MsgBody); err == nil {
+		event.Amount = bm.Amount.String()
+		event.AmountFormatted = msg.AmountFormatted
+	}
+
+	return event
+}
+
+// Notifier pushes relay events to an external system. Notify must not block the caller, delivery
+// failures are logged by the notifier.
+type Notifier interface {
+	Notify(ctx context.Context, event Event)
+}
+
+// New returns a Notifier for all configured targets, nil if none are configured
+func New(cfg types.NotificationsConfig, logger log.Logger) Notifier {
+	var notifiers multiNotifier
+	if cfg.Webhook.URL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(cfg.Webhook, logger))
+	}
+	if cfg.Slack.WebhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(cfg.Slack, logger))
+	}
+	if cfg.PagerDuty.RoutingKey != "" {
+		notifiers = append(notifiers, NewPagerDutyNotifier(cfg.PagerDuty, logger))
+	}
+	if cfg.Discord.WebhookURL != "" {
+		notifiers = append(notifiers, NewDiscordNotifier(cfg.Discord, logger))
+	}
+	if cfg.Telegram.BotToken != "" && cfg.Telegram.ChatID != "" {
+		notifiers = append(notifiers, NewTelegramNotifier(cfg.Telegram, logger))
+	}
+
+	switch len(notifiers) {
+	case 0:
+		return nil
+	case 1:
+		return notifiers[0]
+	default:
+		return notifiers
+	}
+}
+
+// multiNotifier fans events out to several notifiers
+type multiNotifier []Notifier
+
+func (m multiNotifier) Notify(ctx context.Context, event Event) {
+	for _, n := range m {
+		n.Notify(ctx, event)
+	}
+}
+
+// alertEvent maps an event to the chat alert it triggers, empty if none
+func alertEvent(event Event) string {
+	switch {
+	case event.Type == EventStatus && event.Status == types.Failed:
+		return AlertEventFailed
+	case event.Type == EventReattestExhausted:
+		return AlertEventReattestExhausted
+	case event.Type == EventLowBalance:
+		return AlertEventLowBalance
+	case event.Type == EventStuckPending:
+		return AlertEventStuckPending
+	case event.Type == EventUnknownAttester:
+		return AlertEventUnknownAttester
+	default:
+		return ""
+	}
+}
+
+// alertTitle returns the headline of a chat alert for a message event
+func alertTitle(event Event) string {
+	switch event.Type {
+	case EventReattestExhausted:
+		return "Relay failed, re-attestation retries exhausted"
+	case EventStuckPending:
+		return "Attestation stuck pending"
+	case EventUnknownAttester:
+		return "Relay held, attestation signed by an unknown attester"
+	default:
+		return "Relay failed"
+	}
+}
+
+// explorerTxURL returns the explorer link of the tx hash configured for its domain, empty if none
+func explorerTxURL(explorerTxURLs map[types.Domain]string, domain types.Domain, txHash string) string {
+	template := explorerTxURLs[domain]
+	if template == "" {
+		return ""
+	}
+	return strings.ReplaceAll(template, "{tx}", txHash)
+}
+
+// stringSet returns the configured values as a set, falling back to defaults if none are configured
+func stringSet(values []string, defaults ...string) map[string]struct{} {
+	if len(values) == 0 {
+		values = defaults
+	}
+
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var _ Notifier = (*PagerDutyNotifier)(nil)
+
+const defaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyNotifier triggers and resolves PagerDuty incidents for critical conditions
+type PagerDutyNotifier struct {
+	routingKey string
+	severities map[string]string
+	eventsURL  string
+	client     *http.Client
+	logger     log.Logger
+}
+
+func NewPagerDutyNotifier(cfg types.PagerDutyConfig, logger log.Logger) *PagerDutyNotifier {
+	eventsURL := cfg.EventsURL
+	if eventsURL == "" {
+		eventsURL = defaultPagerDutyEventsURL
+	}
+
+	return &PagerDutyNotifier{
+		routingKey: cfg.RoutingKey,
+		severities: cfg.Severities,
+		eventsURL:  eventsURL,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		logger:     logger.With("component", "pagerduty-notifier"),
+	}
+}
+
+// pagerDutyEvent is an Events API v2 request
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string `json:"summary"`
+	Source        string `json:"source"`
+	Severity      string `json:"severity"`
+	Component     string `json:"component,omitempty"`
+	Class         string `json:"class"`
+	CustomDetails Event  `json:"custom_details"`
+}
+
+// Notify triggers or resolves an incident in the background for critical condition events
+func (p *PagerDutyNotifier) Notify(ctx context.Context, event Event) {
+	dedupKey, ok := pagerDutyDedupKey(event)
+	if !ok {
+		return
+	}
+
+	request := pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: "trigger",
+		DedupKey:    dedupKey,
+	}
+	if event.Resolved {
+		request.EventAction = "resolve"
+	} else {
+		request.Payload = &pagerDutyPayload{
+			Summary:       pagerDutySummary(event),
+			Source:        "noble-cctp-relayer",
+			Severity:      p.severity(event.Type),
+			Component:     event.Chain,
+			Class:         event.Type,
+			CustomDetails: event,
+		}
+	}
+
+	go func() {
+		if err := p.send(context.WithoutCancel(ctx), request); err != nil {
+			p.logger.Error("Failed to send PagerDuty event", "dedup_key", dedupKey, "action", request.EventAction, "error", err)
+		}
+	}()
+}
+
+// pagerDutyDedupKey identifies the incident for a critical condition, ok is false for other events
+func pagerDutyDedupKey(event Event) (string, bool) {
+	switch event.Type {
+	case EventBroadcastFailing:
+		return fmt.Sprintf("cctp-relayer/%d/%s", event.DestDomain, event.Type), true
+	case EventCircleUnreachable:
+		return "cctp-relayer/" + event.Type, true
+	case EventBalanceFloor:
+		return fmt.Sprintf("cctp-relayer/%s/%s", event.Chain, event.Type), true
+	default:
+		return "", false
+	}
+}
+
+func pagerDutySummary(event Event) string {
+	switch event.Type {
+	case EventBroadcastFailing:
+		return fmt.Sprintf("%d consecutive broadcasts to %s (domain %d) failed: %s", event.Failures, event.Chain, event.DestDomain, event.Error)
+	case EventCircleUnreachable:
+		return fmt.Sprintf("Circle API unreachable after %d consecutive failed requests", event.Failures)
+	case EventBalanceFloor:
+		return fmt.Sprintf("Minter balance on %s is %g %s, below the %g %s floor", event.Chain, event.Balance, event.Denom, event.Threshold, event.Denom)
+	default:
+		return event.Type
+	}
+}
+
+// severity returns the configured severity for an event type, critical by default
+func (p *PagerDutyNotifier) severity(eventType string) string {
+	if severity, ok := p.severities[eventType]; ok {
+		return severity
+	}
+	return "critical"
+}
+
+func (p *PagerDutyNotifier) send(ctx context.Context, request pagerDutyEvent) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("unable to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.eventsURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
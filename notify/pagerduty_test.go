@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestPagerDutyNotifier verifies critical conditions trigger and resolve incidents with a stable dedup key
+func TestPagerDutyNotifier(t *testing.T) {
+	received := make(chan pagerDutyEvent, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		if json.NewDecoder(r.Body).Decode(&event) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- event
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewPagerDutyNotifier(types.PagerDutyConfig{
+		RoutingKey: "key",
+		Severities: map[string]string{EventBroadcastFailing: "error"},
+		EventsURL:  server.URL,
+	}, log.NewNopLogger())
+
+	// message events don't page
+	notifier.Notify(context.Background(), Event{Type: EventStatus, Status: types.Failed})
+
+	notifier.Notify(context.Background(), Event{Type: EventBroadcastFailing, Chain: "ethereum", DestDomain: 0, Failures: 3})
+	trigger := <-received
+	require.Equal(t, "trigger", trigger.EventAction)
+	require.Equal(t, "key", trigger.RoutingKey)
+	require.Equal(t, "cctp-relayer/0/broadcast-failing", trigger.DedupKey)
+	require.Equal(t, "error", trigger.Payload.Severity)
+
+	notifier.Notify(context.Background(), Event{Type: EventBroadcastFailing, Chain: "ethereum", DestDomain: 0, Resolved: true})
+	select {
+	case resolve := <-received:
+		require.Equal(t, "resolve", resolve.EventAction)
+		require.Equal(t, trigger.DedupKey, resolve.DedupKey)
+		require.Nil(t, resolve.Payload)
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolve event was not sent")
+	}
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var _ Notifier = (*SlackNotifier)(nil)
+
+// Slack alert events
+const (
+	SlackEventFailed            = AlertEventFailed
+	SlackEventReattestExhausted = AlertEventReattestExhausted
+	SlackEventLowBalance        = AlertEventLowBalance
+	SlackEventStuckPending      = AlertEventStuckPending
+	SlackEventUnknownAttester   = AlertEventUnknownAttester
+)
+
+// SlackNotifier posts alerts for failed relays and low balances to a Slack incoming webhook
+type SlackNotifier struct {
+	webhookURL     string
+	events         map[string]struct{}
+	explorerTxURLs map[types.Domain]string
+	client         *http.Client
+	logger         log.Logger
+}
+
+func NewSlackNotifier(cfg types.SlackConfig, logger log.Logger) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL:     cfg.WebhookURL,
+		events:         stringSet(cfg.Events, alertEvents...),
+		explorerTxURLs: cfg.ExplorerTxURLs,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		logger:         logger.With("component", "slack-notifier"),
+	}
+}
+
+// Notify posts the alert in the background if its event is enabled
+func (s *SlackNotifier) Notify(ctx context.Context, event Event) {
+	if _, ok := s.events[alertEvent(event)]; !ok {
+		return
+	}
+
+	go func() {
+		if err := s.post(context.WithoutCancel(ctx), s.format(event)); err != nil {
+			s.logger.Error("Failed to send Slack alert", "type", event.Type, "tx", event.SourceTxHash, "error", err)
+		}
+	}()
+}
+
+// format renders the event as Slack mrkdwn
+func (s *SlackNotifier) format(event Event) string {
+	if event.Type == EventLowBalance {
+		return fmt.Sprintf(":warning: *Low minter balance on %s*: %g %s is below the %g %s threshold",
+			event.Chain, event.Balance, event.Denom, event.Threshold, event.Denom)
+	}
+
+	title := ":x: *Relay failed*"
+	switch event.Type {
+	case EventReattestExhausted:
+		title = ":hourglass: *Relay failed, re-attestation retries exhausted*"
+	case EventStuckPending:
+		title = ":hourglass_flowing_sand: *Attestation stuck pending*"
+	case EventUnknownAttester:
+		title = ":lock: *Relay held, attestation signed by an unknown attester*"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s from domain %d to %d\n", title, event.SourceDomain, event.DestDomain)
+	fmt.Fprintf(&b, "Nonce: %d\n", event.Nonce)
+	if event.AmountFormatted != "" {
+		fmt.Fprintf(&b, "Amount: %s (%s)\n", event.AmountFormatted, event.Amount)
+	} else if event.Amount != "" {
+		fmt.Fprintf(&b, "Amount: %s\n", event.Amount)
+	}
+	fmt.Fprintf(&b, "Source tx: %s", s.txLink(event.SourceDomain, event.SourceTxHash))
+	return b.String()
+}
+
+// txLink links the tx hash to the explorer configured for its domain
+func (s *SlackNotifier) txLink(domain types.Domain, txHash string) string {
+	template, ok := s.explorerTxURLs[domain]
+	if !ok || template == "" {
+		return txHash
+	}
+	return fmt.Sprintf("<%s|%s>", strings.ReplaceAll(template, "{tx}", txHash), txHash)
+}
+
+func (s *SlackNotifier) post(ctx context.Context, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("unable to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestSlackNotifier verifies only enabled events are posted and tx hashes link to the explorer
+func TestSlackNotifier(t *testing.T) {
+	received := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if json.NewDecoder(r.Body).Decode(&payload) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- payload["text"]
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(types.SlackConfig{
+		WebhookURL:     server.URL,
+		Events:         []string{SlackEventReattestExhausted},
+		ExplorerTxURLs: map[types.Domain]string{0: "https://etherscan.io/tx/{tx}"},
+	}, log.NewNopLogger())
+
+	msg := &types.MessageState{Status: types.Failed, SourceDomain: 0, DestDomain: 4, Nonce: 7, SourceTxHash: "0xabc"}
+
+	// failed alerts are not enabled
+	notifier.Notify(context.Background(), NewEvent(msg))
+
+	event := NewEvent(msg)
+	event.Type = EventReattestExhausted
+	notifier.Notify(context.Background(), event)
+
+	select {
+	case text := <-received:
+		require.Contains(t, text, "re-attestation retries exhausted")
+		require.Contains(t, text, "<https://etherscan.io/tx/0xabc|0xabc>")
+	case <-time.After(5 * time.Second):
+		t.Fatal("slack webhook was not called")
+	}
+
+	select {
+	case text := <-received:
+		t.Fatalf("unexpected alert: %s", text)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestAlertEvent verifies events map to the chat alert they trigger
+func TestAlertEvent(t *testing.T) {
+	require.Equal(t, SlackEventFailed, alertEvent(Event{Type: EventStatus, Status: types.Failed}))
+	require.Empty(t, alertEvent(Event{Type: EventStatus, Status: types.Complete}))
+	require.Equal(t, SlackEventReattestExhausted, alertEvent(Event{Type: EventReattestExhausted, Status: types.Failed}))
+	require.Equal(t, SlackEventLowBalance, alertEvent(Event{Type: EventLowBalance}))
+	require.Equal(t, SlackEventStuckPending, alertEvent(Event{Type: EventStuckPending, Status: types.Pending}))
+	require.Equal(t, SlackEventUnknownAttester, alertEvent(Event{Type: EventUnknownAttester, Status: types.Attested}))
+}
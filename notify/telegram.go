@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var _ Notifier = (*TelegramNotifier)(nil)
+
+const defaultTelegramAPIURL = "https://api.telegram.org"
+
+// TelegramNotifier sends alerts for failed relays and low balances to a Telegram chat through a bot
+type TelegramNotifier struct {
+	// bot API sendMessage endpoint, includes the bot token
+	sendMessageURL string
+	chatID         string
+	events         map[string]struct{}
+	explorerTxURLs map[types.Domain]string
+	client         *http.Client
+	logger         log.Logger
+}
+
+func NewTelegramNotifier(cfg types.TelegramConfig, logger log.Logger) *TelegramNotifier {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultTelegramAPIURL
+	}
+
+	return &TelegramNotifier{
+		sendMessageURL: fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(apiURL, "/"), cfg.BotToken),
+		chatID:         cfg.ChatID,
+		events:         stringSet(cfg.Events, alertEvents...),
+		explorerTxURLs: cfg.ExplorerTxURLs,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		logger:         logger.With("component", "telegram-notifier"),
+	}
+}
+
+// Notify sends the alert in the background if its event is enabled
+func (t *TelegramNotifier) Notify(ctx context.Context, event Event) {
+	if _, ok := t.events[alertEvent(event)]; !ok {
+		return
+	}
+
+	go func() {
+		if err := t.send(context.WithoutCancel(ctx), t.format(event)); err != nil {
+			t.logger.Error("Failed to send Telegram alert", "type", event.Type, "tx", event.SourceTxHash, "error", err)
+		}
+	}()
+}
+
+// format renders the event as Telegram HTML
+func (t *TelegramNotifier) format(event Event) string {
+	if event.Type == EventLowBalance {
+		return fmt.Sprintf("⚠️ <b>Low minter balance on %s</b>: %g %s is below the %g %s threshold",
+			html.EscapeString(event.Chain), event.Balance, html.EscapeString(event.Denom), event.Threshold, html.EscapeString(event.Denom))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<b>%s</b> from domain %d to %d\n", alertTitle(event), event.SourceDomain, event.DestDomain)
+	fmt.Fprintf(&b, "Nonce: %d", event.Nonce)
+	if event.AmountFormatted != "" {
+		fmt.Fprintf(&b, ", amount: %s", html.EscapeString(event.AmountFormatted))
+	} else if event.Amount != "" {
+		fmt.Fprintf(&b, ", amount: %s", html.EscapeString(event.Amount))
+	}
+	txHash := html.EscapeString(event.SourceTxHash)
+	if url := explorerTxURL(t.explorerTxURLs, event.SourceDomain, event.SourceTxHash); url != "" {
+		fmt.Fprintf(&b, "\nSource tx: <a href=\"%s\">%s</a>", html.EscapeString(url), txHash)
+	} else {
+		fmt.Fprintf(&b, "\nSource tx: <code>%s</code>", txHash)
+	}
+	return b.String()
+}
+
+func (t *TelegramNotifier) send(ctx context.Context, text string) error {
+	body, err := json.Marshal(map[string]any{
+		"chat_id":                  t.chatID,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.sendMessageURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		// the url error includes the bot token, only report its cause
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("unable to send message: %w", urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var result struct {
+			Description string `json:"description"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, result.Description)
+	}
+	return nil
+}
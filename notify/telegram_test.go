@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestTelegramNotifier verifies alerts are sent to the configured chat through the bot's sendMessage endpoint
+func TestTelegramNotifier(t *testing.T) {
+	type message struct {
+		path   string
+		chatID string
+		text   string
+	}
+	received := make(chan message, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ChatID    string `json:"chat_id"`
+			Text      string `json:"text"`
+			ParseMode string `json:"parse_mode"`
+		}
+		if json.NewDecoder(r.Body).Decode(&payload) != nil || payload.ParseMode != "HTML" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- message{path: r.URL.Path, chatID: payload.ChatID, text: payload.Text}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	notifier := NewTelegramNotifier(types.TelegramConfig{
+		BotToken: "123:token",
+		ChatID:   "-10042",
+		APIURL:   server.URL + "/",
+	}, log.NewNopLogger())
+
+	msg := &types.MessageState{Status: types.Complete, SourceDomain: 4, DestDomain: 0, Nonce: 9, SourceTxHash: "0xdef"}
+
+	// completed relays don't alert
+	notifier.Notify(context.Background(), NewEvent(msg))
+
+	event := NewEvent(msg)
+	event.Type = EventReattestExhausted
+	notifier.Notify(context.Background(), event)
+
+	notifier.Notify(context.Background(), Event{Type: EventLowBalance, Chain: "ethereum", Balance: 0.05, Threshold: 0.1, Denom: "ETH"})
+
+	texts := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case m := <-received:
+			require.Equal(t, "/bot123:token/sendMessage", m.path)
+			require.Equal(t, "-10042", m.chatID)
+			texts[m.text] = true
+		case <-time.After(5 * time.Second):
+			t.Fatal("telegram bot api was not called")
+		}
+	}
+	require.True(t, texts["<b>Relay failed, re-attestation retries exhausted</b> from domain 4 to 0\nNonce: 9\nSource tx: <code>0xdef</code>"])
+	require.True(t, texts["⚠️ <b>Low minter balance on ethereum</b>: 0.05 ETH is below the 0.1 ETH threshold"])
+
+	select {
+	case m := <-received:
+		t.Fatalf("unexpected alert: %s", m.text)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var _ Notifier = (*WebhookNotifier)(nil)
+
+// WebhookNotifier POSTs events as JSON to a configured URL
+type WebhookNotifier struct {
+	url      string
+	headers  map[string]string
+	statuses map[string]struct{}
+	client   *http.Client
+	logger   log.Logger
+}
+
+func NewWebhookNotifier(cfg types.WebhookConfig, logger log.Logger) *WebhookNotifier {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 10
+	}
+
+	return &WebhookNotifier{
+		url:      cfg.URL,
+		headers:  cfg.Headers,
+		statuses: stringSet(cfg.Statuses, types.Complete, types.Failed),
+		client:   &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		logger:   logger.With("component", "webhook-notifier"),
+	}
+}
+
+// Notify sends message events in the background if their status is enabled
+func (w *WebhookNotifier) Notify(ctx context.Context, event Event) {
+	if event.Type == EventLowBalance {
+		return
+	}
+	if _, ok := w.statuses[event.Status]; !ok {
+		return
+	}
+
+	go func() {
+		if err := w.send(context.WithoutCancel(ctx), event); err != nil {
+			w.logger.Error("Failed to send webhook notification", "tx", event.SourceTxHash, "status", event.Status, "error", err)
+		}
+	}()
+}
+
+func (w *WebhookNotifier) send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("unable to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
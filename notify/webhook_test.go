@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestWebhookNotifier verifies enabled events are posted with the configured headers
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan Event, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if r.Header.Get("Authorization") != "secret" || json.NewDecoder(r.Body).Decode(&event) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(types.WebhookConfig{
+		URL:     server.URL,
+		Headers: map[string]string{"Authorization": "secret"},
+	}, log.NewNopLogger())
+
+	msg := &types.MessageState{Status: types.Pending, SourceDomain: 0, DestDomain: 4, Nonce: 7, SourceTxHash: "0xabc"}
+	notifier.Notify(context.Background(), NewEvent(msg))
+
+	msg.Status = types.Complete
+	msg.DestTxHash = "ABC"
+	notifier.Notify(context.Background(), NewEvent(msg))
+
+	select {
+	case event := <-received:
+		require.Equal(t, types.Complete, event.Status)
+		require.Equal(t, types.Domain(4), event.DestDomain)
+		require.Equal(t, uint64(7), event.Nonce)
+		require.Equal(t, "ABC", event.DestTxHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+
+	// pending is not enabled by default
+	select {
+	case event := <-received:
+		t.Fatalf("unexpected event with status %s", event.Status)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestNew verifies no notifier is created without a configured target
+func TestNew(t *testing.T) {
+	require.Nil(t, New(types.NotificationsConfig{}, log.NewNopLogger()))
+	require.NotNil(t, New(types.NotificationsConfig{Webhook: types.WebhookConfig{URL: "http://localhost"}}, log.NewNopLogger()))
+}
@@ -12,31 +12,114 @@ import (
 
 type PromMetrics struct {
 	WalletBalance         *prometheus.GaugeVec
+	WalletBalanceLow      *prometheus.GaugeVec
 	LatestHeight          *prometheus.GaugeVec
 	BroadcastErrors       *prometheus.CounterVec
 	FastTransferAllowance *prometheus.GaugeVec
 	AttestationTotal      *prometheus.CounterVec
 	AttestationPending    *prometheus.GaugeVec
+	ReorgsDetected        *prometheus.CounterVec
+	BroadcastGasPrice     *prometheus.HistogramVec
+	BroadcastBatchSize    *prometheus.HistogramVec
+	RelayLatency          *prometheus.HistogramVec
+	AttestationWait       *prometheus.HistogramVec
+	FilteredMessages      *prometheus.CounterVec
+	BlockTime             *prometheus.GaugeVec
+	ReattestTotal         *prometheus.CounterVec
+	ReattestFailures      *prometheus.CounterVec
+	FastTransferExpired   *prometheus.CounterVec
+	AttestationAPIRequest *prometheus.CounterVec
+	CircuitBreakerState   prometheus.Gauge
+	SequenceMismatches    *prometheus.CounterVec
+	DeadLetters           *prometheus.CounterVec
+	Forwards              *prometheus.CounterVec
+	RPCEndpointActive     *prometheus.GaugeVec
+	WebsocketReconnects   *prometheus.CounterVec
+	BackfilledMessages    *prometheus.CounterVec
+	MintedAmount          *prometheus.CounterVec
+	LastSuccessfulRelay   *prometheus.GaugeVec
+	CircleRequestDuration *prometheus.HistogramVec
+	CircleRequestErrors   *prometheus.CounterVec
+	StuckPending          *prometheus.GaugeVec
+	QueueEnqueueTimeout   *prometheus.CounterVec
+	BroadcastsInFlight    *prometheus.GaugeVec
+	BroadcastRateWait     *prometheus.GaugeVec
+	UsedNonceCacheHits    *prometheus.CounterVec
+	AttestationUnknown    *prometheus.CounterVec
+	UnparseableMessages   *prometheus.CounterVec
+	FilterDryRunMatches   *prometheus.CounterVec
+	StateTxs              *prometheus.GaugeVec
 }
 
+// InitPromMetrics registers the relayer metrics and exposes them on the /metrics HTTP endpoint
 func InitPromMetrics(address string, port int16) *PromMetrics {
 	reg := prometheus.NewRegistry()
+	m := NewPromMetrics(reg)
 
+	// Expose /metrics HTTP endpoint
+	go func() {
+		http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+		server := &http.Server{
+			Addr:        fmt.Sprintf("%s:%d", address, port),
+			ReadTimeout: 3 * time.Second,
+		}
+		log.Fatal(server.ListenAndServe())
+	}()
+
+	return m
+}
+
+// NewPromMetrics registers the relayer metrics with reg without exposing them
+func NewPromMetrics(reg prometheus.Registerer) *PromMetrics {
 	// labels
 	var (
 		walletLabels         = []string{"chain", "address", "denom"}
+		walletLowLabels      = []string{"chain", "address"}
 		heightLabels         = []string{"chain", "domain"}
+		blockTimeLabels      = []string{"chain", "domain"}
 		broadcastErrorLabels = []string{"chain", "domain"}
 		allowanceLabels      = []string{"domain", "token"}
 		attestationLabels    = []string{"status", "source_domain", "dest_domain"}
 		pendingLabels        = []string{"source_domain", "dest_domain"}
+		reorgLabels          = []string{"chain", "domain"}
+		gasPriceLabels       = []string{"chain", "domain"}
+		batchSizeLabels      = []string{"chain", "domain"}
+		latencyLabels        = []string{"source_domain", "dest_domain"}
+		filteredLabels       = []string{"filter_name", "source_domain", "dest_domain"}
+		reattestLabels       = []string{"source_domain"}
+		expiredLabels        = []string{"source_domain", "dest_domain"}
+		apiRequestLabels     = []string{"endpoint"}
+		sequenceLabels       = []string{"chain", "domain"}
+		deadLetterLabels     = []string{"source_domain", "dest_domain"}
+		forwardLabels        = []string{"source_domain", "channel"}
+		endpointLabels       = []string{"chain", "domain", "endpoint"}
+		reconnectLabels      = []string{"chain", "domain"}
+		backfillLabels       = []string{"chain", "domain"}
+		mintedLabels         = []string{"source_domain", "dest_domain", "token"}
+		lastRelayLabels      = []string{"source_domain", "dest_domain"}
+		circleRequestLabels  = []string{"endpoint_type", "status"}
+		stuckPendingLabels   = []string{"source_domain", "dest_domain"}
+		enqueueTimeoutLabels = []string{"chain", "domain"}
+		inFlightLabels       = []string{"chain", "domain"}
+		rateLimitLabels      = []string{"chain", "domain"}
+		nonceCacheLabels     = []string{"source_domain", "dest_domain"}
+		unknownStatusLabels  = []string{"status", "source_domain", "dest_domain"}
+		stateLabels          = []string{"store"}
+		unparseableLabels    = []string{"source_domain", "body_length"}
 	)
 
+	// 1s to ~68m, covers fast transfers as well as standard transfers waiting on finality
+	latencyBuckets := prometheus.ExponentialBuckets(1, 2, 13)
+
 	m := &PromMetrics{
 		WalletBalance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Name: "cctp_relayer_wallet_balance",
 			Help: "The current balance for a wallet",
 		}, walletLabels),
+		WalletBalanceLow: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_wallet_balance_low",
+			Help: "1 if a wallet balance is below the chain's min-balance-alert, 0 otherwise",
+		}, walletLowLabels),
 		LatestHeight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Name: "cctp_relayer_chain_latest_height",
 			Help: "The current height of the chain",
@@ -51,30 +134,181 @@ func InitPromMetrics(address string, port int16) *PromMetrics {
 		}, allowanceLabels),
 		AttestationTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Name: "cctp_relayer_attestation_total",
-			Help: "Attestation state transitions: observed, pending, complete, failed, filtered, minted",
+			Help: "Attestation state transitions: observed, pending, complete, failed, filtered, minted, already-minted",
 		}, attestationLabels),
 		AttestationPending: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Name: "cctp_relayer_attestation_pending",
 			Help: "Number of attestations currently pending",
 		}, pendingLabels),
+		ReorgsDetected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_reorgs_detected_total",
+			Help: "The total number of chain reorgs detected",
+		}, reorgLabels),
+		BroadcastGasPrice: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cctp_relayer_broadcast_gas_price_gwei",
+			Help:    "The max fee per gas used for each broadcast, in gwei",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 20),
+		}, gasPriceLabels),
+		BroadcastBatchSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cctp_relayer_broadcast_batch_size",
+			Help:    "The number of mints included in each broadcast tx",
+			Buckets: []float64{1, 2, 5, 10, 20, 50},
+		}, batchSizeLabels),
+		RelayLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cctp_relayer_relay_latency_seconds",
+			Help:    "Time from when a burn is observed until its mint completes",
+			Buckets: latencyBuckets,
+		}, latencyLabels),
+		AttestationWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cctp_relayer_attestation_wait_seconds",
+			Help:    "Time from when a burn is observed until its attestation is complete",
+			Buckets: latencyBuckets,
+		}, latencyLabels),
+		FilteredMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_filtered_messages_total",
+			Help: "The total number of messages dropped by each filter",
+		}, filteredLabels),
+		BlockTime: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_chain_block_time_seconds",
+			Help: "The rolling average block time of the chain",
+		}, blockTimeLabels),
+		ReattestTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_reattest_total",
+			Help: "The total number of Fast Transfer re-attestations requested",
+		}, reattestLabels),
+		ReattestFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_reattest_failures_total",
+			Help: "The total number of Fast Transfer re-attestation requests that failed",
+		}, reattestLabels),
+		FastTransferExpired: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_fast_transfer_expired_total",
+			Help: "The total number of Fast Transfer attestations that expired before they were broadcast or ran out of re-attestations",
+		}, expiredLabels),
+		AttestationAPIRequest: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_attestation_api_requests_total",
+			Help: "The total number of Circle attestation API requests served by each endpoint",
+		}, apiRequestLabels),
+		CircuitBreakerState: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "cctp_relayer_circle_circuit_breaker_state",
+			Help: "State of the Circle API circuit breaker: 0 closed, 1 half-open, 2 open",
+		}),
+		SequenceMismatches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_sequence_mismatch_recoveries_total",
+			Help: "The total number of account sequence mismatch broadcast errors recovered from by resyncing the sequence",
+		}, sequenceLabels),
+		DeadLetters: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_dead_letter_total",
+			Help: "The total number of messages moved to the dead-letter store after exhausting their retries",
+		}, deadLetterLabels),
+		Forwards: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_forwards_total",
+			Help: "The total number of mints to Noble forwarded over IBC",
+		}, forwardLabels),
+		RPCEndpointActive: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_rpc_endpoint_active",
+			Help: "Whether the rpc endpoint is the one a chain's requests are sent to, by scheme and host",
+		}, endpointLabels),
+		WebsocketReconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_websocket_reconnects_total",
+			Help: "The total number of times a chain's websocket disconnected and was reconnected",
+		}, reconnectLabels),
+		BackfilledMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_backfilled_messages_total",
+			Help: "The total number of messages found by a chain's listener scanning history on startup",
+		}, backfillLabels),
+		MintedAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_minted_amount_total",
+			Help: "The total amount of tokens minted, scaled by the token decimals",
+		}, mintedLabels),
+		LastSuccessfulRelay: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_last_successful_relay_timestamp_seconds",
+			Help: "Unix time of the last message that completed on a route",
+		}, lastRelayLabels),
+		CircleRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cctp_relayer_circle_request_duration_seconds",
+			Help:    "Duration of Circle API requests, by endpoint type and response status",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, circleRequestLabels),
+		CircleRequestErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_circle_request_errors_total",
+			Help: "The total number of Circle API requests that failed or got a non 200 response, by endpoint type and response status",
+		}, circleRequestLabels),
+		StuckPending: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_attestation_stuck_pending",
+			Help: "Number of attestations pending longer than circle.stuck-pending-threshold",
+		}, stuckPendingLabels),
+		QueueEnqueueTimeout: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_queue_enqueue_timeout_total",
+			Help: "The total number of txs a listener held back because the processing queue stayed full",
+		}, enqueueTimeoutLabels),
+		BroadcastsInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_broadcasts_in_flight",
+			Help: "Number of broadcasts currently in flight to a destination domain",
+		}, inFlightLabels),
+		BroadcastRateWait: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_broadcast_rate_limit_wait_seconds",
+			Help: "Seconds until the mints held by broadcast-rate-limits may be broadcast to a destination domain, 0 if the last batch was allowed",
+		}, rateLimitLabels),
+		UsedNonceCacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_used_nonce_cache_hits_total",
+			Help: "The total number of messages skipped because their nonce was cached as used, without querying the destination",
+		}, nonceCacheLabels),
+		AttestationUnknown: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_attestation_unknown_status_total",
+			Help: "The total number of attestation responses with a status the relayer doesn't handle",
+		}, unknownStatusLabels),
+		UnparseableMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_unparseable_messages_total",
+			Help: "The total number of source messages skipped because their message body isn't a supported format",
+		}, unparseableLabels),
+		FilterDryRunMatches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_filter_dry_run_matches_total",
+			Help: "The total number of messages a dry run filter would have filtered",
+		}, filteredLabels),
+		StateTxs: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_state_txs",
+			Help: "Number of txs held in the active state and in the archive of swept terminal txs",
+		}, stateLabels),
 	}
 
 	reg.MustRegister(m.WalletBalance)
+	reg.MustRegister(m.WalletBalanceLow)
 	reg.MustRegister(m.LatestHeight)
 	reg.MustRegister(m.BroadcastErrors)
 	reg.MustRegister(m.FastTransferAllowance)
 	reg.MustRegister(m.AttestationTotal)
 	reg.MustRegister(m.AttestationPending)
-
-	// Expose /metrics HTTP endpoint
-	go func() {
-		http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
-		server := &http.Server{
-			Addr:        fmt.Sprintf("%s:%d", address, port),
-			ReadTimeout: 3 * time.Second,
-		}
-		log.Fatal(server.ListenAndServe())
-	}()
+	reg.MustRegister(m.ReorgsDetected)
+	reg.MustRegister(m.BroadcastGasPrice)
+	reg.MustRegister(m.BroadcastBatchSize)
+	reg.MustRegister(m.RelayLatency)
+	reg.MustRegister(m.AttestationWait)
+	reg.MustRegister(m.FilteredMessages)
+	reg.MustRegister(m.BlockTime)
+	reg.MustRegister(m.ReattestTotal)
+	reg.MustRegister(m.ReattestFailures)
+	reg.MustRegister(m.FastTransferExpired)
+	reg.MustRegister(m.AttestationAPIRequest)
+	reg.MustRegister(m.CircuitBreakerState)
+	reg.MustRegister(m.SequenceMismatches)
+	reg.MustRegister(m.DeadLetters)
+	reg.MustRegister(m.Forwards)
+	reg.MustRegister(m.RPCEndpointActive)
+	reg.MustRegister(m.WebsocketReconnects)
+	reg.MustRegister(m.BackfilledMessages)
+	reg.MustRegister(m.MintedAmount)
+	reg.MustRegister(m.LastSuccessfulRelay)
+	reg.MustRegister(m.CircleRequestDuration)
+	reg.MustRegister(m.CircleRequestErrors)
+	reg.MustRegister(m.StuckPending)
+	reg.MustRegister(m.QueueEnqueueTimeout)
+	reg.MustRegister(m.BroadcastsInFlight)
+	reg.MustRegister(m.BroadcastRateWait)
+	reg.MustRegister(m.UsedNonceCacheHits)
+	reg.MustRegister(m.AttestationUnknown)
+	reg.MustRegister(m.UnparseableMessages)
+	reg.MustRegister(m.FilterDryRunMatches)
+	reg.MustRegister(m.StateTxs)
 
 	return m
 }
@@ -83,10 +317,22 @@ func (m *PromMetrics) SetWalletBalance(chain, address, denom string, balance flo
 	m.WalletBalance.WithLabelValues(chain, address, denom).Set(balance)
 }
 
+func (m *PromMetrics) SetWalletBalanceLow(chain, address string, low bool) {
+	value := 0.0
+	if low {
+		value = 1
+	}
+	m.WalletBalanceLow.WithLabelValues(chain, address).Set(value)
+}
+
 func (m *PromMetrics) SetLatestHeight(chain, domain string, height int64) {
 	m.LatestHeight.WithLabelValues(chain, domain).Set(float64(height))
 }
 
+func (m *PromMetrics) SetBlockTime(chain, domain string, blockTime time.Duration) {
+	m.BlockTime.WithLabelValues(chain, domain).Set(blockTime.Seconds())
+}
+
 func (m *PromMetrics) IncBroadcastErrors(chain, domain string) {
 	m.BroadcastErrors.WithLabelValues(chain, domain).Inc()
 }
@@ -106,3 +352,131 @@ func (m *PromMetrics) IncPending(srcDomain, destDomain string) {
 func (m *PromMetrics) DecPending(srcDomain, destDomain string) {
 	m.AttestationPending.WithLabelValues(srcDomain, destDomain).Dec()
 }
+
+func (m *PromMetrics) IncReorgs(chain, domain string) {
+	m.ReorgsDetected.WithLabelValues(chain, domain).Inc()
+}
+
+func (m *PromMetrics) ObserveBroadcastGasPrice(chain, domain string, gwei float64) {
+	m.BroadcastGasPrice.WithLabelValues(chain, domain).Observe(gwei)
+}
+
+func (m *PromMetrics) ObserveBroadcastBatchSize(chain, domain string, size int) {
+	m.BroadcastBatchSize.WithLabelValues(chain, domain).Observe(float64(size))
+}
+
+func (m *PromMetrics) ObserveRelayLatency(srcDomain, destDomain string, latency time.Duration) {
+	m.RelayLatency.WithLabelValues(srcDomain, destDomain).Observe(latency.Seconds())
+}
+
+func (m *PromMetrics) ObserveAttestationWait(srcDomain, destDomain string, wait time.Duration) {
+	m.AttestationWait.WithLabelValues(srcDomain, destDomain).Observe(wait.Seconds())
+}
+
+func (m *PromMetrics) IncFilteredMessages(filterName, srcDomain, destDomain string) {
+	m.FilteredMessages.WithLabelValues(filterName, srcDomain, destDomain).Inc()
+}
+
+func (m *PromMetrics) IncReattest(srcDomain string) {
+	m.ReattestTotal.WithLabelValues(srcDomain).Inc()
+}
+
+func (m *PromMetrics) IncReattestFailures(srcDomain string) {
+	m.ReattestFailures.WithLabelValues(srcDomain).Inc()
+}
+
+func (m *PromMetrics) IncFastTransferExpired(srcDomain, destDomain string) {
+	m.FastTransferExpired.WithLabelValues(srcDomain, destDomain).Inc()
+}
+
+func (m *PromMetrics) IncAttestationAPIRequests(endpoint string) {
+	m.AttestationAPIRequest.WithLabelValues(endpoint).Inc()
+}
+
+func (m *PromMetrics) SetCircuitBreakerState(state int) {
+	m.CircuitBreakerState.Set(float64(state))
+}
+
+func (m *PromMetrics) IncSequenceMismatches(chain, domain string) {
+	m.SequenceMismatches.WithLabelValues(chain, domain).Inc()
+}
+
+func (m *PromMetrics) IncDeadLetters(srcDomain, destDomain string) {
+	m.DeadLetters.WithLabelValues(srcDomain, destDomain).Inc()
+}
+
+func (m *PromMetrics) IncForwards(srcDomain, channel string) {
+	m.Forwards.WithLabelValues(srcDomain, channel).Inc()
+}
+
+func (m *PromMetrics) SetRPCEndpointActive(chain, domain, endpoint string, active bool) {
+	value := 0.0
+	if active {
+		value = 1
+	}
+	m.RPCEndpointActive.WithLabelValues(chain, domain, endpoint).Set(value)
+}
+
+func (m *PromMetrics) IncWebsocketReconnects(chain, domain string) {
+	m.WebsocketReconnects.WithLabelValues(chain, domain).Inc()
+}
+
+func (m *PromMetrics) AddBackfilledMessages(chain, domain string, count int) {
+	m.BackfilledMessages.WithLabelValues(chain, domain).Add(float64(count))
+}
+
+func (m *PromMetrics) AddMintedAmount(srcDomain, destDomain, token string, amount float64) {
+	m.MintedAmount.WithLabelValues(srcDomain, destDomain, token).Add(amount)
+}
+
+func (m *PromMetrics) SetLastSuccessfulRelay(srcDomain, destDomain string, completed time.Time) {
+	m.LastSuccessfulRelay.WithLabelValues(srcDomain, destDomain).Set(float64(completed.Unix()))
+}
+
+func (m *PromMetrics) ObserveCircleRequest(endpointType, status string, duration time.Duration) {
+	m.CircleRequestDuration.WithLabelValues(endpointType, status).Observe(duration.Seconds())
+}
+
+func (m *PromMetrics) IncCircleRequestErrors(endpointType, status string) {
+	m.CircleRequestErrors.WithLabelValues(endpointType, status).Inc()
+}
+
+func (m *PromMetrics) IncStuckPending(srcDomain, destDomain string) {
+	m.StuckPending.WithLabelValues(srcDomain, destDomain).Inc()
+}
+
+func (m *PromMetrics) DecStuckPending(srcDomain, destDomain string) {
+	m.StuckPending.WithLabelValues(srcDomain, destDomain).Dec()
+}
+
+func (m *PromMetrics) IncQueueEnqueueTimeout(chain, domain string) {
+	m.QueueEnqueueTimeout.WithLabelValues(chain, domain).Inc()
+}
+
+func (m *PromMetrics) SetBroadcastsInFlight(chain, domain string, inFlight int) {
+	m.BroadcastsInFlight.WithLabelValues(chain, domain).Set(float64(inFlight))
+}
+
+func (m *PromMetrics) SetBroadcastRateLimitWait(chain, domain string, wait time.Duration) {
+	m.BroadcastRateWait.WithLabelValues(chain, domain).Set(wait.Seconds())
+}
+
+func (m *PromMetrics) IncUsedNonceCacheHits(srcDomain, destDomain string) {
+	m.UsedNonceCacheHits.WithLabelValues(srcDomain, destDomain).Inc()
+}
+
+func (m *PromMetrics) IncAttestationUnknownStatus(status, srcDomain, destDomain string) {
+	m.AttestationUnknown.WithLabelValues(status, srcDomain, destDomain).Inc()
+}
+
+func (m *PromMetrics) IncUnparseableMessages(srcDomain, bodyLength string) {
+	m.UnparseableMessages.WithLabelValues(srcDomain, bodyLength).Inc()
+}
+
+func (m *PromMetrics) IncFilterDryRunMatches(filterName, srcDomain, destDomain string) {
+	m.FilterDryRunMatches.WithLabelValues(filterName, srcDomain, destDomain).Inc()
+}
+
+func (m *PromMetrics) SetStateTxs(store string, count int) {
+	m.StateTxs.WithLabelValues(store).Set(float64(count))
+}
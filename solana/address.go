@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/gagliardetto/solana-go"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
 // HexToSolanaPublicKey converts EVM-style 32-byte hex addresses to Solana PublicKeys for cross-chain CCTP compatibility
@@ -32,3 +34,23 @@ func BytesToSolanaPublicKey(addrBytes []byte) (solana.PublicKey, error) {
 
 	return solana.PublicKeyFromBytes(addrBytes), nil
 }
+
+// ParseTokenMints converts a burn token -> mint mapping from config into lookup keys matching the
+// 32-byte burn token in CCTP burn messages. EVM burn token addresses are left-padded to 32 bytes.
+func ParseTokenMints(tokenMints map[string]string) (map[string]solana.PublicKey, error) {
+	parsed := make(map[string]solana.PublicKey, len(tokenMints))
+	for burnToken, mint := range tokenMints {
+		padded, err := types.ParseBurnToken(burnToken)
+		if err != nil {
+			return nil, fmt.Errorf("invalid burn token %s: %w", burnToken, err)
+		}
+
+		mintKey, err := solana.PublicKeyFromBase58(mint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mint %s for burn token %s: %w", mint, burnToken, err)
+		}
+
+		parsed[hex.EncodeToString(padded)] = mintKey
+	}
+	return parsed, nil
+}
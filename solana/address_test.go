@@ -0,0 +1,83 @@
+package solana
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseTokenMints verifies burn tokens are normalized to padded lowercase hex keys
+func TestParseTokenMints(t *testing.T) {
+	eurcMint := "HzwqbKZw8HxMN6bF2yFZNrht3c2iXXzpKcFu7uBEDKtr"
+	eurcKey := "0000000000000000000000001abaea1f7c830bd89acc67ec4af516284b1bc33c"
+
+	tests := []struct {
+		name      string
+		burnToken string
+		mint      string
+		wantKey   string
+		wantErr   string
+	}{
+		{
+			name:      "0x prefixed evm token is left padded",
+			burnToken: "0x1aBaEA1f7C830bD89Acc67eC4af516284b1bC33c",
+			mint:      eurcMint,
+			wantKey:   eurcKey,
+		},
+		{
+			name:      "upper case 0X prefix",
+			burnToken: "0X1ABAEA1F7C830BD89ACC67EC4AF516284B1BC33C",
+			mint:      eurcMint,
+			wantKey:   eurcKey,
+		},
+		{
+			name:      "unprefixed evm token is left padded",
+			burnToken: "1abaea1f7c830bd89acc67ec4af516284b1bc33c",
+			mint:      eurcMint,
+			wantKey:   eurcKey,
+		},
+		{
+			name:      "32 byte token is kept as is",
+			burnToken: "0x" + eurcKey,
+			mint:      eurcMint,
+			wantKey:   eurcKey,
+		},
+		{
+			name:      "invalid hex",
+			burnToken: "0xnothex",
+			mint:      eurcMint,
+			wantErr:   "invalid burn token",
+		},
+		{
+			name:      "token longer than 32 bytes",
+			burnToken: "0x00" + eurcKey,
+			mint:      eurcMint,
+			wantErr:   "invalid burn token",
+		},
+		{
+			name:      "invalid mint",
+			burnToken: "0x1abaea1f7c830bd89acc67ec4af516284b1bc33c",
+			mint:      "not-base58!",
+			wantErr:   "invalid mint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseTokenMints(map[string]string{tt.burnToken: tt.mint})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, parsed, 1)
+			require.Equal(t, solana.MustPublicKeyFromBase58(tt.mint), parsed[tt.wantKey])
+
+			padded, err := hex.DecodeString(tt.wantKey)
+			require.NoError(t, err)
+			require.Equal(t, parsed[tt.wantKey], ResolveLocalTokenMint(padded, parsed, USDCMintMainnet))
+		})
+	}
+}
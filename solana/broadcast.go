@@ -2,12 +2,16 @@ package solana
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
 	"github.com/gagliardetto/solana-go/rpc"
 
 	"cosmossdk.io/log"
@@ -29,35 +33,115 @@ func (s *Solana) Broadcast(
 
 MsgLoop:
 	for _, msg := range msgs {
-		attestationBytes, err := hex.DecodeString(msg.Attestation[2:])
+		msgLogger := types.MessageLogger(logger, msg)
+
+		attestation, err := types.NormalizeAttestation(msg.Attestation)
+		if err != nil {
+			return fmt.Errorf("invalid message attestation: %w", err)
+		}
+		attestationBytes, err := types.DecodeAttestation(attestation)
 		if err != nil {
-			return errors.New("unable to decode message attestation")
+			return fmt.Errorf("unable to decode message attestation: %w", err)
 		}
 
+		var lastErr error
 		for attempt := 0; attempt <= s.maxRetries; attempt++ {
-			if msg.Status == types.Complete {
+			if msg.Status == types.Complete || msg.Status == types.AlreadyMinted {
 				continue MsgLoop
 			}
 
-			if err := s.attemptBroadcast(ctx, logger, msg, attestationBytes); err == nil {
+			if lastErr = s.attemptBroadcast(ctx, msgLogger, msg, attestationBytes); lastErr == nil {
 				continue MsgLoop
 			}
 
 			if attempt != s.maxRetries {
-				logger.Info(fmt.Sprintf("Retrying in %d seconds", s.retryIntervalSeconds))
-				time.Sleep(time.Duration(s.retryIntervalSeconds) * time.Second)
+				backoff := withJitter(broadcastBackoff(s.retryInterval(), s.maxRetryInterval(), attempt))
+				msgLogger.Info("Retrying broadcast", "attempt", attempt+1, "retry_in", backoff.Round(time.Millisecond), "reason", broadcastFailureReason(lastErr))
+				select {
+				case <-ctx.Done():
+					return errors.Join(broadcastErrors, ctx.Err())
+				case <-time.After(backoff):
+				}
 			}
 		}
 
+		reason := broadcastFailureReason(lastErr)
+		msgLogger.Error("Reached max number of broadcast attempts", "attempts", s.maxRetries+1, "reason", reason, "error", lastErr)
 		if m != nil {
 			m.IncBroadcastErrors(s.name, fmt.Sprint(s.domain))
 		}
-		broadcastErrors = errors.Join(broadcastErrors, errors.New("reached max number of broadcast attempts"))
+		broadcastErrors = errors.Join(broadcastErrors, fmt.Errorf("reached max number of broadcast attempts, %s: %w", reason, lastErr))
 	}
 
 	return broadcastErrors
 }
 
+const (
+	// defaultMaxRetryInterval caps the doubling broadcast retry interval without a broadcast-max-retry-interval
+	defaultMaxRetryInterval = time.Minute
+
+	failureBlockhashExpired = "blockhash expired"
+	failureAccount          = "account error"
+	failureRPCTimeout       = "rpc timeout"
+	failureOther            = "broadcast error"
+)
+
+// errInvalidUserTokenAccount is returned when the mint recipient's token account can't be minted to
+var errInvalidUserTokenAccount = errors.New("invalid user token account")
+
+// retryInterval returns the delay before the first broadcast retry
+func (s *Solana) retryInterval() time.Duration {
+	return time.Duration(s.retryIntervalSeconds) * time.Second
+}
+
+// maxRetryInterval returns the cap of the broadcast retry interval, never below the first retry interval
+func (s *Solana) maxRetryInterval() time.Duration {
+	maxInterval := defaultMaxRetryInterval
+	if s.maxRetryIntervalSeconds > 0 {
+		maxInterval = time.Duration(s.maxRetryIntervalSeconds) * time.Second
+	}
+	return max(maxInterval, s.retryInterval())
+}
+
+// broadcastBackoff returns the delay before retry attempt+1: the base interval doubled for every earlier attempt, up to maxInterval
+func broadcastBackoff(base, maxInterval time.Duration, attempt int) time.Duration {
+	backoff := base
+	for i := 0; i < attempt && backoff < maxInterval; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxInterval)
+}
+
+// withJitter returns a random delay between half of d and d, so retries of concurrent mints don't run in lockstep
+func withJitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// broadcastFailureReason classifies a failed broadcast for operators: an expired blockhash, a missing or invalid
+// account, an rpc timeout or any other broadcast error
+func broadcastFailureReason(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var netErr net.Error
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "BlockhashNotFound") || strings.Contains(msg, "Blockhash not found") || strings.Contains(msg, "block height exceeded"):
+		return failureBlockhashExpired
+	case errors.Is(err, errInvalidUserTokenAccount) || strings.Contains(msg, "AccountNotFound") || strings.Contains(msg, "InvalidAccountData"):
+		return failureAccount
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		return failureRPCTimeout
+	default:
+		return failureOther
+	}
+}
+
 func (s *Solana) attemptBroadcast(
 	ctx context.Context,
 	logger log.Logger,
@@ -67,13 +151,13 @@ func (s *Solana) attemptBroadcast(
 	logger.Info(fmt.Sprintf("Broadcasting message from %d to %d: with source tx hash %s",
 		msg.SourceDomain, msg.DestDomain, msg.SourceTxHash))
 
-	accounts, err := DeriveCCTPAccounts(msg, s.messageTransmitterProgram, s.tokenMessengerMinterProgram, s.localTokenMint)
+	accounts, err := DeriveCCTPAccounts(msg, s.messageTransmitterProgram, s.tokenMessengerMinterProgram, s.tokenMints, s.localTokenMint)
 	if err != nil {
 		return fmt.Errorf("failed to derive CCTP accounts: %w", err)
 	}
 
 	if err := s.validateUserTokenAccount(ctx, accounts.UserTokenAccount); err != nil {
-		return fmt.Errorf("invalid user token account: %w", err)
+		return fmt.Errorf("%w: %w", errInvalidUserTokenAccount, err)
 	}
 
 	instruction, err := s.buildReceiveMessageInstruction(msg.MsgSentBytes, attestationBytes, accounts)
@@ -81,16 +165,12 @@ func (s *Solana) attemptBroadcast(
 		return fmt.Errorf("failed to build instruction: %w", err)
 	}
 
-	recent, err := s.rpcClient.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
+	recent, err := s.rpcClient.GetLatestBlockhash(ctx, s.commitment)
 	if err != nil {
 		return fmt.Errorf("failed to get recent blockhash: %w", err)
 	}
 
-	tx, err := solana.NewTransaction(
-		[]solana.Instruction{instruction},
-		recent.Value.Blockhash,
-		solana.TransactionPayer(s.minterAddress),
-	)
+	tx, err := s.newTransaction(instruction, recent.Value.Blockhash)
 	if err != nil {
 		return fmt.Errorf("failed to create transaction: %w", err)
 	}
@@ -99,6 +179,9 @@ func (s *Solana) attemptBroadcast(
 		if key.Equals(s.minterAddress) {
 			return &s.privateKey
 		}
+		if s.feePayer != nil && key.Equals(s.feePayer.PublicKey()) {
+			return &s.feePayer
+		}
 		return nil
 	})
 	if err != nil {
@@ -107,7 +190,7 @@ func (s *Solana) attemptBroadcast(
 
 	sig, err := s.rpcClient.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
 		SkipPreflight:       false,
-		PreflightCommitment: rpc.CommitmentFinalized,
+		PreflightCommitment: s.commitment,
 	})
 	if err != nil {
 		logger.Error(fmt.Sprintf("error during broadcast: %s", err.Error()))
@@ -116,11 +199,42 @@ func (s *Solana) attemptBroadcast(
 
 	msg.Status = types.Complete
 	msg.DestTxHash = sig.String()
+	s.pendingMints.Track(msg.DestTxHash, msg)
 
 	logger.Info(fmt.Sprintf("Successfully broadcast %s to Solana. Tx signature: %s", msg.SourceTxHash, msg.DestTxHash))
 	return nil
 }
 
+// newTransaction builds the mint transaction paid by the fee payer, or the minter without one. With an address lookup
+// table configured it is a v0 transaction referencing the accounts in the table by index, otherwise a legacy
+// transaction.
+func (s *Solana) newTransaction(instruction solana.Instruction, blockhash solana.Hash) (*solana.Transaction, error) {
+	opts := []solana.TransactionOption{solana.TransactionPayer(s.payer())}
+	if len(s.lookupTableAddresses) > 0 {
+		opts = append(opts, solana.TransactionAddressTables(map[solana.PublicKey]solana.PublicKeySlice{
+			s.addressLookupTable: s.lookupTableAddresses,
+		}))
+	}
+	return solana.NewTransaction([]solana.Instruction{instruction}, blockhash, opts...)
+}
+
+// loadAddressLookupTable fetches the addresses of the configured address lookup table. A deactivated table can't
+// be used in transactions.
+func (s *Solana) loadAddressLookupTable(ctx context.Context) error {
+	table, err := addresslookuptable.GetAddressLookupTable(ctx, s.rpcClient, s.addressLookupTable)
+	if err != nil {
+		return fmt.Errorf("unable to fetch address lookup table %s: %w", s.addressLookupTable, err)
+	}
+	if table.DeactivationSlot != math.MaxUint64 {
+		return fmt.Errorf("address lookup table %s is deactivated", s.addressLookupTable)
+	}
+	if len(table.Addresses) == 0 {
+		return fmt.Errorf("address lookup table %s is empty", s.addressLookupTable)
+	}
+	s.lookupTableAddresses = table.Addresses
+	return nil
+}
+
 // validateUserTokenAccount verifies the mint recipient account exists
 func (s *Solana) validateUserTokenAccount(ctx context.Context, userTokenAccount solana.PublicKey) error {
 	accountInfo, err := s.rpcClient.GetAccountInfo(ctx, userTokenAccount)
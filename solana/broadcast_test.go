@@ -0,0 +1,96 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewTransaction verifies mints are v0 transactions looking up the static accounts in the address lookup table
+// when one is configured, and legacy transactions otherwise
+func TestNewTransaction(t *testing.T) {
+	minter := solana.NewWallet().PublicKey()
+	program := solana.NewWallet().PublicKey()
+	static := solana.NewWallet().PublicKey()
+	userTokenAccount := solana.NewWallet().PublicKey()
+
+	instruction := solana.NewInstruction(program, solana.AccountMetaSlice{
+		solana.Meta(minter).SIGNER().WRITE(),
+		solana.Meta(static),
+		solana.Meta(userTokenAccount).WRITE(),
+	}, []byte{1})
+
+	s := &Solana{minterAddress: minter}
+	tx, err := s.newTransaction(instruction, solana.Hash{})
+	require.NoError(t, err)
+	require.False(t, tx.Message.IsVersioned())
+	require.Len(t, tx.Message.AccountKeys, 4)
+
+	s.addressLookupTable = solana.NewWallet().PublicKey()
+	s.lookupTableAddresses = solana.PublicKeySlice{static, program}
+	tx, err = s.newTransaction(instruction, solana.Hash{})
+	require.NoError(t, err)
+	require.True(t, tx.Message.IsVersioned())
+	require.Equal(t, 1, tx.Message.NumLookups())
+	require.Equal(t, s.addressLookupTable, tx.Message.AddressTableLookups[0].AccountKey)
+	require.NotContains(t, tx.Message.AccountKeys, static)
+	require.Contains(t, tx.Message.AccountKeys, minter)
+}
+
+// TestNewTransactionFeePayer verifies a configured fee payer pays for and co-signs the mint while the minter stays
+// the signing caller
+func TestNewTransactionFeePayer(t *testing.T) {
+	minter := solana.NewWallet().PublicKey()
+	program := solana.NewWallet().PublicKey()
+
+	instruction := solana.NewInstruction(program, solana.AccountMetaSlice{
+		solana.Meta(minter).SIGNER(),
+	}, []byte{1})
+
+	s := &Solana{minterAddress: minter}
+	tx, err := s.newTransaction(instruction, solana.Hash{})
+	require.NoError(t, err)
+	require.Equal(t, minter, tx.Message.AccountKeys[0])
+	require.EqualValues(t, 1, tx.Message.Header.NumRequiredSignatures)
+
+	s.feePayer = solana.NewWallet().PrivateKey
+	tx, err = s.newTransaction(instruction, solana.Hash{})
+	require.NoError(t, err)
+	require.Equal(t, s.feePayer.PublicKey(), tx.Message.AccountKeys[0])
+	require.Contains(t, tx.Message.AccountKeys, minter)
+	require.EqualValues(t, 2, tx.Message.Header.NumRequiredSignatures)
+}
+
+func TestBroadcastBackoff(t *testing.T) {
+	require.Equal(t, 2*time.Second, broadcastBackoff(2*time.Second, time.Minute, 0))
+	require.Equal(t, 4*time.Second, broadcastBackoff(2*time.Second, time.Minute, 1))
+	require.Equal(t, 32*time.Second, broadcastBackoff(2*time.Second, time.Minute, 4))
+	require.Equal(t, time.Minute, broadcastBackoff(2*time.Second, time.Minute, 5))
+	require.Equal(t, time.Minute, broadcastBackoff(2*time.Second, time.Minute, 100))
+
+	for i := 0; i < 100; i++ {
+		d := withJitter(10 * time.Second)
+		require.GreaterOrEqual(t, d, 5*time.Second)
+		require.LessOrEqual(t, d, 10*time.Second)
+	}
+	require.Zero(t, withJitter(0))
+
+	// the cap never drops below the first retry interval
+	s := &Solana{retryIntervalSeconds: 90}
+	require.Equal(t, 90*time.Second, s.maxRetryInterval())
+	s = &Solana{retryIntervalSeconds: 5, maxRetryIntervalSeconds: 30}
+	require.Equal(t, 30*time.Second, s.maxRetryInterval())
+}
+
+func TestBroadcastFailureReason(t *testing.T) {
+	require.Equal(t, failureBlockhashExpired, broadcastFailureReason(errors.New("Transaction simulation failed: Blockhash not found")))
+	require.Equal(t, failureAccount, broadcastFailureReason(fmt.Errorf("%w: %w", errInvalidUserTokenAccount, errors.New("not found"))))
+	require.Equal(t, failureAccount, broadcastFailureReason(errors.New("AccountNotFound")))
+	require.Equal(t, failureRPCTimeout, broadcastFailureReason(fmt.Errorf("failed to get recent blockhash: %w", context.DeadlineExceeded)))
+	require.Equal(t, failureOther, broadcastFailureReason(errors.New("custom program error: 0x1")))
+}
@@ -5,12 +5,15 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"net/http"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
 
 	"cosmossdk.io/log"
 
@@ -19,6 +22,9 @@ import (
 )
 
 var _ types.Chain = (*Solana)(nil)
+var _ types.RecipientValidator = (*Solana)(nil)
+var _ types.BalanceReporter = (*Solana)(nil)
+var _ types.Resumer = (*Solana)(nil)
 
 type Solana struct {
 	name                        string
@@ -31,11 +37,17 @@ type Solana struct {
 	lookbackPeriod              uint64
 	privateKey                  solana.PrivateKey
 	minterAddress               solana.PublicKey
+	feePayer                    solana.PrivateKey // pays the transaction fees, nil for the minter to pay
 	maxRetries                  int
 	retryIntervalSeconds        int
+	maxRetryIntervalSeconds     int
 	minAmount                   uint64
 	MetricsDenom                string
 	MetricsExponent             int
+	minBalanceAlert             uint64
+	commitment                  rpc.CommitmentType // commitment of broadcasts and chain tracking, burns are read at finalized
+	rpcTimeout                  time.Duration      // zero uses the rpc client default
+	rpcFailover                 *types.RPCFailover // nil without rpc fallbacks
 
 	mu sync.Mutex
 
@@ -43,10 +55,21 @@ type Solana struct {
 
 	messageTransmitterProgram   solana.PublicKey
 	tokenMessengerMinterProgram solana.PublicKey
-	localTokenMint              solana.PublicKey // USDC mint on Solana
+	localTokenMint              solana.PublicKey            // default (USDC) mint on Solana
+	tokenMints                  map[string]solana.PublicKey // remote burn token (32-byte hex) -> Solana mint
+
+	recipients recipientCache
+
+	// broadcast mints waiting for their signature to confirm
+	pendingMints *types.PendingMints
+
+	// lookup table of the v0 transactions mints are broadcast in, zero to broadcast legacy transactions
+	addressLookupTable   solana.PublicKey
+	lookupTableAddresses solana.PublicKeySlice
 
 	latestBlock      uint64
 	lastFlushedBlock uint64
+	blockTime        types.BlockTimeTracker
 }
 
 func NewChain(
@@ -64,6 +87,15 @@ func NewChain(
 	minAmount uint64,
 	metricsDenom string,
 	metricsExponent int,
+	tokenMints map[string]string,
+	localTokenMint string,
+	minBalanceAlert uint64,
+	addressLookupTable string,
+	commitment string,
+	rpcTimeoutSeconds int,
+	feePayerPrivateKeyBase58 string,
+	rpcFallbacks []string,
+	maxRetryIntervalSeconds int,
 ) (*Solana, error) {
 	privKey, err := solana.PrivateKeyFromBase58(privateKeyBase58)
 	if err != nil {
@@ -82,15 +114,48 @@ func NewChain(
 		return nil, fmt.Errorf("unable to parse token messenger minter program address: %w", err)
 	}
 
-	// Default to mainnet USDC with SOLANA_USDC_MINT env var override
-	localTokenMint := USDCMintMainnet
-	if customMint := os.Getenv("SOLANA_USDC_MINT"); customMint != "" {
-		localTokenMint, err = solana.PublicKeyFromBase58(customMint)
+	defaultTokenMint, err := parseLocalTokenMint(localTokenMint, os.Getenv("SOLANA_USDC_MINT"))
+	if err != nil {
+		return nil, err
+	}
+
+	parsedTokenMints, err := ParseTokenMints(tokenMints)
+	if err != nil {
+		return nil, err
+	}
+
+	var lookupTable solana.PublicKey
+	if addressLookupTable != "" {
+		lookupTable, err = solana.PublicKeyFromBase58(addressLookupTable)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse address lookup table: %w", err)
+		}
+	}
+
+	var feePayer solana.PrivateKey
+	if feePayerPrivateKeyBase58 != "" {
+		feePayer, err = solana.PrivateKeyFromBase58(feePayerPrivateKeyBase58)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse SOLANA_USDC_MINT: %w", err)
+			return nil, fmt.Errorf("unable to parse Solana fee payer private key: %w", err)
+		}
+	}
+
+	var rpcFailover *types.RPCFailover
+	if len(rpcFallbacks) > 0 {
+		if rpcFailover, err = types.NewRPCFailover(rpcURL, rpcFallbacks, nil); err != nil {
+			return nil, err
 		}
 	}
 
+	parsedCommitment, err := parseCommitment(commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	if rpcTimeoutSeconds < 0 {
+		return nil, fmt.Errorf("rpc-timeout-seconds must not be negative, got %d", rpcTimeoutSeconds)
+	}
+
 	return &Solana{
 		name:                        name,
 		domain:                      domain,
@@ -102,17 +167,59 @@ func NewChain(
 		lookbackPeriod:              lookbackPeriod,
 		privateKey:                  privKey,
 		minterAddress:               minterAddress,
+		feePayer:                    feePayer,
 		maxRetries:                  maxRetries,
 		retryIntervalSeconds:        retryIntervalSeconds,
+		maxRetryIntervalSeconds:     maxRetryIntervalSeconds,
 		minAmount:                   minAmount,
 		MetricsDenom:                metricsDenom,
 		MetricsExponent:             metricsExponent,
+		minBalanceAlert:             minBalanceAlert,
 		messageTransmitterProgram:   messageTransmitterProgram,
 		tokenMessengerMinterProgram: tokenMessengerMinterProgram,
-		localTokenMint:              localTokenMint,
+		localTokenMint:              defaultTokenMint,
+		tokenMints:                  parsedTokenMints,
+		addressLookupTable:          lookupTable,
+		commitment:                  parsedCommitment,
+		rpcTimeout:                  time.Duration(rpcTimeoutSeconds) * time.Second,
+		rpcFailover:                 rpcFailover,
+		pendingMints:                types.NewPendingMints(),
 	}, nil
 }
 
+// parseCommitment parses the configured commitment level, defaulting to finalized. Processed is rejected since
+// blockhashes and balances of a processed slot may be rolled back.
+// parseLocalTokenMint returns the configured local-token-mint, else the SOLANA_USDC_MINT env var, else mainnet USDC
+func parseLocalTokenMint(configured, env string) (solana.PublicKey, error) {
+	switch {
+	case configured != "":
+		mint, err := solana.PublicKeyFromBase58(configured)
+		if err != nil {
+			return solana.PublicKey{}, fmt.Errorf("unable to parse local-token-mint: %w", err)
+		}
+		return mint, nil
+	case env != "":
+		mint, err := solana.PublicKeyFromBase58(env)
+		if err != nil {
+			return solana.PublicKey{}, fmt.Errorf("unable to parse SOLANA_USDC_MINT: %w", err)
+		}
+		return mint, nil
+	default:
+		return USDCMintMainnet, nil
+	}
+}
+
+func parseCommitment(commitment string) (rpc.CommitmentType, error) {
+	switch rpc.CommitmentType(commitment) {
+	case "", rpc.CommitmentFinalized:
+		return rpc.CommitmentFinalized, nil
+	case rpc.CommitmentConfirmed:
+		return rpc.CommitmentConfirmed, nil
+	default:
+		return "", fmt.Errorf("unsupported commitment %q, must be finalized or confirmed", commitment)
+	}
+}
+
 func (s *Solana) Name() string {
 	return s.name
 }
@@ -134,10 +241,22 @@ func (s *Solana) SetLatestBlock(block uint64) {
 	s.mu.Unlock()
 }
 
+// BlockTime returns the average block time observed while tracking the latest height, 0 if not known yet
+func (s *Solana) BlockTime() time.Duration {
+	return s.blockTime.Average()
+}
+
 func (s *Solana) LastFlushedBlock() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.lastFlushedBlock
 }
 
+// Resume makes the listener backfill from slot on startup, if it is past the configured start slot
+func (s *Solana) Resume(slot uint64) {
+	s.startBlock = max(s.startBlock, slot)
+}
+
 // IsDestinationCaller validates if the relayer is authorized to process this message
 func (s *Solana) IsDestinationCaller(destinationCaller []byte) (isCaller bool, readableAddress string) {
 	zeroByteArr := make([]byte, 32)
@@ -155,7 +274,17 @@ func (s *Solana) IsDestinationCaller(destinationCaller []byte) (isCaller bool, r
 
 // InitializeClients establishes connection to Solana RPC
 func (s *Solana) InitializeClients(ctx context.Context, logger log.Logger) error {
-	s.rpcClient = rpc.New(s.rpcURL)
+	if s.rpcTimeout > 0 || s.rpcFailover != nil {
+		httpClient := &http.Client{Timeout: s.rpcTimeout}
+		if s.rpcFailover != nil {
+			httpClient.Transport = s.rpcFailover
+		}
+		s.rpcClient = rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(s.rpcURL, &jsonrpc.RPCClientOpts{
+			HTTPClient: httpClient,
+		}))
+	} else {
+		s.rpcClient = rpc.New(s.rpcURL)
+	}
 
 	_, err := s.rpcClient.GetHealth(ctx)
 	if err != nil {
@@ -174,7 +303,18 @@ func (s *Solana) InitializeBroadcaster(
 ) error {
 	// Solana uses recent blockhash for replay protection, not nonces
 	sequenceMap.Put(s.Domain(), 0)
-	logger.Info("Initialized Solana broadcaster", "minter_address", s.minterAddress.String())
+
+	if !s.addressLookupTable.IsZero() {
+		if err := s.loadAddressLookupTable(ctx); err != nil {
+			return err
+		}
+		logger.Info("Loaded address lookup table, broadcasting v0 transactions",
+			"address_lookup_table", s.addressLookupTable.String(), "addresses", len(s.lookupTableAddresses))
+	}
+
+	go s.trackMintConfirmations(ctx, logger)
+
+	logger.Info("Initialized Solana broadcaster", "minter_address", s.minterAddress.String(), "fee_payer", s.payer().String())
 	return nil
 }
 
@@ -192,28 +332,56 @@ func (s *Solana) TrackLatestBlockHeight(
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			slot, err := s.rpcClient.GetSlot(ctx, rpc.CommitmentFinalized)
+			slot, err := s.rpcClient.GetSlot(ctx, s.commitment)
+			s.rpcFailover.ReportActive(metrics, s.name, fmt.Sprint(s.domain))
 			if err != nil {
 				logger.Error("Failed to get Solana slot", "error", err)
 				continue
 			}
 
 			s.SetLatestBlock(slot)
+			s.blockTime.Observe(slot, time.Now())
 
 			if metrics != nil {
 				metrics.SetLatestHeight(s.name, fmt.Sprint(s.domain), int64(slot))
+				if blockTime := s.BlockTime(); blockTime > 0 {
+					metrics.SetBlockTime(s.name, fmt.Sprint(s.domain), blockTime)
+				}
 			}
 		}
 	}
 }
 
+// payer returns the account paying the fees of mints, the minter unless a fee payer is configured
+func (s *Solana) payer() solana.PublicKey {
+	if s.feePayer == nil {
+		return s.minterAddress
+	}
+	return s.feePayer.PublicKey()
+}
+
+// WalletBalance returns the SOL balance of the wallet paying the fees scaled by the metrics exponent
+func (s *Solana) WalletBalance(ctx context.Context) (float64, string, error) {
+	balance, err := s.rpcClient.GetBalance(ctx, s.payer(), s.commitment)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return float64(balance.Value) / math.Pow10(s.MetricsExponent), s.MetricsDenom, nil
+}
+
+// MinBalanceAlert returns the configured min-balance-alert scaled by the metrics exponent
+func (s *Solana) MinBalanceAlert() float64 {
+	return float64(s.minBalanceAlert) / math.Pow10(s.MetricsExponent)
+}
+
 // WalletBalanceMetric tracks SOL balance of the relayer wallet for monitoring
 func (s *Solana) WalletBalanceMetric(
 	ctx context.Context,
 	logger log.Logger,
 	metrics *relayer.PromMetrics,
 ) {
-	if metrics == nil {
+	if metrics == nil && s.minBalanceAlert == 0 {
 		return
 	}
 
@@ -225,29 +393,25 @@ func (s *Solana) WalletBalanceMetric(
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			balance, err := s.rpcClient.GetBalance(ctx, s.minterAddress, rpc.CommitmentFinalized)
+			balance, denom, err := s.WalletBalance(ctx)
 			if err != nil {
 				logger.Error("Failed to get Solana wallet balance", "error", err)
 				continue
 			}
 
-			balanceInDenom := float64(balance.Value) / float64(s.MetricsExponent)
-			metrics.SetWalletBalance(s.name, s.minterAddress.String(), s.MetricsDenom, balanceInDenom)
+			low := s.minBalanceAlert > 0 && balance < s.MinBalanceAlert()
+			if low {
+				logger.Error("Fee payer balance below min-balance-alert", "chain", s.name, "balance", balance, "min_balance", s.MinBalanceAlert(), "denom", denom)
+			}
+
+			if metrics != nil {
+				metrics.SetWalletBalance(s.name, s.payer().String(), denom, balance)
+				metrics.SetWalletBalanceLow(s.name, s.payer().String(), low)
+			}
 		}
 	}
 }
 
-// No-Op: StartListener satisfies the Chain interface but is not needed for Solana (destination-only)
-func (s *Solana) StartListener(
-	ctx context.Context,
-	logger log.Logger,
-	processingQueue chan *types.TxState,
-	flushOnlyMode bool,
-	flushInterval time.Duration,
-) {
-	<-ctx.Done()
-}
-
 // No-Op: CloseClients cleans up RPC connections
 func (s *Solana) CloseClients() error {
 	return nil
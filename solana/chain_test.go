@@ -0,0 +1,46 @@
+package solana
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseCommitment verifies the commitment defaults to finalized and rejects processed
+func TestParseCommitment(t *testing.T) {
+	commitment, err := parseCommitment("")
+	require.NoError(t, err)
+	require.Equal(t, rpc.CommitmentFinalized, commitment)
+
+	commitment, err = parseCommitment("confirmed")
+	require.NoError(t, err)
+	require.Equal(t, rpc.CommitmentConfirmed, commitment)
+
+	_, err = parseCommitment("processed")
+	require.Error(t, err)
+
+	_, err = parseCommitment("final")
+	require.Error(t, err)
+}
+
+// TestParseLocalTokenMint verifies the configured mint takes precedence over SOLANA_USDC_MINT and mainnet USDC
+func TestParseLocalTokenMint(t *testing.T) {
+	devnetUSDC := "4zMMC9srt5Ri5X14GAgXhaHii3GnPAEERYPJgZJDncDU"
+
+	mint, err := parseLocalTokenMint(devnetUSDC, USDCMintMainnet.String())
+	require.NoError(t, err)
+	require.Equal(t, solana.MustPublicKeyFromBase58(devnetUSDC), mint)
+
+	mint, err = parseLocalTokenMint("", devnetUSDC)
+	require.NoError(t, err)
+	require.Equal(t, solana.MustPublicKeyFromBase58(devnetUSDC), mint)
+
+	mint, err = parseLocalTokenMint("", "")
+	require.NoError(t, err)
+	require.Equal(t, USDCMintMainnet, mint)
+
+	_, err = parseLocalTokenMint("not-base58!", "")
+	require.ErrorContains(t, err, "local-token-mint")
+}
@@ -14,21 +14,52 @@ type ChainConfig struct {
 	RPC                  string `yaml:"rpc"`
 	WS                   string `yaml:"ws"`
 	Domain               types.Domain
-	MessageTransmitter   string `yaml:"message-transmitter"`
-	TokenMessengerMinter string `yaml:"token-messenger-minter"`
+	ChainType            types.ChainType `yaml:"chain-type"` // defaults to solana
+	MessageTransmitter   string          `yaml:"message-transmitter"`
+	TokenMessengerMinter string          `yaml:"token-messenger-minter"`
 
 	StartBlock     uint64 `yaml:"start-block"`
 	LookbackPeriod uint64 `yaml:"lookback-period"`
 
-	BroadcastRetries       int `yaml:"broadcast-retries"`
-	BroadcastRetryInterval int `yaml:"broadcast-retry-interval"`
+	BroadcastRetries          int `yaml:"broadcast-retries"`
+	BroadcastRetryInterval    int `yaml:"broadcast-retry-interval"`
+	BroadcastMaxRetryInterval int `yaml:"broadcast-max-retry-interval"` // seconds the doubling retry interval is capped at (default: 60)
 
 	MinMintAmount uint64 `yaml:"min-mint-amount"`
 
+	// remote burn token address (hex) -> Solana SPL mint (base58), e.g. for EURC.
+	// Burn tokens without a mapping are minted as USDC.
+	TokenMints map[string]string `yaml:"token-mints"`
+	// Solana mint (base58) of USDC, e.g. the devnet USDC mint. Falls back to the SOLANA_USDC_MINT env var, then
+	// mainnet USDC.
+	LocalTokenMint string `yaml:"local-token-mint"`
+
 	MetricsDenom    string `yaml:"metrics-denom"`
 	MetricsExponent int    `yaml:"metrics-exponent"`
+	MinBalanceAlert uint64 `yaml:"min-balance-alert"` // minter balance in lamports to alert below, 0 disables
+
+	// address lookup table (base58) holding the static CCTP accounts, mints are broadcast as legacy transactions
+	// when empty
+	AddressLookupTable string `yaml:"address-lookup-table"`
+
+	// commitment level for broadcasting and chain tracking, finalized (default) or confirmed. Burns are always
+	// read at finalized.
+	Commitment        string `yaml:"commitment"`
+	RPCTimeoutSeconds int    `yaml:"rpc-timeout-seconds"` // timeout of a request to the rpc, 0 uses the client default
+
+	// tried in order when the rpc fails repeatedly, the rpc is rechecked periodically
+	RPCFallbacks []string `yaml:"rpc-fallbacks"`
 
 	MinterPrivateKey string `yaml:"minter-private-key"`
+	// pays the fees of mints instead of the minter, which stays the CCTP caller. The minter pays when empty.
+	FeePayerPrivateKey string `yaml:"fee-payer-private-key"`
+}
+
+func (c *ChainConfig) DomainType() (types.Domain, types.ChainType) {
+	if c.ChainType == "" {
+		return c.Domain, types.ChainTypeSolana
+	}
+	return c.Domain, c.ChainType
 }
 
 func (c *ChainConfig) Chain(name string) (types.Chain, error) {
@@ -43,6 +74,10 @@ func (c *ChainConfig) Chain(name string) (types.Chain, error) {
 		}
 	}
 
+	if feePayerKey := os.Getenv(strings.ToUpper(name) + "_FEE_PAYER_PRIV_KEY"); feePayerKey != "" {
+		c.FeePayerPrivateKey = feePayerKey
+	}
+
 	return NewChain(
 		name,
 		c.Domain,
@@ -58,5 +93,14 @@ func (c *ChainConfig) Chain(name string) (types.Chain, error) {
 		c.MinMintAmount,
 		c.MetricsDenom,
 		c.MetricsExponent,
+		c.TokenMints,
+		c.LocalTokenMint,
+		c.MinBalanceAlert,
+		c.AddressLookupTable,
+		c.Commitment,
+		c.RPCTimeoutSeconds,
+		c.FeePayerPrivateKey,
+		c.RPCFallbacks,
+		c.BroadcastMaxRetryInterval,
 	)
 }
@@ -0,0 +1,95 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"cosmossdk.io/log"
+)
+
+const (
+	// mintConfirmationInterval is how often the signature statuses of broadcast mints are polled
+	mintConfirmationInterval = 5 * time.Second
+
+	// mintConfirmationTimeout is how long a mint may stay unconfirmed, well past the ~90s a blockhash is valid for
+	mintConfirmationTimeout = 5 * time.Minute
+
+	// maxSignatureStatuses is the max number of signatures a getSignatureStatuses call accepts
+	maxSignatureStatuses = 256
+)
+
+// trackMintConfirmations polls the signature statuses of broadcast mints, recording on each message whether its
+// transaction confirmed or failed on chain
+func (s *Solana) trackMintConfirmations(ctx context.Context, logger log.Logger) {
+	logger = logger.With("routine", "trackMintConfirmations", "chain", s.name, "domain", s.domain)
+
+	ticker := time.NewTicker(mintConfirmationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkMintConfirmations(ctx, logger)
+		}
+	}
+}
+
+// checkMintConfirmations resolves the broadcast mints whose signature reached the broadcast commitment or failed
+func (s *Solana) checkMintConfirmations(ctx context.Context, logger log.Logger) {
+	hashes := s.pendingMints.TxHashes()
+	for start := 0; start < len(hashes); start += maxSignatureStatuses {
+		batch := hashes[start:min(start+maxSignatureStatuses, len(hashes))]
+
+		sigs := make([]solana.Signature, 0, len(batch))
+		for _, hash := range batch {
+			sig, err := solana.SignatureFromBase58(hash)
+			if err != nil {
+				s.pendingMints.Fail(hash, fmt.Sprintf("invalid mint tx signature: %v", err))
+				continue
+			}
+			sigs = append(sigs, sig)
+		}
+
+		res, err := s.rpcClient.GetSignatureStatuses(ctx, false, sigs...)
+		if err != nil {
+			logger.Error("Unable to query mint signature statuses", "err", err)
+			return
+		}
+
+		for i, status := range res.Value {
+			if i >= len(sigs) || status == nil {
+				continue
+			}
+			hash := sigs[i].String()
+			switch {
+			case status.Err != nil:
+				if msg := s.pendingMints.Fail(hash, fmt.Sprintf("mint tx failed: %v", status.Err)); msg != nil {
+					logger.Error("Mint tx failed on chain", "tx", hash, "src-tx", msg.SourceTxHash, "err", status.Err)
+				}
+			case confirmedAt(status.ConfirmationStatus, s.commitment):
+				if msg := s.pendingMints.Confirm(hash); msg != nil {
+					msg.DestBlock = status.Slot
+					logger.Debug("Mint tx confirmed", "tx", hash, "src-tx", msg.SourceTxHash, "slot", status.Slot)
+				}
+			}
+		}
+	}
+
+	for _, msg := range s.pendingMints.Expire(mintConfirmationTimeout) {
+		logger.Error("Mint tx was not confirmed in time", "tx", msg.DestTxHash, "src-tx", msg.SourceTxHash, "timeout", mintConfirmationTimeout)
+	}
+}
+
+// confirmedAt returns whether a transaction with the given confirmation status reached commitment
+func confirmedAt(status rpc.ConfirmationStatusType, commitment rpc.CommitmentType) bool {
+	if status == rpc.ConfirmationStatusFinalized {
+		return true
+	}
+	return status == rpc.ConfirmationStatusConfirmed && commitment == rpc.CommitmentConfirmed
+}
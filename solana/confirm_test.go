@@ -0,0 +1,68 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestCheckMintConfirmations verifies broadcast mints are resolved from their signature statuses
+func TestCheckMintConfirmations(t *testing.T) {
+	finalized, failed, processed := solana.Signature{1}, solana.Signature{2}, solana.Signature{3}
+	statuses := map[string]interface{}{
+		finalized.String(): map[string]interface{}{"slot": 10, "err": nil, "confirmationStatus": "finalized"},
+		failed.String():    map[string]interface{}{"slot": 10, "err": map[string]interface{}{"InstructionError": []interface{}{0, map[string]interface{}{"Custom": 1}}}, "confirmationStatus": "confirmed"},
+		processed.String(): map[string]interface{}{"slot": 10, "err": nil, "confirmationStatus": "processed"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{}     `json:"id"`
+			Params [][]interface{} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		value := make([]interface{}, 0, len(req.Params[0]))
+		for _, sig := range req.Params[0] {
+			value = append(value, statuses[sig.(string)])
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  map[string]interface{}{"context": map[string]interface{}{"slot": 11}, "value": value},
+		}))
+	}))
+	t.Cleanup(server.Close)
+
+	s := &Solana{rpcClient: rpc.New(server.URL), commitment: rpc.CommitmentFinalized, pendingMints: types.NewPendingMints()}
+	finalizedMsg, failedMsg, processedMsg := &types.MessageState{}, &types.MessageState{}, &types.MessageState{}
+	s.pendingMints.Track(finalized.String(), finalizedMsg)
+	s.pendingMints.Track(failed.String(), failedMsg)
+	s.pendingMints.Track(processed.String(), processedMsg)
+
+	s.checkMintConfirmations(context.Background(), log.NewNopLogger())
+
+	require.True(t, finalizedMsg.DestConfirmed)
+	require.False(t, failedMsg.DestConfirmed)
+	require.Contains(t, failedMsg.DestError, "InstructionError")
+	// not yet at the broadcast commitment
+	require.False(t, processedMsg.DestConfirmed)
+	require.Empty(t, processedMsg.DestError)
+	require.Equal(t, []string{processed.String()}, s.pendingMints.TxHashes())
+}
+
+func TestConfirmedAt(t *testing.T) {
+	require.True(t, confirmedAt(rpc.ConfirmationStatusFinalized, rpc.CommitmentFinalized))
+	require.False(t, confirmedAt(rpc.ConfirmationStatusConfirmed, rpc.CommitmentFinalized))
+	require.True(t, confirmedAt(rpc.ConfirmationStatusConfirmed, rpc.CommitmentConfirmed))
+	require.False(t, confirmedAt(rpc.ConfirmationStatusProcessed, rpc.CommitmentConfirmed))
+}
@@ -0,0 +1,373 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gorilla/websocket"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// signaturesPageSize is the max number of signatures returned by a getSignaturesForAddress call
+const signaturesPageSize = 1000
+
+// messageSentDiscriminator is the anchor discriminator of the MessageSent accounts the MessageTransmitter
+// program writes every sent message to
+var messageSentDiscriminator = func() []byte {
+	hash := sha256.Sum256([]byte("account:MessageSent"))
+	return hash[:8]
+}()
+
+// maxSupportedTransactionVersion allows fetching versioned (v0) transactions
+var maxSupportedTransactionVersion = uint64(0)
+
+// StartListener queries the history of the MessageTransmitter program from (start block - lookback) up to the
+// latest slot, then subscribes to the logs mentioning it over the websocket and enqueues the messages sent by
+// each transaction. Solana slots are used as block heights.
+//
+// If the websocket subscription fails, it is re-established and the slots missed in between are backfilled.
+func (s *Solana) StartListener(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	flushOnlyMode bool,
+	flushInterval time.Duration,
+	metrics *relayer.PromMetrics,
+) {
+	logger = logger.With("chain", s.name, "domain", s.domain)
+
+	// FlushOnlyMode is used for the secondary, flush only relayer. When enabled, the main stream is not started.
+	if flushOnlyMode {
+		s.flushMechanism(ctx, logger, processingQueue, flushInterval)
+		return
+	}
+
+	latestBlock := s.LatestBlock()
+	start := latestBlock
+	if s.startBlock != 0 {
+		start = s.startBlock
+	}
+	startLookback := lookbackFrom(start, s.lookbackPeriod)
+
+	logger.Info(fmt.Sprintf("Getting history from %d: starting at: %d looking back %d slots", startLookback, start, s.lookbackPeriod))
+	backfilled := s.getAndConsumeHistory(ctx, logger, processingQueue, startLookback)
+	logger.Info("Finished getting history", "messages", backfilled)
+	if metrics != nil {
+		metrics.AddBackfilledMessages(s.name, fmt.Sprint(s.domain), backfilled)
+	}
+
+	if flushInterval > 0 {
+		go s.flushMechanism(ctx, logger, processingQueue, flushInterval)
+	}
+
+	for {
+		err := s.consumeStream(ctx, logger, processingQueue)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error("Websocket disconnected. Reconnecting...", "err", err)
+		if metrics != nil {
+			metrics.IncWebsocketReconnects(s.name, fmt.Sprint(s.domain))
+		}
+		time.Sleep(1 * time.Second)
+
+		// catch up on the slots missed while disconnected
+		s.getAndConsumeHistory(ctx, logger, processingQueue, lookbackFrom(s.LastFlushedBlock(), 0))
+	}
+}
+
+// lookbackFrom returns start - lookback, floored at slot 0
+func lookbackFrom(start, lookback uint64) uint64 {
+	if lookback > start {
+		return 0
+	}
+	return start - lookback
+}
+
+// logsNotification is a logsSubscribe notification
+type logsNotification struct {
+	Method string `json:"method"`
+	Params struct {
+		Result struct {
+			Context struct {
+				Slot uint64 `json:"slot"`
+			} `json:"context"`
+			Value struct {
+				Signature solana.Signature `json:"signature"`
+				Err       any              `json:"err"`
+			} `json:"value"`
+		} `json:"result"`
+	} `json:"params"`
+}
+
+// consumeStream subscribes to the logs of finalized transactions mentioning the MessageTransmitter program and
+// enqueues their messages until the subscription fails or ctx is done
+func (s *Solana) consumeStream(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.wsURL, nil)
+	if err != nil {
+		return fmt.Errorf("unable to connect to websocket: %w", err)
+	}
+	defer conn.Close()
+
+	// unblock ReadJSON once ctx is done and keep the connection alive while no burns are sent
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	err = conn.WriteJSON(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "logsSubscribe",
+		"params": []any{
+			map[string]any{"mentions": []string{s.messageTransmitterProgram.String()}},
+			map[string]any{"commitment": rpc.CommitmentFinalized},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to logs: %w", err)
+	}
+
+	logger.Info("Starting consumption of incoming stream")
+	for {
+		var notification logsNotification
+		if err := conn.ReadJSON(&notification); err != nil {
+			return err
+		}
+		// skip the subscription confirmation
+		if notification.Method != "logsNotification" {
+			continue
+		}
+
+		result := notification.Params.Result
+		if result.Value.Err == nil {
+			s.consumeTx(ctx, logger, processingQueue, result.Value.Signature)
+		}
+		s.setLastFlushedBlock(result.Context.Slot)
+	}
+}
+
+// getAndConsumeHistory enqueues the messages of every successful transaction mentioning the MessageTransmitter
+// program from the start slot up to the latest finalized one, oldest first, and returns how many it enqueued
+func (s *Solana) getAndConsumeHistory(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	start uint64,
+) (consumed int) {
+	var history []*rpc.TransactionSignature
+	var before solana.Signature
+	latestSlot := s.LatestBlock()
+
+	limit := signaturesPageSize
+	for {
+		page, err := s.rpcClient.GetSignaturesForAddressWithOpts(ctx, s.messageTransmitterProgram, &rpc.GetSignaturesForAddressOpts{
+			Limit:      &limit,
+			Before:     before,
+			Commitment: rpc.CommitmentFinalized,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return consumed
+			}
+			logger.Error("Unable to query signatures", "before", before, "err", err)
+			time.Sleep(1 * time.Second)
+			continue
+		}
+
+		done := len(page) < signaturesPageSize
+		for _, sig := range page {
+			if sig.Slot < start {
+				done = true
+				break
+			}
+			latestSlot = max(latestSlot, sig.Slot)
+			if sig.Err == nil {
+				history = append(history, sig)
+			}
+		}
+		if done {
+			break
+		}
+		before = page[len(page)-1].Signature
+	}
+
+	// signatures are returned newest first
+	for i := len(history) - 1; i >= 0; i-- {
+		consumed += s.consumeTx(ctx, logger, processingQueue, history[i].Signature)
+	}
+	s.setLastFlushedBlock(latestSlot)
+	return consumed
+}
+
+// flushMechanism re-queries the history from (last flushed slot - lookback) every flush interval
+func (s *Solana) flushMechanism(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	flushInterval time.Duration,
+) {
+	logger.Info(fmt.Sprintf("Starting flush mechanism. Will flush every %v", flushInterval))
+
+	for {
+		timer := time.NewTimer(flushInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			start := s.LastFlushedBlock()
+			if start == 0 {
+				start = s.LatestBlock()
+			}
+			startLookback := lookbackFrom(start, s.lookbackPeriod)
+
+			logger.Info(fmt.Sprintf("Flush started from %d: (last flushed slot: %d, lookback %d)", startLookback, start, s.lookbackPeriod))
+			s.getAndConsumeHistory(ctx, logger, processingQueue, startLookback)
+			logger.Info(fmt.Sprintf("Flush complete up to %d", s.LastFlushedBlock()))
+		}
+	}
+}
+
+// consumeTx enqueues the messages sent by a transaction and returns how many it enqueued
+func (s *Solana) consumeTx(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	signature solana.Signature,
+) int {
+	msgs, err := s.messagesSent(ctx, signature)
+	if err != nil {
+		logger.Error("Unable to get messages sent by tx, skipping", "tx hash", signature, "err", err)
+		return 0
+	}
+	if len(msgs) == 0 {
+		return 0
+	}
+
+	for _, msg := range msgs {
+		logger.Info(fmt.Sprintf("New msg from source domain %d with tx hash %s", msg.SourceDomain, msg.SourceTxHash))
+	}
+	if !types.Enqueue(logger, processingQueue, &types.TxState{TxHash: signature.String(), Msgs: msgs}, s.name, s.domain) {
+		return 0
+	}
+	return len(msgs)
+}
+
+// messagesSent returns the messages a transaction wrote to MessageSent accounts. Messages whose account has
+// already been reclaimed can't be recovered and are skipped.
+func (s *Solana) messagesSent(ctx context.Context, signature solana.Signature) ([]*types.MessageState, error) {
+	txResult, err := s.rpcClient.GetTransaction(ctx, signature, &rpc.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     rpc.CommitmentFinalized,
+		MaxSupportedTransactionVersion: &maxSupportedTransactionVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get transaction: %w", err)
+	}
+	if txResult.Meta != nil && txResult.Meta.Err != nil {
+		return nil, nil
+	}
+	if txResult.Transaction == nil {
+		return nil, errors.New("transaction not returned")
+	}
+	tx, err := txResult.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+
+	// MessageSent accounts are created by the tx, so they are always writable signers of it
+	var candidates []solana.PublicKey
+	for i, key := range tx.Message.AccountKeys {
+		if tx.Message.IsSigner(key) && i != 0 {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	accounts, err := s.rpcClient.GetMultipleAccountsWithOpts(ctx, candidates, &rpc.GetMultipleAccountsOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: rpc.CommitmentFinalized,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get accounts: %w", err)
+	}
+
+	var msgs []*types.MessageState
+	for _, account := range accounts.Value {
+		if account == nil || !account.Owner.Equals(s.messageTransmitterProgram) || account.Data == nil {
+			continue
+		}
+		msgBytes, ok := parseMessageSentAccount(account.Data.GetBinary())
+		if !ok {
+			continue
+		}
+		msg, err := types.NewMessageState(msgBytes, signature.String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse message: %w", err)
+		}
+		msg.SourceBlock = txResult.Slot
+		if txResult.BlockTime != nil {
+			msg.SourceTime = txResult.BlockTime.Time()
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// parseMessageSentAccount returns the message stored in a MessageSent account. The account is laid out as the
+// anchor discriminator, the rent payer, then the message as a borsh vec. v2 accounts have an i64 creation
+// timestamp between the rent payer and the message.
+func parseMessageSentAccount(data []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(data, messageSentDiscriminator) {
+		return nil, false
+	}
+
+	const rentPayerEnd = 8 + 32
+	for _, offset := range []int{rentPayerEnd, rentPayerEnd + 8} {
+		if len(data) < offset+4 {
+			break
+		}
+		length := int(binary.LittleEndian.Uint32(data[offset:]))
+		if offset+4+length == len(data) {
+			return data[offset+4:], true
+		}
+	}
+	return nil, false
+}
+
+func (s *Solana) setLastFlushedBlock(slot uint64) {
+	s.mu.Lock()
+	if slot > s.lastFlushedBlock {
+		s.lastFlushedBlock = slot
+	}
+	s.mu.Unlock()
+}
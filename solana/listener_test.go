@@ -0,0 +1,40 @@
+package solana
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func messageSentAccount(createdAt bool, msg []byte) []byte {
+	data := append([]byte{}, messageSentDiscriminator...)
+	data = append(data, make([]byte, 32)...) // rent payer
+	if createdAt {
+		data = binary.LittleEndian.AppendUint64(data, 1700000000)
+	}
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(msg)))
+	return append(data, msg...)
+}
+
+func TestParseMessageSentAccount(t *testing.T) {
+	msg := []byte("message sent bytes")
+
+	parsed, ok := parseMessageSentAccount(messageSentAccount(false, msg))
+	require.True(t, ok)
+	require.Equal(t, msg, parsed)
+
+	parsed, ok = parseMessageSentAccount(messageSentAccount(true, msg))
+	require.True(t, ok)
+	require.Equal(t, msg, parsed)
+
+	// not a MessageSent account
+	data := messageSentAccount(false, msg)
+	data[0]++
+	_, ok = parseMessageSentAccount(data)
+	require.False(t, ok)
+
+	// truncated
+	_, ok = parseMessageSentAccount(messageSentAccount(false, msg)[:50])
+	require.False(t, ok)
+}
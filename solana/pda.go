@@ -2,6 +2,7 @@ package solana
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"strconv"
 
@@ -10,6 +11,15 @@ import (
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
+// ResolveLocalTokenMint returns the Solana mint for a remote burn token, falling back to the default mint
+// when the burn token has no configured mapping.
+func ResolveLocalTokenMint(burnToken []byte, tokenMints map[string]solana.PublicKey, defaultTokenMint solana.PublicKey) solana.PublicKey {
+	if mint, ok := tokenMints[hex.EncodeToString(burnToken)]; ok {
+		return mint
+	}
+	return defaultTokenMint
+}
+
 // DeriveCCTPAccounts derives all Program Derived Addresses required for CCTP receiveMessage
 // Solana CCTP uses PDAs (deterministic addresses owned by programs) instead of contract state.
 // Each PDA is derived from specific seeds + program ID, following Circle's implementation.
@@ -17,7 +27,8 @@ func DeriveCCTPAccounts(
 	msg *types.MessageState,
 	messageTransmitterProgram solana.PublicKey,
 	tokenMessengerMinterProgram solana.PublicKey,
-	localTokenMint solana.PublicKey,
+	tokenMints map[string]solana.PublicKey,
+	defaultTokenMint solana.PublicKey,
 ) (*CCTPAccounts, error) {
 	parsedMsg, err := new(types.Message).Parse(msg.MsgSentBytes)
 	if err != nil {
@@ -29,6 +40,9 @@ func DeriveCCTPAccounts(
 		return nil, fmt.Errorf("failed to parse burn message: %w", err)
 	}
 
+	// The local mint depends on which token was burned on the source domain (e.g. USDC or EURC)
+	localTokenMint := ResolveLocalTokenMint(burnMessage.BurnToken, tokenMints, defaultTokenMint)
+
 	messageTransmitter, _, err := solana.FindProgramAddress(
 		[][]byte{[]byte("message_transmitter")},
 		messageTransmitterProgram,
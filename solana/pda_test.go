@@ -0,0 +1,124 @@
+package solana
+
+import (
+	"encoding/binary"
+	"testing"
+
+	nobletypes "github.com/circlefin/noble-cctp/x/cctp/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var (
+	testUSDCBurnToken = common.LeftPadBytes(common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48").Bytes(), 32)
+	testEURCBurnToken = common.LeftPadBytes(common.HexToAddress("0x1aBaEA1f7C830bD89Acc67eC4af516284b1bC33c").Bytes(), 32)
+	testEURCMint      = solana.MustPublicKeyFromBase58("HzwqbKZw8HxMN6bF2yFZNrht3c2iXXzpKcFu7uBEDKtr")
+)
+
+func testTokenMints(t *testing.T) map[string]solana.PublicKey {
+	t.Helper()
+
+	tokenMints, err := ParseTokenMints(map[string]string{
+		"0x1aBaEA1f7C830bD89Acc67eC4af516284b1bC33c": testEURCMint.String(),
+	})
+	require.NoError(t, err)
+	return tokenMints
+}
+
+func testBurnMessageSentBytes(t *testing.T, sourceDomain uint32, burnToken []byte) []byte {
+	t.Helper()
+
+	burn := nobletypes.BurnMessage{
+		BurnToken:     burnToken,
+		MintRecipient: solana.NewWallet().PublicKey().Bytes(),
+		Amount:        math.NewInt(1000000),
+		MessageSender: make([]byte, 32),
+	}
+	body, err := burn.Bytes()
+	require.NoError(t, err)
+
+	msg := nobletypes.Message{
+		SourceDomain:      sourceDomain,
+		DestinationDomain: 5,
+		Nonce:             70000,
+		Sender:            make([]byte, 32),
+		Recipient:         make([]byte, 32),
+		DestinationCaller: make([]byte, 32),
+		MessageBody:       body,
+	}
+	raw, err := msg.Bytes()
+	require.NoError(t, err)
+	return raw
+}
+
+// TestResolveLocalTokenMint verifies mapped burn tokens resolve to their mint and others fall back to the default
+func TestResolveLocalTokenMint(t *testing.T) {
+	tokenMints := testTokenMints(t)
+
+	tests := []struct {
+		name      string
+		burnToken []byte
+		want      solana.PublicKey
+	}{
+		{name: "mapped eurc", burnToken: testEURCBurnToken, want: testEURCMint},
+		{name: "unmapped usdc falls back", burnToken: testUSDCBurnToken, want: USDCMintMainnet},
+		{name: "unpadded token is not mapped", burnToken: common.HexToAddress("0x1aBaEA1f7C830bD89Acc67eC4af516284b1bC33c").Bytes(), want: USDCMintMainnet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ResolveLocalTokenMint(tt.burnToken, tokenMints, USDCMintMainnet))
+		})
+	}
+
+	require.Equal(t, USDCMintMainnet, ResolveLocalTokenMint(testEURCBurnToken, nil, USDCMintMainnet))
+}
+
+// TestDeriveCCTPAccountsTokenMint verifies the token pair, custody and local token PDAs follow the burned token
+func TestDeriveCCTPAccountsTokenMint(t *testing.T) {
+	messageTransmitterProgram := solana.NewWallet().PublicKey()
+	tokenMessengerMinterProgram := solana.NewWallet().PublicKey()
+	tokenMints := testTokenMints(t)
+
+	sourceDomain := make([]byte, 4)
+	binary.BigEndian.PutUint32(sourceDomain, 0)
+
+	derive := func(seeds ...[]byte) solana.PublicKey {
+		addr, _, err := solana.FindProgramAddress(seeds, tokenMessengerMinterProgram)
+		require.NoError(t, err)
+		return addr
+	}
+
+	tests := []struct {
+		name      string
+		burnToken []byte
+		mint      solana.PublicKey
+	}{
+		{name: "eurc", burnToken: testEURCBurnToken, mint: testEURCMint},
+		{name: "usdc", burnToken: testUSDCBurnToken, mint: USDCMintMainnet},
+	}
+
+	derived := make(map[string]*CCTPAccounts, len(tests))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &types.MessageState{MsgSentBytes: testBurnMessageSentBytes(t, 0, tt.burnToken)}
+
+			accounts, err := DeriveCCTPAccounts(msg, messageTransmitterProgram, tokenMessengerMinterProgram, tokenMints, USDCMintMainnet)
+			require.NoError(t, err)
+
+			require.Equal(t, derive([]byte("token_pair"), sourceDomain, tt.burnToken), accounts.TokenPair)
+			require.Equal(t, derive([]byte("custody"), tt.mint.Bytes()), accounts.CustodyTokenAccount)
+			require.Equal(t, derive([]byte("local_token"), tt.mint.Bytes()), accounts.LocalToken)
+			derived[tt.name] = accounts
+		})
+	}
+
+	require.NotEqual(t, derived["usdc"].TokenPair, derived["eurc"].TokenPair)
+	require.NotEqual(t, derived["usdc"].CustodyTokenAccount, derived["eurc"].CustodyTokenAccount)
+	require.NotEqual(t, derived["usdc"].LocalToken, derived["eurc"].LocalToken)
+}
@@ -0,0 +1,155 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// recipientCheckTimeout bounds the account lookup so a slow rpc doesn't stall the processor
+	recipientCheckTimeout = 5 * time.Second
+
+	// valid token accounts are cached longer than invalid ones, which may be fixed after the burn
+	validRecipientTTL   = 10 * time.Minute
+	invalidRecipientTTL = time.Minute
+	// maxCachedRecipients bounds the recipient cache, expired and then the soonest expiring checks are evicted
+	maxCachedRecipients = 10000
+
+	// SPL token account layout: mint (32) | owner (32) | amount (8) | delegate (36) | state (1) | ...
+	tokenAccountMintOffset  = 0
+	tokenAccountStateOffset = 108
+	tokenAccountLength      = 165
+
+	tokenAccountInitialized = 1
+)
+
+// recipientCheck is a cached mint recipient validation result
+type recipientCheck struct {
+	reason  string
+	expires time.Time
+}
+
+// recipientCache caches mint recipient validation results by token account and mint
+type recipientCache struct {
+	mu      sync.Mutex
+	entries map[[2]solana.PublicKey]recipientCheck
+}
+
+// ValidateMintRecipient checks the mint recipient of a burn to Solana is an initialized SPL token account of the
+// mint the burned token is minted as. The mint recipient must be a token account, a wallet address can't be minted
+// to. A recipient that doesn't exist yet is valid, it may be created before the mint is broadcast and the broadcast
+// is retried until it is. Returns the reason the recipient is invalid, empty if it is valid, or an error if the
+// account couldn't be fetched. Results of existing accounts are cached.
+func (s *Solana) ValidateMintRecipient(ctx context.Context, msg *types.MessageState) (string, error) {
+	burnMessage, err := new(types.BurnMessage).Parse(msg.MsgBody)
+	if err != nil {
+		return fmt.Sprintf("not a valid burn message: %v", err), nil
+	}
+
+	userTokenAccount, err := BytesToSolanaPublicKey(burnMessage.MintRecipient)
+	if err != nil {
+		return fmt.Sprintf("invalid mint recipient: %v", err), nil
+	}
+	mint := ResolveLocalTokenMint(burnMessage.BurnToken, s.tokenMints, s.localTokenMint)
+
+	key := [2]solana.PublicKey{userTokenAccount, mint}
+	if reason, ok := s.recipients.get(key); ok {
+		return reason, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, recipientCheckTimeout)
+	defer cancel()
+
+	res, err := s.rpcClient.GetAccountInfoWithOpts(ctx, userTokenAccount, &rpc.GetAccountInfoOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: rpc.CommitmentConfirmed,
+	})
+	switch {
+	case errors.Is(err, rpc.ErrNotFound) || (err == nil && res.Value == nil):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("unable to fetch mint recipient %s: %w", userTokenAccount, err)
+	}
+
+	reason := checkTokenAccount(userTokenAccount, res.Value, mint)
+	s.recipients.put(key, reason)
+	return reason, nil
+}
+
+// checkTokenAccount returns why account, the mint recipient, can't receive mints of mint, empty if it can
+func checkTokenAccount(address solana.PublicKey, account *rpc.Account, mint solana.PublicKey) string {
+	if !account.Owner.Equals(solana.TokenProgramID) && !account.Owner.Equals(solana.Token2022ProgramID) {
+		return fmt.Sprintf("mint recipient %s is not a token account, owner %s", address, account.Owner)
+	}
+
+	var data []byte
+	if account.Data != nil {
+		data = account.Data.GetBinary()
+	}
+	if len(data) < tokenAccountLength {
+		return fmt.Sprintf("mint recipient %s is not a token account, data length %d", address, len(data))
+	}
+	if accountMint := solana.PublicKeyFromBytes(data[tokenAccountMintOffset : tokenAccountMintOffset+32]); !accountMint.Equals(mint) {
+		return fmt.Sprintf("mint recipient %s is a token account for mint %s, expected %s", address, accountMint, mint)
+	}
+	if data[tokenAccountStateOffset] != tokenAccountInitialized {
+		return fmt.Sprintf("mint recipient %s is not an initialized token account, state %d", address, data[tokenAccountStateOffset])
+	}
+	return ""
+}
+
+func (c *recipientCache) get(key [2]solana.PublicKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	check, ok := c.entries[key]
+	if !ok || time.Now().After(check.expires) {
+		return "", false
+	}
+	return check.reason, true
+}
+
+func (c *recipientCache) put(key [2]solana.PublicKey, reason string) {
+	ttl := validRecipientTTL
+	if reason != "" {
+		ttl = invalidRecipientTTL
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[[2]solana.PublicKey]recipientCheck)
+	}
+	now := time.Now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxCachedRecipients {
+		c.evict(now)
+	}
+	c.entries[key] = recipientCheck{reason: reason, expires: now.Add(ttl)}
+}
+
+// evict drops the expired checks, or the soonest expiring one if none expired. The caller must hold the lock.
+func (c *recipientCache) evict(now time.Time) {
+	var soonest [2]solana.PublicKey
+	var soonestExpires time.Time
+	for key, check := range c.entries {
+		if now.After(check.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if soonestExpires.IsZero() || check.expires.Before(soonestExpires) {
+			soonest, soonestExpires = key, check.expires
+		}
+	}
+	if len(c.entries) >= maxCachedRecipients {
+		delete(c.entries, soonest)
+	}
+}
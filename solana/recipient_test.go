@@ -0,0 +1,56 @@
+package solana
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenAccount returns an SPL token account of mint in the given state
+func tokenAccount(owner solana.PublicKey, mint solana.PublicKey, state byte) *rpc.Account {
+	data := make([]byte, tokenAccountLength)
+	copy(data[tokenAccountMintOffset:], mint.Bytes())
+	data[tokenAccountStateOffset] = state
+	return &rpc.Account{Owner: owner, Data: rpc.DataBytesOrJSONFromBytes(data)}
+}
+
+func TestCheckTokenAccount(t *testing.T) {
+	address := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	require.Empty(t, checkTokenAccount(address, tokenAccount(solana.TokenProgramID, mint, tokenAccountInitialized), mint))
+	require.Empty(t, checkTokenAccount(address, tokenAccount(solana.Token2022ProgramID, mint, tokenAccountInitialized), mint))
+
+	// a wallet address is owned by the system program
+	wallet := &rpc.Account{Owner: solana.SystemProgramID, Data: rpc.DataBytesOrJSONFromBytes(nil)}
+	require.Contains(t, checkTokenAccount(address, wallet, mint), "is not a token account")
+
+	otherMint := solana.NewWallet().PublicKey()
+	require.Contains(t, checkTokenAccount(address, tokenAccount(solana.TokenProgramID, otherMint, tokenAccountInitialized), mint), "expected "+mint.String())
+
+	require.Contains(t, checkTokenAccount(address, tokenAccount(solana.TokenProgramID, mint, 2), mint), "not an initialized token account")
+}
+
+func TestRecipientCache(t *testing.T) {
+	var cache recipientCache
+	key := [2]solana.PublicKey{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+
+	_, ok := cache.get(key)
+	require.False(t, ok)
+
+	cache.put(key, "is not a token account")
+	reason, ok := cache.get(key)
+	require.True(t, ok)
+	require.Equal(t, "is not a token account", reason)
+
+	// a full cache evicts the soonest expiring check
+	for len(cache.entries) < maxCachedRecipients {
+		cache.put([2]solana.PublicKey{solana.NewWallet().PublicKey()}, "")
+	}
+	cache.put([2]solana.PublicKey{solana.NewWallet().PublicKey()}, "")
+	require.Len(t, cache.entries, maxCachedRecipients)
+	_, ok = cache.get(key)
+	require.False(t, ok)
+}
@@ -39,11 +39,12 @@ func ConfigSetup(t *testing.T) (a *cmd.AppState, registeredDomains map[types.Dom
 				MinterPrivateKey: "1111111111111111111111111111111111111111111111111111111111111111",
 			},
 			"ethereum": &ethereum.ChainConfig{
-				ChainID:          11155111,
-				Domain:           types.Domain(0),
-				MinterPrivateKey: "1111111111111111111111111111111111111111111111111111111111111111",
-				RPC:              GetEnvOrDefault("SEPOLIA_RPC", "https://ethereum-sepolia-rpc.publicnode.com"),
-				WS:               GetEnvOrDefault("SEPOLIA_WS", "wss://ethereum-sepolia-rpc.publicnode.com"),
+				ChainID:            11155111,
+				Domain:             types.Domain(0),
+				MinterPrivateKey:   "1111111111111111111111111111111111111111111111111111111111111111",
+				RPC:                GetEnvOrDefault("SEPOLIA_RPC", "https://ethereum-sepolia-rpc.publicnode.com"),
+				WS:                 GetEnvOrDefault("SEPOLIA_WS", "wss://ethereum-sepolia-rpc.publicnode.com"),
+				MessageTransmitter: "0x7865fAfC2db2093669d92c0F33AeEF291086BEFD",
 			},
 		},
 		Circle: types.CircleSettings{
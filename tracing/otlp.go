@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// exportURL returns the collector's /v1/traces url, the endpoint may be the collector's base url
+func exportURL(endpoint string) string {
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	return url
+}
+
+// newOTLPExporter creates an exporter posting spans as OTLP/HTTP protobuf to url, with headers sent on every export
+func newOTLPExporter(ctx context.Context, url string, headers map[string]string) (sdktrace.SpanExporter, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(url)}
+	if len(headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(headers))
+	}
+	return otlptracehttp.New(ctx, opts...)
+}
@@ -0,0 +1,226 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	defaultServiceName  = "noble-cctp-relayer"
+	instrumentationName = "github.com/strangelove-ventures/noble-cctp-relayer"
+
+	// exportInterval is how often ended spans are exported
+	exportInterval = 5 * time.Second
+	// maxQueuedSpans bounds the spans waiting for export, newer spans are dropped while the collector is unreachable
+	maxQueuedSpans = 4096
+
+	// transferTTL is how long a transfer root span stays open, transfers that never reach a final status, e.g.
+	// txs dropped from the queue, are ended as expired after it
+	transferTTL = 24 * time.Hour
+	// transferSweepInterval is how often expired transfer root spans are ended
+	transferSweepInterval = time.Minute
+)
+
+// global is the tracer spans are recorded with, nil while tracing is disabled
+var global atomic.Pointer[Tracer]
+
+// Tracer records spans of the relay pipeline and exports them in batches to an OTLP/HTTP collector
+type Tracer struct {
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+	logger   log.Logger
+
+	mu sync.Mutex
+	// root span of each transfer by iris lookup id
+	transfers map[string]*transfer
+
+	done chan struct{}
+}
+
+// Init starts exporting spans if an OTLP endpoint is configured and makes the tracer the one spans are
+// recorded with. Returns nil if tracing is disabled or the exporter can't be created.
+func Init(ctx context.Context, cfg types.TracingConfig, logger log.Logger) *Tracer {
+	if cfg.OTLPEndpoint == "" {
+		return nil
+	}
+	logger = logger.With("component", "tracing")
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+
+	url := exportURL(cfg.OTLPEndpoint)
+	exporter, err := newOTLPExporter(ctx, url, cfg.Headers)
+	if err != nil {
+		logger.Error("Unable to create trace exporter, tracing is disabled", "endpoint", url, "error", err)
+		return nil
+	}
+
+	// the batch span processor reports failed exports through the global error handler
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Error("Failed to export spans", "error", err)
+	}))
+
+	t := newTracer(exporter, serviceName, logger)
+	global.Store(t)
+
+	go t.sweepLoop()
+	logger.Info("Exporting traces", "endpoint", url, "service_name", serviceName)
+	return t
+}
+
+func newTracer(exporter sdktrace.SpanExporter, serviceName string, logger log.Logger) *Tracer {
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter,
+			sdktrace.WithBatchTimeout(exportInterval),
+			sdktrace.WithMaxQueueSize(maxQueuedSpans),
+		),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithIDGenerator(transferIDGenerator{}),
+	)
+
+	return &Tracer{
+		provider:  provider,
+		tracer:    provider.Tracer(instrumentationName),
+		logger:    logger,
+		transfers: make(map[string]*transfer),
+		done:      make(chan struct{}),
+	}
+}
+
+// Shutdown stops recording spans and exports the spans that have ended
+func (t *Tracer) Shutdown(ctx context.Context) {
+	if t == nil {
+		return
+	}
+	global.CompareAndSwap(t, nil)
+	close(t.done)
+	if err := t.provider.Shutdown(ctx); err != nil {
+		t.logger.Error("Failed to export spans on shutdown", "error", err)
+	}
+}
+
+func (t *Tracer) sweepLoop() {
+	ticker := time.NewTicker(transferSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+			t.endExpiredTransfers(time.Now())
+		}
+	}
+}
+
+// Span is a timed operation of the relay pipeline. A nil span is a no-op so callers don't need to check
+// whether tracing is enabled.
+type Span struct {
+	span trace.Span
+}
+
+// ContextWithSpan returns ctx carrying span as the parent of spans started from it
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, span.span)
+}
+
+// SpanFromContext returns the span carried by ctx, nil if none
+func SpanFromContext(ctx context.Context) *Span {
+	span := trace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return nil
+	}
+	return &Span{span: span}
+}
+
+// Start starts a span as a child of the span carried by ctx, or a new trace if ctx carries none.
+// kv are attribute key value pairs.
+func Start(ctx context.Context, name string, kv ...any) (context.Context, *Span) {
+	t := global.Load()
+	if t == nil {
+		return ctx, nil
+	}
+
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(attributes(kv)...))
+	return ctx, &Span{span: span}
+}
+
+// SetAttributes adds key value pairs to the span
+func (s *Span) SetAttributes(kv ...any) {
+	if s == nil {
+		return
+	}
+	s.span.SetAttributes(attributes(kv)...)
+}
+
+// RecordError marks the span as failed, a nil err is ignored
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End ends the span and queues it for export. Only the first call has an effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.span.End()
+}
+
+// attributes converts key value pairs to span attributes
+func attributes(kv []any) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		attrs = append(attrs, newAttribute(fmt.Sprint(kv[i]), kv[i+1]))
+	}
+	return attrs
+}
+
+func newAttribute(key string, value any) attribute.KeyValue {
+	switch value := value.(type) {
+	case bool:
+		return attribute.Bool(key, value)
+	case int:
+		return attribute.Int(key, value)
+	case int32:
+		return attribute.Int64(key, int64(value))
+	case int64:
+		return attribute.Int64(key, value)
+	case uint:
+		return attribute.Int64(key, int64(value))
+	case uint32:
+		return attribute.Int64(key, int64(value))
+	case uint64:
+		return attribute.Int64(key, int64(value))
+	case float32:
+		return attribute.Float64(key, float64(value))
+	case float64:
+		return attribute.Float64(key, value)
+	case string:
+		return attribute.String(key, value)
+	default:
+		return attribute.String(key, fmt.Sprint(value))
+	}
+}
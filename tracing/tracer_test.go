@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const testIrisLookupID = "85bbf7e65a5992e6317a61f005e06d9972a033d71b514be183b179e1b47723fe"
+
+// startTestTracer makes a tracer exporting to memory the one spans are recorded with
+func startTestTracer(t *testing.T) (*Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracer := newTracer(exporter, defaultServiceName, log.NewNopLogger())
+	global.Store(tracer)
+	t.Cleanup(func() { global.CompareAndSwap(tracer, nil) })
+	return tracer, exporter
+}
+
+// TestTransferTrace verifies the spans of a transfer share a trace derived from its iris lookup id
+func TestTransferTrace(t *testing.T) {
+	tracer, exporter := startTestTracer(t)
+
+	StartTransfer(testIrisLookupID, time.Now(), "source_domain", types.Domain(0), "nonce", uint64(7))
+	_, span := Start(TransferContext(context.Background(), testIrisLookupID), "broadcast")
+	span.RecordError(errors.New("out of gas"))
+	span.End()
+	EndTransfer(testIrisLookupID, types.Complete)
+
+	require.NoError(t, tracer.provider.ForceFlush(context.Background()))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	child, root := spans[0], spans[1]
+	require.Equal(t, "broadcast", child.Name)
+	require.Equal(t, "transfer", root.Name)
+	require.Equal(t, testIrisLookupID[:32], root.SpanContext.TraceID().String())
+	require.Equal(t, root.SpanContext.TraceID(), child.SpanContext.TraceID())
+	require.Equal(t, root.SpanContext.SpanID(), child.Parent.SpanID())
+	require.False(t, root.Parent.IsValid())
+	require.Equal(t, codes.Error, child.Status.Code)
+	require.Equal(t, "out of gas", child.Status.Description)
+	require.Equal(t, codes.Unset, root.Status.Code)
+	require.Contains(t, root.Attributes, attribute.Int64("nonce", 7))
+	require.Contains(t, root.Attributes, attribute.String("status", types.Complete))
+
+	serviceName, ok := root.Resource.Set().Value("service.name")
+	require.True(t, ok)
+	require.Equal(t, defaultServiceName, serviceName.AsString())
+
+	tracer.Shutdown(context.Background())
+}
+
+// TestExpiredTransfers verifies transfers that never end are ended as expired after the transfer ttl
+func TestExpiredTransfers(t *testing.T) {
+	tracer, exporter := startTestTracer(t)
+
+	StartTransfer(testIrisLookupID, time.Time{})
+	require.Zero(t, tracer.endExpiredTransfers(time.Now()))
+	require.Equal(t, 1, tracer.endExpiredTransfers(time.Now().Add(transferTTL)))
+	require.Empty(t, tracer.transfers)
+
+	require.NoError(t, tracer.provider.ForceFlush(context.Background()))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Contains(t, spans[0].Attributes, attribute.String("status", "expired"))
+
+	// ending the expired transfer again is a no-op
+	EndTransfer(testIrisLookupID, types.Complete)
+	tracer.Shutdown(context.Background())
+}
+
+// TestOTLPExport verifies spans are posted to the collector's traces endpoint with the configured headers
+func TestOTLPExport(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+	}))
+	defer server.Close()
+
+	tracer := Init(context.Background(), types.TracingConfig{
+		OTLPEndpoint: server.URL,
+		Headers:      map[string]string{"Authorization": "secret"},
+	}, log.NewNopLogger())
+	require.NotNil(t, tracer)
+
+	_, span := Start(context.Background(), "broadcast")
+	span.End()
+	tracer.Shutdown(context.Background())
+
+	r := <-requests
+	require.Equal(t, "/v1/traces", r.URL.Path)
+	require.Equal(t, "secret", r.Header.Get("Authorization"))
+}
+
+// TestDisabled verifies spans are no-ops without an OTLP endpoint
+func TestDisabled(t *testing.T) {
+	require.Nil(t, Init(context.Background(), types.TracingConfig{}, log.NewNopLogger()))
+
+	StartTransfer(testIrisLookupID, time.Time{})
+	ctx, span := Start(TransferContext(context.Background(), testIrisLookupID), "broadcast")
+	require.Nil(t, span)
+	require.Nil(t, SpanFromContext(ctx))
+	span.SetAttributes("key", "value")
+	span.End()
+	EndTransfer(testIrisLookupID, types.Complete)
+}
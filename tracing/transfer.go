@@ -0,0 +1,136 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// transfer is the open root span of a transfer
+type transfer struct {
+	span    *Span
+	started time.Time
+}
+
+// StartTransfer starts the root span of a transfer if it is not active yet. The trace id is derived from
+// the iris lookup id so the trace of a transfer can be looked up by it. A zero start defaults to now.
+func StartTransfer(irisLookupID string, start time.Time, kv ...any) {
+	t := global.Load()
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.transfers[irisLookupID]; ok {
+		return
+	}
+
+	now := time.Now()
+	if start.IsZero() {
+		start = now
+	}
+	ctx := context.WithValue(context.Background(), transferTraceKey{}, transferTraceID(irisLookupID))
+	_, span := t.tracer.Start(ctx, "transfer",
+		trace.WithNewRoot(),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(newAttribute("iris_lookup_id", irisLookupID)),
+		trace.WithAttributes(attributes(kv)...),
+	)
+	t.transfers[irisLookupID] = &transfer{span: &Span{span: span}, started: now}
+}
+
+// TransferContext returns ctx carrying the root span of a transfer, ctx itself if the transfer has none
+func TransferContext(ctx context.Context, irisLookupID string) context.Context {
+	t := global.Load()
+	if t == nil {
+		return ctx
+	}
+
+	t.mu.Lock()
+	tr, ok := t.transfers[irisLookupID]
+	t.mu.Unlock()
+	if !ok {
+		return ctx
+	}
+	return ContextWithSpan(ctx, tr.span)
+}
+
+// EndTransfer ends the root span of a transfer with its final status
+func EndTransfer(irisLookupID, status string) {
+	t := global.Load()
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	tr, ok := t.transfers[irisLookupID]
+	delete(t.transfers, irisLookupID)
+	t.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	tr.span.SetAttributes("status", status)
+	tr.span.End()
+}
+
+// endExpiredTransfers ends the root spans of transfers started longer than the transfer ttl before now, so
+// transfers that never reach a final status don't stay in memory
+func (t *Tracer) endExpiredTransfers(now time.Time) int {
+	t.mu.Lock()
+	var expired []*transfer
+	for irisLookupID, tr := range t.transfers {
+		if now.Sub(tr.started) >= transferTTL {
+			expired = append(expired, tr)
+			delete(t.transfers, irisLookupID)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, tr := range expired {
+		tr.span.SetAttributes("status", "expired")
+		tr.span.End()
+	}
+	return len(expired)
+}
+
+type transferTraceKey struct{}
+
+// transferIDGenerator uses the trace id of a transfer for its root span and random ids otherwise
+type transferIDGenerator struct{}
+
+func (transferIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	traceID, ok := ctx.Value(transferTraceKey{}).(trace.TraceID)
+	if !ok {
+		_, _ = rand.Read(traceID[:])
+	}
+	return traceID, newSpanID()
+}
+
+func (transferIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	return newSpanID()
+}
+
+func newSpanID() trace.SpanID {
+	var id trace.SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// transferTraceID returns the first 16 bytes of the iris lookup id, or of its hash if it isn't a hex hash
+func transferTraceID(irisLookupID string) trace.TraceID {
+	var id trace.TraceID
+	decoded, err := hex.DecodeString(strings.TrimPrefix(irisLookupID, "0x"))
+	if err != nil || len(decoded) < len(id) {
+		sum := sha256.Sum256([]byte(irisLookupID))
+		decoded = sum[:]
+	}
+	copy(id[:], decoded)
+	return id
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+)
+
+// FormatAmount formats an amount in the token's base units as an exact decimal string scaled by the token decimals,
+// without trailing zeros, e.g. 1500000 with 6 decimals is "1.5"
+func FormatAmount(amount *big.Int, decimals int) string {
+	if amount == nil {
+		return ""
+	}
+	if decimals <= 0 {
+		return amount.String()
+	}
+
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	formatted := whole
+	if frac != "" {
+		formatted += "." + frac
+	}
+	if amount.Sign() < 0 {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
+
+// FormatBurnAmount formats the burn amount with the configured decimals of the burn token
+func (c *Config) FormatBurnAmount(burnToken []byte, amount *big.Int) string {
+	return FormatAmount(amount, c.TokenExponent(burnToken))
+}
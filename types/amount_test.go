@@ -0,0 +1,62 @@
+package types_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		decimals int
+		expected string
+	}{
+		{1500000, 6, "1.5"},
+		{1000000, 6, "1"},
+		{1, 6, "0.000001"},
+		{0, 6, "0"},
+		{123456789, 6, "123.456789"},
+		{-2500000, 6, "-2.5"},
+		{42, 0, "42"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, types.FormatAmount(big.NewInt(tt.amount), tt.decimals), "amount %d decimals %d", tt.amount, tt.decimals)
+	}
+
+	// amounts beyond float64 precision stay exact
+	amount, _ := new(big.Int).SetString("123456789012345678901", 10)
+	require.Equal(t, "123.456789012345678901", types.FormatAmount(amount, 18))
+
+	require.Equal(t, "", types.FormatAmount(nil, 6))
+}
+
+func TestFormatBurnAmount(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	cfg := &types.Config{TokenExponents: map[string]int{token.Hex(): 18}}
+
+	require.Equal(t, "0.0000000000015", cfg.FormatBurnAmount(common.LeftPadBytes(token.Bytes(), 32), big.NewInt(1500000)))
+	require.Equal(t, "1.5", cfg.FormatBurnAmount(make([]byte, 32), big.NewInt(1500000)))
+}
+
+func TestParseBurnToken(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+
+	padded, err := types.ParseBurnToken(token.Hex())
+	require.NoError(t, err)
+	require.Equal(t, common.LeftPadBytes(token.Bytes(), 32), padded)
+
+	padded, err = types.ParseBurnToken(common.Bytes2Hex(common.LeftPadBytes(token.Bytes(), 32)))
+	require.NoError(t, err)
+	require.Equal(t, common.LeftPadBytes(token.Bytes(), 32), padded)
+
+	_, err = types.ParseBurnToken("0x" + strings.Repeat("ab", 33))
+	require.ErrorContains(t, err, "longer than 32 bytes")
+	_, err = types.ParseBurnToken("not hex")
+	require.Error(t, err)
+}
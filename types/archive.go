@@ -0,0 +1,68 @@
+package types
+
+import (
+	"slices"
+	"sync"
+)
+
+// StateArchive holds txs swept from the State after all their messages reached a terminal status, so they can
+// still be looked up and aren't relayed again if re-emitted by a flush. It keeps at most size txs, evicting the
+// oldest archived first. It is safe for concurrent use.
+type StateArchive struct {
+	size int
+
+	mu  sync.Mutex
+	txs map[string]*TxState
+	// tx hashes in the order they were archived
+	order []string
+}
+
+func NewStateArchive(size int) *StateArchive {
+	return &StateArchive{
+		size: size,
+		txs:  make(map[string]*TxState),
+	}
+}
+
+// Store archives tx, evicting the oldest archived txs beyond the archive size
+func (a *StateArchive) Store(tx *TxState) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.txs[tx.TxHash]; !ok {
+		a.order = append(a.order, tx.TxHash)
+	}
+	a.txs[tx.TxHash] = tx
+
+	for len(a.order) > a.size {
+		delete(a.txs, a.order[0])
+		a.order = a.order[1:]
+	}
+}
+
+// Load returns the archived tx with the hash
+func (a *StateArchive) Load(txHash string) (*TxState, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tx, ok := a.txs[txHash]
+	return tx, ok
+}
+
+// Remove removes the tx from the archive, e.g. when it is moved back to the State to be retried
+func (a *StateArchive) Remove(txHash string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.txs[txHash]; !ok {
+		return
+	}
+	delete(a.txs, txHash)
+	a.order = slices.DeleteFunc(a.order, func(hash string) bool { return hash == txHash })
+}
+
+func (a *StateArchive) Len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.txs)
+}
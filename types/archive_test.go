@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateArchive(t *testing.T) {
+	archive := NewStateArchive(2)
+	archive.Store(&TxState{TxHash: "a"})
+	archive.Store(&TxState{TxHash: "b"})
+	archive.Store(&TxState{TxHash: "b"})
+	require.Equal(t, 2, archive.Len())
+
+	// the oldest archived tx is evicted
+	archive.Store(&TxState{TxHash: "c"})
+	require.Equal(t, 2, archive.Len())
+	_, ok := archive.Load("a")
+	require.False(t, ok)
+	tx, ok := archive.Load("c")
+	require.True(t, ok)
+	require.Equal(t, "c", tx.TxHash)
+
+	archive.Remove("b")
+	archive.Store(&TxState{TxHash: "d"})
+	require.Equal(t, 2, archive.Len())
+	_, ok = archive.Load("c")
+	require.True(t, ok)
+}
@@ -1,12 +1,53 @@
 package types
 
-import "encoding/json"
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AttestationSignatureLength is the length of each ECDSA signature concatenated in an attestation
+const AttestationSignatureLength = 65
+
+// NormalizeAttestation returns the attestation as lowercase hex with the 0x prefix, accepting it with or without
+// the prefix in any casing. It returns an error if the attestation isn't hex encoded signatures.
+func NormalizeAttestation(attestation string) (string, error) {
+	attestation = strings.TrimSpace(attestation)
+	attestation = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(attestation, "0x"), "0X"))
+	if attestation == "" {
+		return "", errors.New("attestation is empty")
+	}
+	if _, err := hex.DecodeString(attestation); err != nil {
+		return "", fmt.Errorf("attestation is not valid hex: %w", err)
+	}
+	if length := len(attestation) / 2; length%AttestationSignatureLength != 0 {
+		return "", fmt.Errorf("attestation length %d is not a multiple of the %d byte signature length", length, AttestationSignatureLength)
+	}
+	return "0x" + attestation, nil
+}
+
+// DecodeAttestation decodes a hex encoded attestation, with or without the 0x prefix
+func DecodeAttestation(attestation string) ([]byte, error) {
+	attestation = strings.TrimPrefix(strings.TrimPrefix(attestation, "0x"), "0X")
+	if attestation == "" {
+		return nil, errors.New("attestation is empty")
+	}
+	bz, err := hex.DecodeString(attestation)
+	if err != nil {
+		return nil, fmt.Errorf("attestation is not valid hex: %w", err)
+	}
+	return bz, nil
+}
 
 // AttestationResponse is the response received from Circle's iris api
 // Example: https://iris-api-sandbox.circle.com/attestations/0x85bbf7e65a5992e6317a61f005e06d9972a033d71b514be183b179e1b47723fe
 type AttestationResponse struct {
 	Attestation string `json:"attestation"`
 	Status      string `json:"status"`
+	// hex encoded message the attestation was returned for, only set by the v2 api
+	Message string `json:"message,omitempty"`
 }
 
 // AttestationResponseV2 is the response received from Circle's iris api v2 messages endpoint
@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestDecodeAttestation(t *testing.T) {
+	expected := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	for _, attestation := range []string{"0xdeadbeef", "deadbeef", "0XDEADBEEF"} {
+		bz, err := types.DecodeAttestation(attestation)
+		require.NoError(t, err, attestation)
+		require.Equal(t, expected, bz, attestation)
+	}
+
+	for _, invalid := range []string{"", "0x", "0xdeadbee", "0xnothex", "PENDING"} {
+		_, err := types.DecodeAttestation(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestNormalizeAttestation(t *testing.T) {
+	signature := strings.Repeat("ab", types.AttestationSignatureLength)
+	expected := "0x" + signature + signature
+
+	for _, attestation := range []string{
+		"0x" + signature + signature,
+		signature + signature,
+		"0X" + strings.ToUpper(signature+signature),
+		" 0x" + signature + strings.ToUpper(signature) + "\n",
+	} {
+		normalized, err := types.NormalizeAttestation(attestation)
+		require.NoError(t, err, attestation)
+		require.Equal(t, expected, normalized, attestation)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"0x",
+		"PENDING",
+		"0x" + signature[:len(signature)-1], // odd length
+		"0x" + signature[:len(signature)-2], // truncated signature
+		"0x" + signature[:len(signature)-2] + "zz",      // not hex
+		"0x" + signature + signature[:len(signature)/2], // partial second signature
+	} {
+		_, err := types.NormalizeAttestation(invalid)
+		require.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// blockTimeWindow is the number of latest height observations the average block time is computed over
+const blockTimeWindow = 20
+
+type heightObservation struct {
+	height uint64
+	at     time.Time
+}
+
+// BlockTimeTracker keeps a rolling average of a chain's block time from the latest heights polled from it
+type BlockTimeTracker struct {
+	mu           sync.Mutex
+	observations []heightObservation
+}
+
+// Observe records the chain's latest height at the time it was queried. Repeated heights are ignored, a lower
+// height (e.g. after failing over to a lagging rpc) restarts the average.
+func (t *BlockTimeTracker) Observe(height uint64, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if n := len(t.observations); n > 0 {
+		last := t.observations[n-1]
+		if height == last.height {
+			return
+		}
+		if height < last.height {
+			t.observations = t.observations[:0]
+		}
+	}
+
+	t.observations = append(t.observations, heightObservation{height: height, at: at})
+	if len(t.observations) > blockTimeWindow {
+		t.observations = t.observations[len(t.observations)-blockTimeWindow:]
+	}
+}
+
+// Average returns the average block time over the observed heights, 0 until two heights have been observed
+func (t *BlockTimeTracker) Average() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.observations) < 2 {
+		return 0
+	}
+	first, last := t.observations[0], t.observations[len(t.observations)-1]
+	return last.at.Sub(first.at) / time.Duration(last.height-first.height)
+}
+
+// BlocksIn returns the number of blocks expected to be produced in d, rounded up. Returns false if the block
+// time isn't known yet.
+func BlocksIn(d, blockTime time.Duration) (uint64, bool) {
+	if blockTime <= 0 {
+		return 0, false
+	}
+	return uint64((d + blockTime - 1) / blockTime), true
+}
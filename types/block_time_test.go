@@ -0,0 +1,46 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestBlockTimeTracker(t *testing.T) {
+	var tracker types.BlockTimeTracker
+	start := time.Now()
+
+	tracker.Observe(100, start)
+	require.Zero(t, tracker.Average())
+
+	// repeated heights don't count as blocks
+	tracker.Observe(100, start.Add(6*time.Second))
+	tracker.Observe(105, start.Add(60*time.Second))
+	require.Equal(t, 12*time.Second, tracker.Average())
+
+	// only the latest observations are averaged
+	for i := 1; i <= 30; i++ {
+		tracker.Observe(105+uint64(i), start.Add(60*time.Second+time.Duration(i)*2*time.Second))
+	}
+	require.Equal(t, 2*time.Second, tracker.Average())
+
+	// a lower height restarts the average
+	tracker.Observe(50, start)
+	require.Zero(t, tracker.Average())
+}
+
+func TestBlocksIn(t *testing.T) {
+	blocks, ok := types.BlocksIn(time.Minute, 12*time.Second)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), blocks)
+
+	blocks, ok = types.BlocksIn(time.Minute, 7*time.Second)
+	require.True(t, ok)
+	require.Equal(t, uint64(9), blocks)
+
+	_, ok = types.BlocksIn(time.Minute, 0)
+	require.False(t, ok)
+}
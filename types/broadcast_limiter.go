@@ -0,0 +1,65 @@
+package types
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBroadcastConcurrency is the number of concurrent broadcasts allowed to a destination domain without
+// a broadcast-concurrency entry
+const DefaultBroadcastConcurrency = 4
+
+// BroadcastLimiter bounds the number of concurrent broadcasts to each destination domain so a burst of attested
+// messages doesn't overload the destination's RPC or minter account sequence.
+type BroadcastLimiter struct {
+	limits map[Domain]int
+
+	mu sync.Mutex
+	// destination domain -> semaphore holding a token per in flight broadcast
+	sems map[Domain]chan struct{}
+}
+
+// NewBroadcastLimiter creates a limiter allowing limits[domain] concurrent broadcasts to each domain,
+// DefaultBroadcastConcurrency for domains without a positive limit
+func NewBroadcastLimiter(limits map[Domain]int) *BroadcastLimiter {
+	return &BroadcastLimiter{
+		limits: limits,
+		sems:   map[Domain]chan struct{}{},
+	}
+}
+
+func (l *BroadcastLimiter) sem(domain Domain) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sem, ok := l.sems[domain]
+	if !ok {
+		limit := l.limits[domain]
+		if limit <= 0 {
+			limit = DefaultBroadcastConcurrency
+		}
+		sem = make(chan struct{}, limit)
+		l.sems[domain] = sem
+	}
+	return sem
+}
+
+// Acquire blocks until a broadcast to the domain is allowed, returning the context error if it is done first.
+// Every successful Acquire must be followed by a Release.
+func (l *BroadcastLimiter) Acquire(ctx context.Context, domain Domain) error {
+	select {
+	case l.sem(domain) <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees the broadcast slot taken by Acquire
+func (l *BroadcastLimiter) Release(domain Domain) {
+	<-l.sem(domain)
+}
+
+// InFlight returns the number of broadcasts to the domain currently holding a slot
+func (l *BroadcastLimiter) InFlight(domain Domain) int {
+	return len(l.sem(domain))
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastLimiter(t *testing.T) {
+	l := NewBroadcastLimiter(map[Domain]int{4: 1})
+	ctx := context.Background()
+
+	require.NoError(t, l.Acquire(ctx, 4))
+	require.Equal(t, 1, l.InFlight(4))
+
+	// the second broadcast to domain 4 waits for the first to release
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, l.Acquire(timeoutCtx, 4), context.DeadlineExceeded)
+
+	// domains without a limit use the default
+	for i := 0; i < DefaultBroadcastConcurrency; i++ {
+		require.NoError(t, l.Acquire(ctx, 0))
+	}
+	require.Equal(t, DefaultBroadcastConcurrency, l.InFlight(0))
+
+	l.Release(4)
+	require.Equal(t, 0, l.InFlight(4))
+	require.NoError(t, l.Acquire(ctx, 4))
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token bucket refilling Rate mints per second up to Burst, a zero Rate disables the limit
+type RateLimit struct {
+	Rate  float64 `yaml:"rate"`  // mints per second
+	Burst int     `yaml:"burst"` // mints allowed at once after being idle (default: 1)
+}
+
+// BroadcastRateLimits caps the broadcast throughput across all destinations and per destination domain
+type BroadcastRateLimits struct {
+	RateLimit `yaml:",inline"`
+
+	// dest domain -> rate limit of broadcasts to the domain
+	Domains map[Domain]RateLimit `yaml:"domains"`
+}
+
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	burst := float64(max(limit.Burst, 1))
+	return &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// wait returns how long until the bucket has a token, 0 if it has one now
+func (b *tokenBucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// BroadcastRateLimiter caps the rate of mints broadcast globally and to each destination domain so integrations
+// with strict downstream quotas aren't exceeded. It doesn't block, callers requeue the mints that aren't allowed.
+type BroadcastRateLimiter struct {
+	mu      sync.Mutex
+	global  *tokenBucket
+	domains map[Domain]*tokenBucket
+
+	now func() time.Time
+}
+
+// NewBroadcastRateLimiter creates a limiter from the configured limits, limits with a zero rate are not enforced
+func NewBroadcastRateLimiter(limits BroadcastRateLimits) *BroadcastRateLimiter {
+	l := &BroadcastRateLimiter{
+		domains: map[Domain]*tokenBucket{},
+		now:     time.Now,
+	}
+	now := l.now()
+	if limits.Rate > 0 {
+		l.global = newTokenBucket(limits.RateLimit, now)
+	}
+	for domain, limit := range limits.Domains {
+		if limit.Rate > 0 {
+			l.domains[domain] = newTokenBucket(limit, now)
+		}
+	}
+	return l
+}
+
+// Take takes a token from the global and the domain's bucket for each of up to n mints to the domain and returns
+// how many are allowed now. A batch is split if the buckets hold fewer tokens than mints, if not all are allowed
+// it also returns how long until the next one would be.
+func (l *BroadcastRateLimiter) Take(domain Domain, n int) (int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	allowed := n
+	buckets := []*tokenBucket{l.global, l.domains[domain]}
+	for _, b := range buckets {
+		if b == nil {
+			continue
+		}
+		b.refill(now)
+		allowed = min(allowed, int(b.tokens))
+	}
+	for _, b := range buckets {
+		if b != nil {
+			b.tokens -= float64(allowed)
+		}
+	}
+	if allowed == n {
+		return allowed, 0
+	}
+
+	var wait time.Duration
+	for _, b := range buckets {
+		if b != nil {
+			wait = max(wait, b.wait())
+		}
+	}
+	return allowed, wait
+}
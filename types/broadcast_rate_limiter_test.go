@@ -0,0 +1,48 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewBroadcastRateLimiter(BroadcastRateLimits{
+		RateLimit: RateLimit{Rate: 10, Burst: 3},
+		Domains:   map[Domain]RateLimit{4: {Rate: 1}},
+	})
+	l.now = func() time.Time { return now }
+
+	// domain 4 allows a single mint per second
+	allowed, _ := l.Take(4, 1)
+	require.Equal(t, 1, allowed)
+	allowed, wait := l.Take(4, 1)
+	require.Equal(t, 0, allowed)
+	require.Equal(t, time.Second, wait)
+
+	// a rate limited broadcast doesn't take a global token, 2 are left of the burst of 3
+	require.Equal(t, 2.0, l.global.tokens)
+
+	// a batch takes a token per mint and is split when the global burst runs out
+	allowed, wait = l.Take(0, 3)
+	require.Equal(t, 2, allowed)
+	require.Equal(t, 100*time.Millisecond, wait)
+	allowed, _ = l.Take(1, 1)
+	require.Equal(t, 0, allowed)
+
+	now = now.Add(time.Second)
+	allowed, wait = l.Take(4, 1)
+	require.Equal(t, 1, allowed)
+	require.Zero(t, wait)
+	allowed, _ = l.Take(0, 2)
+	require.Equal(t, 2, allowed)
+
+	// without limits every mint is allowed
+	l = NewBroadcastRateLimiter(BroadcastRateLimits{})
+	for i := 0; i < 100; i++ {
+		allowed, _ = l.Take(4, 10)
+		require.Equal(t, 10, allowed)
+	}
+}
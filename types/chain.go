@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"cosmossdk.io/log"
@@ -23,6 +24,9 @@ type Chain interface {
 	// SetLatestBlock sets the latest block
 	SetLatestBlock(block uint64)
 
+	// BlockTime returns the rolling average block time observed by TrackLatestBlockHeight, 0 if not known yet.
+	BlockTime() time.Duration
+
 	// LastFlushedBlock returns the last block included in a flush. In the rare situation of a crash,
 	// this block is a good block to start at to catch up on any missed transactions.
 	LastFlushedBlock() uint64
@@ -55,6 +59,7 @@ type Chain interface {
 		processingQueue chan *TxState,
 		flushOnlyMode bool,
 		flushInterval time.Duration,
+		metrics *relayer.PromMetrics,
 	)
 
 	// Broadcast broadcasts CCTP mint messages to the chain.
@@ -78,3 +83,62 @@ type Chain interface {
 		metrics *relayer.PromMetrics,
 	)
 }
+
+// BalanceReporter is implemented by chains that pay fees from the minter wallet.
+type BalanceReporter interface {
+	// WalletBalance returns the minter wallet balance scaled to the metrics denom.
+	WalletBalance(ctx context.Context) (balance float64, denom string, err error)
+
+	// MinBalanceAlert returns the configured minimum minter balance scaled to the metrics denom, 0 if unset.
+	MinBalanceAlert() float64
+}
+
+// ReorgAware is implemented by source chains that can detect burns removed from the canonical chain by a reorg.
+type ReorgAware interface {
+	// IsReorgedTx returns true if the source tx is no longer on chain.
+	IsReorgedTx(txHash string) bool
+}
+
+// TxFetcher is implemented by source chains that can look up the CCTP messages emitted by a past tx.
+type TxFetcher interface {
+	// FetchTx returns the messages sent by the source tx.
+	FetchTx(ctx context.Context, txHash string) (*TxState, error)
+}
+
+// RecipientValidator is implemented by destination chains that can check a mint recipient exists and can receive
+// the mint before the message is attested and broadcast.
+type RecipientValidator interface {
+	// ValidateMintRecipient returns why the mint recipient of msg is invalid, empty if it is valid. An error means
+	// the recipient couldn't be checked.
+	ValidateMintRecipient(ctx context.Context, msg *MessageState) (string, error)
+}
+
+// MintConfirmer is implemented by destination chains whose broadcast mints must be confirmed before the message is
+// complete.
+type MintConfirmer interface {
+	// WaitForMints waits until the broadcast mints of msgs are confirmed on chain. Mints that reverted are marked
+	// failed. An error means the mints couldn't be confirmed in time. It is called in the background after the
+	// broadcast and must lock the state, see StateLocker, to read or update msgs.
+	WaitForMints(ctx context.Context, logger log.Logger, msgs []*MessageState) error
+}
+
+// ReachabilityChecker is implemented by chains whose clients can connect without contacting every endpoint.
+type ReachabilityChecker interface {
+	// CheckReachability returns an error if an rpc or websocket endpoint does not respond.
+	CheckReachability(ctx context.Context) error
+}
+
+// Resumer is implemented by source chains whose listener can backfill the blocks missed while the relayer was down.
+type Resumer interface {
+	// Resume makes the listener scan from block on startup, if it is past the configured start block. It must be
+	// called before StartListener.
+	Resume(block uint64)
+}
+
+// StateLocker is implemented by chains that update message states outside of Broadcast, e.g. when re-broadcasting
+// a stuck mint.
+type StateLocker interface {
+	// UseStateLock makes the chain hold mu while it reads or writes message states. It must be called before
+	// InitializeBroadcaster.
+	UseStateLock(mu sync.Locker)
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChainType is the family of a chain, which determines how its messages and addresses are handled
+type ChainType string
+
+const (
+	ChainTypeEVM    ChainType = "evm"
+	ChainTypeCosmos ChainType = "cosmos"
+	ChainTypeSolana ChainType = "solana"
+)
+
+// ParseChainType parses a string into ChainType, returns an empty ChainType if s is empty
+func ParseChainType(s string) (ChainType, error) {
+	switch normalized := ChainType(strings.ToLower(strings.TrimSpace(s))); normalized {
+	case "", ChainTypeEVM, ChainTypeCosmos, ChainTypeSolana:
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("invalid chain type %q: must be 'evm', 'cosmos' or 'solana'", s)
+	}
+}
+
+// DomainTypes maps the domain of every configured chain to its chain type
+func (c *Config) DomainTypes() map[Domain]ChainType {
+	domainTypes := make(map[Domain]ChainType, len(c.Chains))
+	for _, chain := range c.Chains {
+		domain, chainType := chain.DomainType()
+		domainTypes[domain] = chainType
+	}
+	return domainTypes
+}
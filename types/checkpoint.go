@@ -0,0 +1,78 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// persistedCheckpoint is the last flushed block of a chain saved to the checkpoint file
+type persistedCheckpoint struct {
+	Domain Domain `json:"domain"`
+	Block  uint64 `json:"block"`
+}
+
+// SaveCheckpoints writes the last flushed block of each chain to path. Chains that haven't flushed yet keep the
+// checkpoint already saved at path. The file is replaced atomically so a crash mid-write doesn't lose the previous
+// checkpoints.
+func SaveCheckpoints(path string, chains map[Domain]Chain) error {
+	blocks, err := LoadCheckpoints(path)
+	if err != nil {
+		return err
+	}
+
+	var checkpoints []persistedCheckpoint
+	for domain, chain := range chains {
+		block := chain.LastFlushedBlock()
+		if block == 0 {
+			block = blocks[domain]
+		}
+		if block != 0 {
+			checkpoints = append(checkpoints, persistedCheckpoint{Domain: domain, Block: block})
+		}
+	}
+
+	bz, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write checkpoint file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write checkpoint file: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadCheckpoints returns the last flushed block of each domain saved at path. A missing file is not an error.
+func LoadCheckpoints(path string) (map[Domain]uint64, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[Domain]uint64{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read checkpoint file: %w", err)
+	}
+
+	var checkpoints []persistedCheckpoint
+	if err := json.Unmarshal(bz, &checkpoints); err != nil {
+		return nil, fmt.Errorf("unable to parse checkpoint file %s: %w", path, err)
+	}
+
+	blocks := make(map[Domain]uint64, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		blocks[checkpoint.Domain] = checkpoint.Block
+	}
+	return blocks, nil
+}
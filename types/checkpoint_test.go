@@ -0,0 +1,53 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// flushedChain is a chain that only reports its last flushed block
+type flushedChain struct {
+	types.Chain
+	lastFlushed uint64
+}
+
+func (c *flushedChain) LastFlushedBlock() uint64 { return c.lastFlushed }
+
+func TestCheckpointPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	// a missing file has no checkpoints
+	blocks, err := types.LoadCheckpoints(path)
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+
+	require.NoError(t, types.SaveCheckpoints(path, map[types.Domain]types.Chain{
+		0: &flushedChain{lastFlushed: 100},
+		4: &flushedChain{lastFlushed: 2000},
+		5: &flushedChain{}, // not flushed yet
+	}))
+
+	blocks, err = types.LoadCheckpoints(path)
+	require.NoError(t, err)
+	require.Equal(t, map[types.Domain]uint64{0: 100, 4: 2000}, blocks)
+
+	// after a restart, chains keep their checkpoint until they flush again
+	require.NoError(t, types.SaveCheckpoints(path, map[types.Domain]types.Chain{
+		0: &flushedChain{lastFlushed: 150},
+		4: &flushedChain{},
+		5: &flushedChain{lastFlushed: 30},
+	}))
+
+	blocks, err = types.LoadCheckpoints(path)
+	require.NoError(t, err)
+	require.Equal(t, map[types.Domain]uint64{0: 150, 4: 2000, 5: 30}, blocks)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = types.LoadCheckpoints(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestRequeueInterval(t *testing.T) {
+	cfg := types.CircleSettings{FetchRetryInterval: 2}
+	require.Equal(t, 2*time.Second, cfg.RequeueInterval(types.RequeuePending))
+	require.Equal(t, 2*time.Second, cfg.RequeueInterval(types.RequeueError))
+	require.Zero(t, cfg.RequeueInterval(types.RequeueBroadcast))
+
+	cfg.PendingPollInterval = 15
+	cfg.BroadcastRequeueWait = 1
+	require.Equal(t, 15*time.Second, cfg.RequeueInterval(types.RequeuePending))
+	require.Equal(t, 2*time.Second, cfg.RequeueInterval(types.RequeueError))
+	require.Equal(t, time.Second, cfg.RequeueInterval(types.RequeueBroadcast))
+}
+
+func TestAPIListenAddress(t *testing.T) {
+	cfg := types.Config{}
+	require.Equal(t, "localhost:8000", cfg.APIListenAddress())
+
+	cfg.API.GRPCPort = 9090
+	require.Equal(t, "localhost:9090", cfg.GRPCListenAddress())
+
+	// the grpc api listens on the same host as the api
+	cfg.API.ListenAddress = "0.0.0.0:9000"
+	require.Equal(t, "0.0.0.0:9000", cfg.APIListenAddress())
+	require.Equal(t, "0.0.0.0:9090", cfg.GRPCListenAddress())
+}
+
+func TestPendingBudget(t *testing.T) {
+	cfg := types.CircleSettings{FetchRetries: 30, FetchRetryInterval: 10, PendingPollInterval: 3}
+	require.Equal(t, 5*time.Minute, cfg.PendingBudget())
+}
+
+func TestSourceAPIVersion(t *testing.T) {
+	cfg := types.CircleSettings{APIVersion: "v1", APIVersions: map[types.Domain]string{0: "v2", 5: "bogus"}}
+
+	version, err := cfg.GetSourceAPIVersion(0)
+	require.NoError(t, err)
+	require.Equal(t, types.APIVersionV2, version)
+
+	// domains without an override use the global api-version
+	version, err = cfg.GetSourceAPIVersion(4)
+	require.NoError(t, err)
+	require.Equal(t, types.APIVersionV1, version)
+
+	_, err = cfg.GetSourceAPIVersion(5)
+	require.Error(t, err)
+
+	require.True(t, cfg.UsesAPIVersion(types.APIVersionV1))
+	require.True(t, cfg.UsesAPIVersion(types.APIVersionV2))
+	require.False(t, (&types.CircleSettings{APIVersion: "v1"}).UsesAPIVersion(types.APIVersionV2))
+}
+
+func TestTokenExponent(t *testing.T) {
+	cfg := types.Config{TokenExponents: map[string]int{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48": 8}}
+
+	token := make([]byte, 32)
+	copy(token[12:], common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48").Bytes())
+	require.Equal(t, 8, cfg.TokenExponent(token))
+
+	// tokens without an exponent default to the 6 decimals of USDC
+	require.Equal(t, 6, cfg.TokenExponent(make([]byte, 32)))
+}
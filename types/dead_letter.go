@@ -0,0 +1,69 @@
+package types
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DeadLetter is a tx whose messages exhausted their retries without completing
+type DeadLetter struct {
+	TxHash        string          `json:"tx_hash"`
+	Msgs          []*MessageState `json:"msgs"`
+	LastError     string          `json:"last_error"`
+	RetryAttempts int             `json:"retry_attempts"`
+	DeadLettered  time.Time       `json:"dead_lettered"`
+}
+
+// DeadLetterStore holds the txs that exhausted their retries until they are requeued. It is safe for concurrent use.
+type DeadLetterStore struct {
+	mu      sync.Mutex
+	letters map[string]*DeadLetter
+}
+
+func NewDeadLetterStore() *DeadLetterStore {
+	return &DeadLetterStore{letters: make(map[string]*DeadLetter)}
+}
+
+// Add records tx as dead-lettered with the last error that kept it from completing, replacing an earlier entry
+func (s *DeadLetterStore) Add(tx *TxState, lastErr error) {
+	letter := &DeadLetter{
+		TxHash:        tx.TxHash,
+		Msgs:          tx.Msgs,
+		RetryAttempts: tx.RetryAttempt,
+		DeadLettered:  time.Now(),
+	}
+	if lastErr != nil {
+		letter.LastError = lastErr.Error()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters[tx.TxHash] = letter
+}
+
+// Remove removes the tx from the store, returns false if it wasn't dead-lettered
+func (s *DeadLetterStore) Remove(txHash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.letters[txHash]
+	delete(s.letters, txHash)
+	return ok
+}
+
+// List returns the dead-lettered txs, oldest first
+func (s *DeadLetterStore) List() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letters := make([]DeadLetter, 0, len(s.letters))
+	for _, letter := range s.letters {
+		letters = append(letters, *letter)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].DeadLettered.Before(letters[j].DeadLettered)
+	})
+	return letters
+}
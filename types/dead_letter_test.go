@@ -0,0 +1,31 @@
+package types_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestDeadLetterStore(t *testing.T) {
+	s := types.NewDeadLetterStore()
+	require.Empty(t, s.List())
+
+	first := &types.TxState{TxHash: "0x01", RetryAttempt: 10, Msgs: []*types.MessageState{{Nonce: 1}}}
+	s.Add(first, errors.New("attestation is still processing"))
+	s.Add(&types.TxState{TxHash: "0x02"}, nil)
+
+	letters := s.List()
+	require.Len(t, letters, 2)
+	require.Equal(t, "0x01", letters[0].TxHash)
+	require.Equal(t, "attestation is still processing", letters[0].LastError)
+	require.Equal(t, 10, letters[0].RetryAttempts)
+	require.Equal(t, first.Msgs, letters[0].Msgs)
+	require.Empty(t, letters[1].LastError)
+
+	require.True(t, s.Remove("0x01"))
+	require.False(t, s.Remove("0x01"))
+	require.Len(t, s.List(), 1)
+}
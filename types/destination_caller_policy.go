@@ -0,0 +1,77 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DestinationCallerPolicy sets which destination domains only relay messages whose destination caller is the
+// relayer's minter. It is configured either as a single value for every destination domain:
+//
+//	destination-caller-only: true
+//
+// or per destination domain, with an optional default for the domains not listed:
+//
+//	destination-caller-only:
+//	  default: false
+//	  5: true   # solana, only relay transfers sent to our minter
+type DestinationCallerPolicy struct {
+	Default   bool
+	PerDomain map[Domain]bool
+}
+
+// For returns whether a destination domain requires the relayer as destination caller, the default if the domain
+// has no setting
+func (p DestinationCallerPolicy) For(domain Domain) bool {
+	if only, ok := p.PerDomain[domain]; ok {
+		return only
+	}
+	return p.Default
+}
+
+// RequiresCaller returns whether messages to a destination domain without a destination caller are dropped as well.
+// Only the domains listed as destination caller only drop them, the default keeps relaying them like it did before
+// the policy could be set per domain.
+func (p DestinationCallerPolicy) RequiresCaller(domain Domain) bool {
+	return p.PerDomain[domain]
+}
+
+func (p *DestinationCallerPolicy) UnmarshalYAML(unmarshal func(any) error) error {
+	var only bool
+	if err := unmarshal(&only); err == nil {
+		*p = DestinationCallerPolicy{Default: only}
+		return nil
+	}
+
+	var perDomain map[string]bool
+	if err := unmarshal(&perDomain); err != nil {
+		return fmt.Errorf("destination caller policy must be a bool or a map of domain to bool: %w", err)
+	}
+
+	policy := DestinationCallerPolicy{PerDomain: make(map[Domain]bool, len(perDomain))}
+	for key, only := range perDomain {
+		if key == "default" {
+			policy.Default = only
+			continue
+		}
+		domain, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid destination caller policy domain %q", key)
+		}
+		policy.PerDomain[Domain(domain)] = only
+	}
+	*p = policy
+	return nil
+}
+
+func (p DestinationCallerPolicy) MarshalYAML() (any, error) {
+	if len(p.PerDomain) == 0 {
+		return p.Default, nil
+	}
+
+	perDomain := map[string]bool{"default": p.Default}
+	for domain, only := range p.PerDomain {
+		perDomain[fmt.Sprint(domain)] = only
+	}
+	return perDomain, nil
+}
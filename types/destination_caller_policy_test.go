@@ -0,0 +1,39 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestDestinationCallerPolicyScalar(t *testing.T) {
+	var cfg types.ConfigWrapper
+	require.NoError(t, yaml.Unmarshal([]byte("destination-caller-only: true"), &cfg))
+
+	require.True(t, cfg.DestinationCallerOnly.For(0))
+	require.True(t, cfg.DestinationCallerOnly.For(5))
+}
+
+func TestDestinationCallerPolicyPerDomain(t *testing.T) {
+	var cfg types.ConfigWrapper
+	require.NoError(t, yaml.Unmarshal([]byte(`
+destination-caller-only:
+  default: false
+  5: true
+`), &cfg))
+
+	require.True(t, cfg.DestinationCallerOnly.For(5))
+	require.False(t, cfg.DestinationCallerOnly.For(0))
+
+	// round trips through the config command output
+	out, err := yaml.Marshal(cfg.DestinationCallerOnly)
+	require.NoError(t, err)
+	var roundTrip types.DestinationCallerPolicy
+	require.NoError(t, yaml.Unmarshal(out, &roundTrip))
+	require.Equal(t, cfg.DestinationCallerOnly, roundTrip)
+
+	require.Error(t, yaml.Unmarshal([]byte("destination-caller-only: {solana: true}"), &cfg))
+}
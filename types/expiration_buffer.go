@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ExpirationBuffer is the number of blocks before a Fast Transfer attestation expires to re-attest at. It is
+// configured either as a single value for every destination domain:
+//
+//	expiration-buffer-blocks: 100
+//
+// or per destination domain, with an optional default for the domains not listed:
+//
+//	expiration-buffer-blocks:
+//	  default: 100
+//	  0: 25    # ethereum, 12s blocks
+//	  3: 600   # arbitrum, 0.25s blocks
+type ExpirationBuffer struct {
+	Default   uint
+	PerDomain map[Domain]uint
+}
+
+// For returns the buffer for a destination domain, the default if the domain has none
+func (b ExpirationBuffer) For(domain Domain) uint {
+	if blocks, ok := b.PerDomain[domain]; ok {
+		return blocks
+	}
+	return b.Default
+}
+
+func (b *ExpirationBuffer) UnmarshalYAML(unmarshal func(any) error) error {
+	var blocks uint
+	if err := unmarshal(&blocks); err == nil {
+		*b = ExpirationBuffer{Default: blocks}
+		return nil
+	}
+
+	var perDomain map[string]uint
+	if err := unmarshal(&perDomain); err != nil {
+		return fmt.Errorf("expiration buffer must be a number of blocks or a map of domain to blocks: %w", err)
+	}
+
+	buffer := ExpirationBuffer{PerDomain: make(map[Domain]uint, len(perDomain))}
+	for key, blocks := range perDomain {
+		if key == "default" {
+			buffer.Default = blocks
+			continue
+		}
+		domain, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid expiration buffer domain %q", key)
+		}
+		buffer.PerDomain[Domain(domain)] = blocks
+	}
+	*b = buffer
+	return nil
+}
+
+func (b ExpirationBuffer) MarshalYAML() (any, error) {
+	if len(b.PerDomain) == 0 {
+		return b.Default, nil
+	}
+
+	perDomain := map[string]uint{"default": b.Default}
+	for domain, blocks := range b.PerDomain {
+		perDomain[fmt.Sprint(domain)] = blocks
+	}
+	return perDomain, nil
+}
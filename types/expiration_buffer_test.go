@@ -0,0 +1,41 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestExpirationBufferScalar(t *testing.T) {
+	var cfg types.CircleSettings
+	require.NoError(t, yaml.Unmarshal([]byte("expiration-buffer-blocks: 100"), &cfg))
+
+	require.Equal(t, uint(100), cfg.ExpirationBufferBlocks.For(0))
+	require.Equal(t, uint(100), cfg.ExpirationBufferBlocks.For(3))
+}
+
+func TestExpirationBufferPerDomain(t *testing.T) {
+	var cfg types.CircleSettings
+	require.NoError(t, yaml.Unmarshal([]byte(`
+expiration-buffer-blocks:
+  default: 100
+  0: 25
+  3: 600
+`), &cfg))
+
+	require.Equal(t, uint(25), cfg.ExpirationBufferBlocks.For(0))
+	require.Equal(t, uint(600), cfg.ExpirationBufferBlocks.For(3))
+	require.Equal(t, uint(100), cfg.ExpirationBufferBlocks.For(4))
+
+	// round trips through the config command output
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	var roundTrip types.CircleSettings
+	require.NoError(t, yaml.Unmarshal(out, &roundTrip))
+	require.Equal(t, cfg.ExpirationBufferBlocks, roundTrip.ExpirationBufferBlocks)
+
+	require.Error(t, yaml.Unmarshal([]byte("expiration-buffer-blocks: {noble: 10}"), &cfg))
+}
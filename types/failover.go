@@ -0,0 +1,202 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+const (
+	// DefaultFailoverThreshold is the number of consecutive failed requests that fail over to the next rpc endpoint
+	DefaultFailoverThreshold = 3
+	// DefaultPrimaryRecheck is how long requests stay on a fallback rpc endpoint before the primary is tried again
+	DefaultPrimaryRecheck = 5 * time.Minute
+)
+
+// RPCFailover is the transport of a chain's rpc client that sends each request to the active one of its rpc
+// endpoints. Clients are built against the primary endpoint and the request is rewritten to the active endpoint.
+// After threshold consecutive requests fail to connect, get a server error or are rate limited, the next endpoint
+// becomes active. While a fallback is active, a request is sent to the primary every recheck interval and the
+// primary becomes active again once it succeeds. It is safe for concurrent use.
+type RPCFailover struct {
+	base      http.RoundTripper
+	endpoints []*url.URL
+	threshold int
+	recheck   time.Duration
+
+	mu       sync.Mutex
+	active   int
+	failures int
+	switched time.Time // when a fallback became active or the primary was last rechecked
+}
+
+// NewRPCFailover returns the failover of the primary rpc endpoint and its fallbacks, sending requests with base.
+// tcp:// endpoints are sent over http.
+func NewRPCFailover(primary string, fallbacks []string, base http.RoundTripper) (*RPCFailover, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	f := &RPCFailover{
+		base:      base,
+		threshold: DefaultFailoverThreshold,
+		recheck:   DefaultPrimaryRecheck,
+	}
+	for _, endpoint := range append([]string{primary}, fallbacks...) {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rpc endpoint %s: %w", EndpointLabel(endpoint), err)
+		}
+		if u.Scheme == "tcp" {
+			u.Scheme = "http"
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("rpc endpoint %s must be http or https", EndpointLabel(endpoint))
+		}
+		f.endpoints = append(f.endpoints, u)
+	}
+	return f, nil
+}
+
+// Active returns the endpoint requests are sent to
+func (f *RPCFailover) Active() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.endpoints[f.active].String()
+}
+
+// RoundTrip sends req to the active endpoint, or to the primary if it is due to be rechecked
+func (f *RPCFailover) RoundTrip(req *http.Request) (*http.Response, error) {
+	now := time.Now()
+
+	f.mu.Lock()
+	active := f.active
+	recheck := active != 0 && now.Sub(f.switched) >= f.recheck
+	if recheck {
+		// one request rechecks the primary, the others stay on the fallback
+		f.switched = now
+	}
+	f.mu.Unlock()
+
+	if recheck {
+		resp, err := f.send(req, 0)
+		if !isEndpointFailure(resp, err) {
+			f.use(0)
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if req.GetBody == nil {
+			return nil, fmt.Errorf("primary rpc endpoint failed recheck: %w", endpointError(resp, err))
+		}
+
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+
+	resp, err := f.send(req, active)
+	f.record(active, isEndpointFailure(resp, err), now)
+	return resp, err
+}
+
+// send sends req to the endpoint at index
+func (f *RPCFailover) send(req *http.Request, index int) (*http.Response, error) {
+	endpoint := f.endpoints[index]
+	primary := f.endpoints[0]
+
+	out := req.Clone(req.Context())
+	out.URL.Scheme = endpoint.Scheme
+	out.URL.Host = endpoint.Host
+	out.URL.Path = endpoint.Path + strings.TrimPrefix(req.URL.Path, primary.Path)
+	out.URL.RawPath = ""
+	if endpoint.RawQuery != primary.RawQuery {
+		out.URL.RawQuery = endpoint.RawQuery
+	}
+	out.Host = ""
+	if endpoint.User != nil {
+		password, _ := endpoint.User.Password()
+		out.SetBasicAuth(endpoint.User.Username(), password)
+	} else if primary.User != nil {
+		out.Header.Del("Authorization")
+	}
+
+	return f.base.RoundTrip(out)
+}
+
+// use makes the endpoint at index active
+func (f *RPCFailover) use(index int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.active = index
+	f.failures = 0
+}
+
+// record counts a request sent to the endpoint at index, failing over to the next endpoint once threshold
+// consecutive requests failed. Requests to an endpoint that is no longer active aren't counted.
+func (f *RPCFailover) record(index int, failed bool, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if index != f.active {
+		return
+	}
+	if !failed {
+		f.failures = 0
+		return
+	}
+
+	f.failures++
+	if f.failures >= f.threshold && len(f.endpoints) > 1 {
+		f.active = (f.active + 1) % len(f.endpoints)
+		f.failures = 0
+		f.switched = now
+	}
+}
+
+// ReportActive sets the metric of which rpc endpoint of the chain is active
+func (f *RPCFailover) ReportActive(m *relayer.PromMetrics, chain, domain string) {
+	if f == nil || m == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, endpoint := range f.endpoints {
+		m.SetRPCEndpointActive(chain, domain, EndpointLabel(endpoint.String()), i == f.active)
+	}
+}
+
+// isEndpointFailure returns true if the endpoint could not be reached, returned a server error or rate limited
+func isEndpointFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
+
+func endpointError(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return errors.New(resp.Status)
+}
+
+// EndpointLabel returns the scheme and host of an endpoint, leaving out credentials and api keys in its path or query
+func EndpointLabel(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "invalid"
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -2,8 +2,15 @@ package types
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 )
 
 // MessageFilter defines the interface for message filtering plugins
@@ -14,44 +21,201 @@ type MessageFilter interface {
 	Close() error
 }
 
+// ErrHoldMessage is returned, wrapped, by a filter that can't decide on a message yet, e.g. while a screening API
+// is unavailable. The message is neither filtered nor relayed, it is requeued and filtered again.
+var ErrHoldMessage = errors.New("message held by filter")
+
+// DryRunFilter wraps a filter whose matches are logged and counted by the FilterRegistry without filtering the
+// message, to observe a filter against live traffic before enforcing it
+type DryRunFilter struct {
+	MessageFilter
+}
+
+func NewDryRunFilter(filter MessageFilter) *DryRunFilter {
+	return &DryRunFilter{MessageFilter: filter}
+}
+
+// FilterStatus describes a filter and the number of messages it dropped, or would have dropped in dry run
+type FilterStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	DryRun  bool   `json:"dry_run"`
+	Dropped uint64 `json:"dropped"`
+}
+
 // FilterRegistry manages message filters
 type FilterRegistry struct {
+	mu      sync.RWMutex
 	filters []MessageFilter
-	logger  log.Logger
+	// Filter calls running with filters, replaced along with them
+	inFlight *sync.WaitGroup
+	logger   log.Logger
+	metrics  *relayer.PromMetrics
+	// run every filter and report all matches instead of returning on the first match
+	evaluateAll atomic.Bool
+
+	countsMu sync.Mutex
+	// filter name -> messages dropped, kept across Replace
+	counts map[string]uint64
 }
 
-// NewFilterRegistry creates a new filter registry
-func NewFilterRegistry(logger log.Logger) *FilterRegistry {
+// NewFilterRegistry creates a new filter registry. metrics may be nil.
+func NewFilterRegistry(logger log.Logger, metrics *relayer.PromMetrics) *FilterRegistry {
 	return &FilterRegistry{
-		filters: make([]MessageFilter, 0),
-		logger:  logger,
+		filters:  make([]MessageFilter, 0),
+		inFlight: &sync.WaitGroup{},
+		logger:   logger,
+		metrics:  metrics,
+		counts:   make(map[string]uint64),
 	}
 }
 
 func (r *FilterRegistry) Register(filter MessageFilter) {
+	r.mu.Lock()
 	r.filters = append(r.filters, filter)
+	r.mu.Unlock()
 	r.logger.Debug("Registered filter", "name", filter.Name())
 }
 
-func (r *FilterRegistry) Filter(ctx context.Context, msg *MessageState) (shouldFilter bool, reason string) {
-	for _, filter := range r.filters {
+// Replace swaps the registered filters for filters and closes the previous ones. Messages being filtered while the
+// filters are replaced finish with the previous filters, Replace waits for them before closing the previous filters.
+func (r *FilterRegistry) Replace(filters []MessageFilter) {
+	r.mu.Lock()
+	previous, inFlight := r.filters, r.inFlight
+	r.filters, r.inFlight = filters, &sync.WaitGroup{}
+	r.mu.Unlock()
+
+	inFlight.Wait()
+	closeFilters(r.logger, previous)
+}
+
+// SetEvaluateAll sets whether Filter runs every filter and aggregates the matches instead of returning on the
+// first match
+func (r *FilterRegistry) SetEvaluateAll(evaluateAll bool) {
+	r.evaluateAll.Store(evaluateAll)
+}
+
+// Filter runs msg through the registered filters and returns the name and reason of the first filter that
+// matched. With SetEvaluateAll, every filter is run and the names and reasons of all matching filters are
+// returned comma and semicolon separated. A filter holding the message stops the run, its name is returned with
+// an error wrapping ErrHoldMessage and the message must be requeued. Filters can log with the message scoped
+// logger carried by ctx, see LoggerFromContext.
+func (r *FilterRegistry) Filter(ctx context.Context, msg *MessageState) (shouldFilter bool, filteredBy string, reason string, err error) {
+	logger := LoggerFromContext(ctx, MessageLogger(r.logger, msg))
+
+	r.mu.RLock()
+	filters, inFlight := r.filters, r.inFlight
+	inFlight.Add(1)
+	r.mu.RUnlock()
+	defer inFlight.Done()
+
+	var names, reasons []string
+	for _, filter := range filters {
 		filtered, filterReason, err := filter.Filter(ctx, msg)
 		if err != nil {
-			r.logger.Error("Filter error", "filter", filter.Name(), "error", err)
+			// a dry run filter never holds up a message
+			if _, dryRun := filter.(*DryRunFilter); !dryRun && errors.Is(err, ErrHoldMessage) {
+				return false, filter.Name(), "", err
+			}
+			logger.Error("Filter error", "filter", filter.Name(), "error", err)
 			continue
 		}
 		if filtered {
-			return true, filterReason
+			if _, dryRun := filter.(*DryRunFilter); dryRun {
+				// requeued messages are filtered again, only report the first pass
+				if msg.Status == Created {
+					logger.Info("Message would have been filtered (dry run)", "filter", filter.Name(), "reason", filterReason)
+					r.count(filter.Name())
+					if r.metrics != nil {
+						r.metrics.IncFilterDryRunMatches(filter.Name(), fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+					}
+				}
+				continue
+			}
+			// requeued messages are filtered again, only count the first time
+			if msg.Status != Filtered {
+				r.count(filter.Name())
+				if r.metrics != nil {
+					r.metrics.IncFilteredMessages(filter.Name(), fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+				}
+			}
+			if !r.evaluateAll.Load() {
+				return true, filter.Name(), filterReason, nil
+			}
+			names = append(names, filter.Name())
+			reasons = append(reasons, filter.Name()+": "+filterReason)
+		}
+	}
+	if len(names) > 0 {
+		return true, strings.Join(names, ","), strings.Join(reasons, "; "), nil
+	}
+	return false, "", "", nil
+}
+
+// OrderFilters returns filters with the filters named in order first, in that order, followed by the others in
+// their original order. Names in order that match no filter are ignored.
+func OrderFilters(filters []MessageFilter, order []string) []MessageFilter {
+	ordered := make([]MessageFilter, 0, len(filters))
+	placed := make(map[int]bool, len(filters))
+	for _, name := range order {
+		for i, filter := range filters {
+			if !placed[i] && filter.Name() == name {
+				ordered = append(ordered, filter)
+				placed[i] = true
+			}
+		}
+	}
+	for i, filter := range filters {
+		if !placed[i] {
+			ordered = append(ordered, filter)
+		}
+	}
+	return ordered
+}
+
+func (r *FilterRegistry) count(filterName string) {
+	r.countsMu.Lock()
+	r.counts[filterName]++
+	r.countsMu.Unlock()
+}
+
+// Statuses returns the registered filters in the order they are run
+func (r *FilterRegistry) Statuses() []FilterStatus {
+	r.mu.RLock()
+	filters := r.filters
+	r.mu.RUnlock()
+
+	r.countsMu.Lock()
+	defer r.countsMu.Unlock()
+
+	statuses := make([]FilterStatus, len(filters))
+	for i, filter := range filters {
+		_, dryRun := filter.(*DryRunFilter)
+		statuses[i] = FilterStatus{
+			Name:    filter.Name(),
+			Enabled: true,
+			DryRun:  dryRun,
+			Dropped: r.counts[filter.Name()],
 		}
 	}
-	return false, ""
+	return statuses
 }
 
+// Close closes the registered filters once the messages being filtered are done
 func (r *FilterRegistry) Close() error {
-	for _, filter := range r.filters {
+	r.mu.RLock()
+	filters, inFlight := r.filters, r.inFlight
+	r.mu.RUnlock()
+
+	inFlight.Wait()
+	closeFilters(r.logger, filters)
+	return nil
+}
+
+func closeFilters(logger log.Logger, filters []MessageFilter) {
+	for _, filter := range filters {
 		if err := filter.Close(); err != nil {
-			r.logger.Error("Error closing filter", "filter", filter.Name(), "error", err)
+			logger.Error("Error closing filter", "filter", filter.Name(), "error", err)
 		}
 	}
-	return nil
 }
@@ -2,25 +2,33 @@ package types
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 
 	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 )
 
 type MockFilter struct {
 	name         string
 	shouldFilter bool
 	filterReason string
+	err          error
 	closed       bool
 }
 
 func (m *MockFilter) Name() string { return m.name }
 func (m *MockFilter) Filter(ctx context.Context, msg *MessageState) (bool, string, error) {
-	return m.shouldFilter, m.filterReason, nil
+	return m.shouldFilter, m.filterReason, m.err
 }
 func (m *MockFilter) Initialize(ctx context.Context, config map[string]interface{}, logger log.Logger) error {
 	return nil
@@ -39,39 +47,190 @@ func testMsg() *MessageState {
 }
 
 func TestFilterRegistry_Register(t *testing.T) {
-	registry := NewFilterRegistry(testLogger())
+	registry := NewFilterRegistry(testLogger(), nil)
 	registry.Register(&MockFilter{name: "filter1"})
 	registry.Register(&MockFilter{name: "filter2"})
 	require.Len(t, registry.filters, 2)
 }
 
 func TestFilterRegistry_Filter_NoMatch(t *testing.T) {
-	registry := NewFilterRegistry(testLogger())
+	registry := NewFilterRegistry(testLogger(), nil)
 	registry.Register(&MockFilter{name: "test", shouldFilter: false})
-	filtered, reason := registry.Filter(context.Background(), testMsg())
+	filtered, filteredBy, reason, _ := registry.Filter(context.Background(), testMsg())
 	require.False(t, filtered)
+	require.Empty(t, filteredBy)
 	require.Empty(t, reason)
 }
 
 func TestFilterRegistry_Filter_Match(t *testing.T) {
-	registry := NewFilterRegistry(testLogger())
+	registry := NewFilterRegistry(testLogger(), nil)
 	registry.Register(&MockFilter{name: "test", shouldFilter: true, filterReason: "test reason"})
-	filtered, reason := registry.Filter(context.Background(), testMsg())
+	filtered, filteredBy, reason, _ := registry.Filter(context.Background(), testMsg())
 	require.True(t, filtered)
+	require.Equal(t, "test", filteredBy)
 	require.Equal(t, "test reason", reason)
 }
 
+func TestFilterRegistry_Filter_Hold(t *testing.T) {
+	registry := NewFilterRegistry(testLogger(), nil)
+	registry.Register(&MockFilter{name: "holding", err: fmt.Errorf("%w: api down", ErrHoldMessage)})
+	registry.Register(&MockFilter{name: "matching", shouldFilter: true})
+	filtered, filteredBy, _, err := registry.Filter(context.Background(), testMsg())
+	require.ErrorIs(t, err, ErrHoldMessage)
+	require.False(t, filtered)
+	require.Equal(t, "holding", filteredBy)
+
+	// a dry run filter doesn't hold the message, other errors are logged and skipped
+	registry = NewFilterRegistry(testLogger(), nil)
+	registry.Register(NewDryRunFilter(&MockFilter{name: "holding", err: fmt.Errorf("%w: api down", ErrHoldMessage)}))
+	registry.Register(&MockFilter{name: "failing", err: fmt.Errorf("api down")})
+	registry.Register(&MockFilter{name: "matching", shouldFilter: true})
+	filtered, filteredBy, _, err = registry.Filter(context.Background(), testMsg())
+	require.NoError(t, err)
+	require.True(t, filtered)
+	require.Equal(t, "matching", filteredBy)
+}
+
 func TestFilterRegistry_Filter_MultipleFilters(t *testing.T) {
-	registry := NewFilterRegistry(testLogger())
+	registry := NewFilterRegistry(testLogger(), nil)
 	registry.Register(&MockFilter{name: "filter1", shouldFilter: false})
 	registry.Register(&MockFilter{name: "filter2", shouldFilter: true, filterReason: "matched"})
-	filtered, reason := registry.Filter(context.Background(), testMsg())
+	filtered, filteredBy, reason, _ := registry.Filter(context.Background(), testMsg())
 	require.True(t, filtered)
+	require.Equal(t, "filter2", filteredBy)
 	require.Equal(t, "matched", reason)
 }
 
+func TestFilterRegistry_Filter_Metrics(t *testing.T) {
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	registry := NewFilterRegistry(testLogger(), m)
+	registry.Register(&MockFilter{name: "route", shouldFilter: true})
+
+	msg := testMsg()
+	registry.Filter(context.Background(), msg)
+	// an already filtered message is not counted again when requeued
+	msg.Status = Filtered
+	registry.Filter(context.Background(), msg)
+
+	counter := m.FilteredMessages.WithLabelValues("route", fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+	require.Equal(t, 1.0, testutil.ToFloat64(counter))
+}
+
+func TestFilterRegistry_Filter_DryRun(t *testing.T) {
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	registry := NewFilterRegistry(testLogger(), m)
+	registry.Register(NewDryRunFilter(&MockFilter{name: "sanctions", shouldFilter: true, filterReason: "sanctioned"}))
+	registry.Register(&MockFilter{name: "route", shouldFilter: false})
+
+	msg := testMsg()
+	msg.Status = Created
+	filtered, filteredBy, reason, _ := registry.Filter(context.Background(), msg)
+	require.False(t, filtered)
+	require.Empty(t, filteredBy)
+	require.Empty(t, reason)
+
+	// requeued messages are not counted again
+	msg.Status = Pending
+	registry.Filter(context.Background(), msg)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.FilterDryRunMatches.WithLabelValues("sanctions", "0", "4")))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.FilteredMessages.WithLabelValues("sanctions", "0", "4")))
+}
+
+func TestFilterRegistry_Statuses(t *testing.T) {
+	registry := NewFilterRegistry(testLogger(), nil)
+	registry.Register(&MockFilter{name: "route", shouldFilter: true})
+	registry.Register(NewDryRunFilter(&MockFilter{name: "sanctions", shouldFilter: true}))
+
+	msg := testMsg()
+	registry.Filter(context.Background(), msg)
+	registry.Filter(context.Background(), testMsg())
+
+	// counts are kept when the filters are replaced on a config reload
+	registry.Replace([]MessageFilter{&MockFilter{name: "route"}})
+	require.Equal(t, []FilterStatus{{Name: "route", Enabled: true, Dropped: 2}}, registry.Statuses())
+
+	registry.Replace([]MessageFilter{NewDryRunFilter(&MockFilter{name: "sanctions", shouldFilter: true})})
+	msg.Status = Created
+	registry.Filter(context.Background(), msg)
+	require.Equal(t, []FilterStatus{{Name: "sanctions", Enabled: true, DryRun: true, Dropped: 1}}, registry.Statuses())
+}
+
+func TestFilterRegistry_Filter_EvaluateAll(t *testing.T) {
+	registry := NewFilterRegistry(testLogger(), nil)
+	registry.SetEvaluateAll(true)
+	registry.Register(&MockFilter{name: "route", shouldFilter: true, filterReason: "route disabled"})
+	registry.Register(&MockFilter{name: "low-transfer", shouldFilter: false})
+	registry.Register(&MockFilter{name: "sanctions", shouldFilter: true, filterReason: "sanctioned depositor"})
+
+	filtered, filteredBy, reason, _ := registry.Filter(context.Background(), testMsg())
+	require.True(t, filtered)
+	require.Equal(t, "route,sanctions", filteredBy)
+	require.Equal(t, "route: route disabled; sanctions: sanctioned depositor", reason)
+
+	statuses := registry.Statuses()
+	require.Equal(t, uint64(1), statuses[0].Dropped)
+	require.Equal(t, uint64(1), statuses[2].Dropped)
+}
+
+func TestOrderFilters(t *testing.T) {
+	route, lowTransfer, mintRecipient, sanctions := &MockFilter{name: "route"}, &MockFilter{name: "low-transfer"},
+		&MockFilter{name: "mint-recipient"}, &MockFilter{name: "sanctions"}
+	filters := []MessageFilter{route, mintRecipient, sanctions, lowTransfer}
+
+	ordered := OrderFilters(filters, []string{"low-transfer", "unknown", "route"})
+	require.Equal(t, []MessageFilter{lowTransfer, route, mintRecipient, sanctions}, ordered)
+	require.Equal(t, filters, OrderFilters(filters, nil))
+}
+
+// blockingFilter blocks in Filter until release is closed and records when it is closed
+type blockingFilter struct {
+	MockFilter
+	entered chan struct{}
+	release chan struct{}
+	closed  atomic.Bool
+}
+
+func (b *blockingFilter) Filter(ctx context.Context, msg *MessageState) (bool, string, error) {
+	close(b.entered)
+	<-b.release
+	return false, "", nil
+}
+
+func (b *blockingFilter) Close() error {
+	b.closed.Store(true)
+	return nil
+}
+
+// TestFilterRegistry_ReplaceDrains verifies replaced filters are closed only once the messages they are filtering
+// are done
+func TestFilterRegistry_ReplaceDrains(t *testing.T) {
+	registry := NewFilterRegistry(testLogger(), nil)
+	previous := &blockingFilter{MockFilter: MockFilter{name: "sanctions"}, entered: make(chan struct{}), release: make(chan struct{})}
+	registry.Register(previous)
+
+	go registry.Filter(context.Background(), testMsg())
+	<-previous.entered
+
+	replaced := make(chan struct{})
+	go func() {
+		registry.Replace([]MessageFilter{&MockFilter{name: "route"}})
+		close(replaced)
+	}()
+
+	// new messages already run through the new filters while the previous ones drain
+	require.Eventually(t, func() bool {
+		return len(registry.Statuses()) == 1 && registry.Statuses()[0].Name == "route"
+	}, time.Second, 10*time.Millisecond)
+	require.False(t, previous.closed.Load())
+
+	close(previous.release)
+	<-replaced
+	require.True(t, previous.closed.Load())
+}
+
 func TestFilterRegistry_Close(t *testing.T) {
-	registry := NewFilterRegistry(testLogger())
+	registry := NewFilterRegistry(testLogger(), nil)
 	f1, f2 := &MockFilter{name: "f1"}, &MockFilter{name: "f2"}
 	registry.Register(f1)
 	registry.Register(f2)
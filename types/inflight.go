@@ -0,0 +1,43 @@
+package types
+
+import (
+	"sync"
+)
+
+// InFlightSet tracks the messages currently being handled by a processor worker so that
+// the same message is never processed by two workers concurrently.
+type InFlightSet struct {
+	mu sync.Mutex
+	// set of iris lookup ids
+	ids map[string]struct{}
+}
+
+func NewInFlightSet() *InFlightSet {
+	return &InFlightSet{
+		ids: map[string]struct{}{},
+	}
+}
+
+// TryAcquire marks the message as in flight. It returns false if the message is already being handled.
+func (s *InFlightSet) TryAcquire(irisLookupID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.ids[irisLookupID]; ok {
+		return false
+	}
+	s.ids[irisLookupID] = struct{}{}
+	return true
+}
+
+// Release marks the message as no longer in flight.
+func (s *InFlightSet) Release(irisLookupID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.ids, irisLookupID)
+}
+
+func (s *InFlightSet) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.ids)
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlightSet(t *testing.T) {
+	set := NewInFlightSet()
+
+	require.True(t, set.TryAcquire("abc"))
+	require.False(t, set.TryAcquire("abc"))
+	require.True(t, set.TryAcquire("def"))
+	require.Equal(t, 2, set.Len())
+
+	set.Release("abc")
+	require.True(t, set.TryAcquire("abc"))
+}
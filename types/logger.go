@@ -0,0 +1,28 @@
+package types
+
+import (
+	"context"
+
+	"cosmossdk.io/log"
+)
+
+// MessageLogger returns logger scoped to a message. Every line logged while handling the message carries the
+// same iris_lookup_id and nonce fields so a single transfer can be followed through the logs.
+func MessageLogger(logger log.Logger, msg *MessageState) log.Logger {
+	return logger.With("iris_lookup_id", msg.IrisLookupID, "nonce", msg.Nonce)
+}
+
+type loggerKey struct{}
+
+// ContextWithLogger returns ctx carrying logger, used to hand a message scoped logger to filters
+func ContextWithLogger(ctx context.Context, logger log.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger carried by ctx, fallback if it carries none
+func LoggerFromContext(ctx context.Context, fallback log.Logger) log.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(log.Logger); ok {
+		return logger
+	}
+	return fallback
+}
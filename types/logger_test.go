@@ -0,0 +1,29 @@
+package types_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestMessageLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewLogger(&buf, log.OutputJSONOption())
+
+	msg := &types.MessageState{IrisLookupID: "0xabc", Nonce: 42}
+	ctx := types.ContextWithLogger(context.Background(), types.MessageLogger(logger, msg))
+
+	types.LoggerFromContext(ctx, logger).Info("filtered")
+	require.Contains(t, buf.String(), `"iris_lookup_id":"0xabc"`)
+	require.Contains(t, buf.String(), `"nonce":42`)
+
+	buf.Reset()
+	types.LoggerFromContext(context.Background(), logger).Info("unscoped")
+	require.NotContains(t, buf.String(), "iris_lookup_id")
+}
@@ -3,8 +3,11 @@ package types
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 )
 
 // Message defines ...
@@ -30,6 +33,21 @@ type BurnMessage struct {
 	MessageSender []byte
 }
 
+// ParseBurnToken decodes a configured burn token, hex with or without 0x, and left pads it to the 32 bytes burn
+// messages carry it in, so e.g. a 20 byte EVM address matches the BurnToken of its burns
+func ParseBurnToken(token string) ([]byte, error) {
+	bz, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(token), "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) > 32 {
+		return nil, fmt.Errorf("token %s is longer than 32 bytes", token)
+	}
+	padded := make([]byte, 32)
+	copy(padded[32-len(bz):], bz)
+	return padded, nil
+}
+
 // MetadataMessage defines ...
 type MetadataMessage struct {
 	Nonce     uint64
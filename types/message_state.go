@@ -2,8 +2,10 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/circlefin/noble-cctp/x/cctp/types"
@@ -19,41 +21,84 @@ const (
 	Complete string = "complete"
 	Failed   string = "failed"
 	Filtered string = "filtered"
+	// AlreadyMinted is terminal like Complete, for messages whose nonce was already used on the destination, i.e.
+	// minted by someone else, so they aren't counted as relays performed
+	AlreadyMinted string = "already-minted"
+	// SimulatedComplete is terminal like Complete, for attested messages that were only logged instead of broadcast
+	// because the relayer runs with --dry-run
+	SimulatedComplete string = "simulated-complete"
 
 	Mint    string = "mint"
 	Forward string = "forward"
+
+	// FinalityThresholdFinalized is the CCTP v2 finality threshold for standard (hard finality) transfers.
+	// Messages attested below this threshold are Fast Transfers.
+	FinalityThresholdFinalized uint32 = 2000
 )
 
 type Domain uint32
 
+// IsTerminal returns true if a message with the status is not processed anymore
+func IsTerminal(status string) bool {
+	switch status {
+	case Complete, AlreadyMinted, SimulatedComplete, Failed, Filtered:
+		return true
+	}
+	return false
+}
+
 type TxState struct {
 	TxHash       string
 	Msgs         []*MessageState
 	RetryAttempt int
+	// time spent requeued waiting on pending attestations, counted against PendingBudget instead of RetryAttempt
+	PendingWait time.Duration
 }
 
 type MessageState struct {
 	IrisLookupID      string // hex encoded MessageSent bytes
-	Status            string // created, pending, attested, complete, failed, filtered
+	Status            string // created, pending, attested, complete, already-minted, simulated-complete, failed, filtered
+	FilteredBy        string // name of the filter that dropped the message, empty if not filtered
+	FilterReason      string // why the filter dropped the message, empty if not filtered
+	FailureReason     string // why the message failed before it was broadcast, empty if not known
 	Attestation       string // hex encoded attestation
+	AttestedMessage   []byte // message bytes Circle returned the attestation for, nil if not returned (v1 api)
 	SourceDomain      Domain // uint32 source domain id
 	DestDomain        Domain // uint32 destination domain id
 	SourceTxHash      string
 	DestTxHash        string
-	MsgSentBytes      []byte // bytes of the MessageSent message transmitter event
-	MsgBody           []byte // bytes of the MessageBody
-	DestinationCaller []byte // address authorized to call transaction
-	Channel           string // "channel-%d" if a forward, empty if not a forward
+	DestConfirmed     bool             // set once the mint tx succeeded on the destination
+	DestError         string           // why the mint tx failed on the destination, empty if not known
+	DestBlock         uint64           // destination block (slot on solana) the mint tx was included in, 0 if not known
+	MsgSentBytes      []byte           // bytes of the MessageSent message transmitter event
+	MsgBody           []byte           // bytes of the MessageBody
+	DestinationCaller []byte           // address authorized to call transaction
+	Channel           string           // "channel-%d" if a forward, empty if not a forward
+	Metadata          *MetadataMessage // parsed forwarding metadata of the message body, nil for burn messages
 	Created           time.Time
 	Updated           time.Time
 	Nonce             uint64
+	StuckPending      bool      // set once the message stayed pending past circle.stuck-pending-threshold
+	SourceReorged     bool      // set while the source tx is removed from the canonical chain by a reorg
+	SourceBlock       uint64    // source chain block (slot on solana) the message was emitted in, 0 if not known
+	SourceTime        time.Time // source chain block time, zero if not known
+	Amount            string    // burn amount in the token's base units, empty if not a burn message
+	AmountFormatted   string    // burn amount scaled by the token decimals, e.g. "1.5", empty if not a burn message
 
 	// V2/Fast Transfer fields
 	CctpVersion       string
 	ExpirationBlock   uint64 // destination chain block when attestation expires
-	FinalityThreshold uint32
+	FinalityThreshold uint32 // finality threshold the attestation was executed at
 	ReattestCount     uint
 	LastReattestTime  time.Time
+	// set once the message was counted as an expired Fast Transfer
+	FastTransferExpired bool
+
+	// minimum finality threshold requested by the burn, read from the v2 message header, 0 for v1 messages
+	MinFinalityThreshold uint32
+
+	// minimum executed finality threshold the route requires before broadcasting, 0 if none
+	RequiredFinalityThreshold uint32
 }
 
 // EvmLogToMessageState transforms an evm log into a messageState given an ABI
@@ -64,17 +109,30 @@ func EvmLogToMessageState(abi abi.ABI, messageSent abi.Event, log *ethtypes.Log)
 	}
 
 	rawMessageSentBytes := event["message"].([]byte)
-	message, _ := new(types.Message).Parse(rawMessageSentBytes)
+	messageState, err = NewMessageState(rawMessageSentBytes, log.TxHash.Hex())
+	if err != nil {
+		return nil, err
+	}
+	messageState.SourceBlock = log.BlockNumber
+	return messageState, nil
+}
+
+// NewMessageState builds a created messageState from raw MessageSent bytes emitted by a source tx
+func NewMessageState(rawMessageSentBytes []byte, sourceTxHash string) (*MessageState, error) {
+	message, err := new(types.Message).Parse(rawMessageSentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse message: %w", err)
+	}
 
 	hashed := crypto.Keccak256(rawMessageSentBytes)
 	hashedHexStr := hex.EncodeToString(hashed)
 
-	messageState = &MessageState{
+	messageState := &MessageState{
 		IrisLookupID:      hashedHexStr,
 		Status:            Created,
 		SourceDomain:      Domain(message.SourceDomain),
 		DestDomain:        Domain(message.DestinationDomain),
-		SourceTxHash:      log.TxHash.Hex(),
+		SourceTxHash:      sourceTxHash,
 		MsgSentBytes:      rawMessageSentBytes,
 		MsgBody:           message.MessageBody,
 		DestinationCaller: message.DestinationCaller,
@@ -82,30 +140,125 @@ func EvmLogToMessageState(abi abi.ABI, messageSent abi.Event, log *ethtypes.Log)
 		Created:           time.Now(),
 		Updated:           time.Now(),
 	}
+	messageState.MinFinalityThreshold = minFinalityThreshold(message.Version, rawMessageSentBytes)
 
 	// Try to parse as BurnMessage (standard CCTP burn/mint)
 	if _, err := new(BurnMessage).Parse(message.MessageBody); err == nil {
 		return messageState, nil
 	}
 
-	// Try to parse as MetadataMessage (v2 fast transfer with metadata)
-	if _, err := new(MetadataMessage).Parse(message.MessageBody); err == nil {
+	// Try to parse as MetadataMessage (v2 fast transfer with metadata), kept so integrators can see what will execute
+	if metadata, err := new(MetadataMessage).Parse(message.MessageBody); err == nil {
+		messageState.Metadata = metadata
+		messageState.Channel = fmt.Sprintf("channel-%d", metadata.Channel)
 		return messageState, nil
 	}
 
-	return nil, fmt.Errorf("message body is not a valid CCTP BurnMessage or MetadataMessage format (length: %d bytes)", len(message.MessageBody))
+	return nil, &UnparseableMessageError{SourceDomain: messageState.SourceDomain, BodyLength: len(message.MessageBody)}
+}
+
+// minFinalityThreshold returns the minimum finality threshold of a CCTP v2 (message version 1) message, so Fast
+// Transfers can be told apart before they are attested. Returns 0 for v1 messages.
+func minFinalityThreshold(version uint32, rawMessageSentBytes []byte) uint32 {
+	// v2 header: version, source and destination domain, 32 byte nonce, sender, recipient and destination caller
+	const (
+		minFinalityThresholdIndex = 140
+		finalityThresholdIndex    = 144
+	)
+	if version != 1 || len(rawMessageSentBytes) < finalityThresholdIndex {
+		return 0
+	}
+	return binary.BigEndian.Uint32(rawMessageSentBytes[minFinalityThresholdIndex:finalityThresholdIndex])
+}
+
+// UnparseableMessageError is returned for a message whose body is neither a BurnMessage nor a MetadataMessage,
+// e.g. an empty body or a message format introduced by Circle that isn't supported yet
+type UnparseableMessageError struct {
+	SourceDomain Domain
+	BodyLength   int
+}
+
+func (e *UnparseableMessageError) Error() string {
+	return fmt.Sprintf("message body is not a valid CCTP BurnMessage or MetadataMessage format (length: %d bytes)", e.BodyLength)
+}
+
+// VerifyAttestedMessage checks the attestation was returned for this message and not e.g. for another message of
+// the same source tx, by comparing the message bytes Circle returned alongside the attestation with the message
+// bytes. Attestations fetched without them, from the v1 api by the iris lookup id, always match.
+func (m *MessageState) VerifyAttestedMessage() error {
+	if len(m.AttestedMessage) == 0 {
+		return nil
+	}
+	if !sameMessage(m.MsgSentBytes, m.AttestedMessage) {
+		return fmt.Errorf("attestation was returned for message 0x%s, not 0x%s",
+			hex.EncodeToString(crypto.Keccak256(m.AttestedMessage)), strings.TrimPrefix(m.IrisLookupID, "0x"))
+	}
+	return nil
+}
+
+// attestationFilledFields are the byte ranges of a v2 message (message version 1) that Circle fills in when it
+// attests the message and are zero in the emitted message: the nonce and executed finality threshold of the header,
+// and the executed fee and expiration block of a burn body
+var attestationFilledFields = [][2]int{{12, 44}, {144, 148}, {312, 344}, {344, 376}}
+
+// sameMessage returns true if the attested message is the emitted message, ignoring the v2 fields Circle fills in
+func sameMessage(emitted, attested []byte) bool {
+	if len(emitted) != len(attested) {
+		return false
+	}
+	if len(emitted) < 4 || binary.BigEndian.Uint32(emitted[:4]) != 1 {
+		return bytes.Equal(emitted, attested)
+	}
+
+	masked := bytes.Clone(attested)
+	for _, field := range attestationFilledFields {
+		if field[1] <= len(masked) {
+			copy(masked[field[0]:field[1]], emitted[field[0]:field[1]])
+		}
+	}
+	return bytes.Equal(emitted, masked)
+}
+
+// IsFastTransfer returns true if the message is a v2 Fast Transfer, detected either by an attestation
+// expiration block or by a finality threshold below the finalized threshold. Before the attestation is fetched,
+// the minimum finality threshold the burn requested is used.
+func (m *MessageState) IsFastTransfer() bool {
+	if m.ExpirationBlock > 0 {
+		return true
+	}
+	if m.FinalityThreshold > 0 {
+		return m.FinalityThreshold < FinalityThresholdFinalized
+	}
+	return m.MinFinalityThreshold > 0 && m.MinFinalityThreshold < FinalityThresholdFinalized
+}
+
+// HasFastTransfer returns true if any message in the tx is a v2 Fast Transfer
+func (t *TxState) HasFastTransfer() bool {
+	for _, msg := range t.Msgs {
+		if msg.IsFastTransfer() {
+			return true
+		}
+	}
+	return false
 }
 
 // Equal checks if two MessageState instances are equal
 func (m *MessageState) Equal(other *MessageState) bool {
 	return (m.IrisLookupID == other.IrisLookupID &&
 		m.Status == other.Status &&
+		m.FilteredBy == other.FilteredBy &&
+		m.FilterReason == other.FilterReason &&
+		m.FailureReason == other.FailureReason &&
 		m.Attestation == other.Attestation &&
 		m.SourceDomain == other.SourceDomain &&
 		m.DestDomain == other.DestDomain &&
 		m.SourceTxHash == other.SourceTxHash &&
 		m.DestTxHash == other.DestTxHash &&
+		m.DestConfirmed == other.DestConfirmed &&
+		m.DestError == other.DestError &&
+		m.DestBlock == other.DestBlock &&
 		bytes.Equal(m.MsgSentBytes, other.MsgSentBytes) &&
+		bytes.Equal(m.AttestedMessage, other.AttestedMessage) &&
 		bytes.Equal(m.DestinationCaller, other.DestinationCaller) &&
 		m.Channel == other.Channel &&
 		m.Created == other.Created &&
@@ -113,5 +266,12 @@ func (m *MessageState) Equal(other *MessageState) bool {
 		m.CctpVersion == other.CctpVersion &&
 		m.ExpirationBlock == other.ExpirationBlock &&
 		m.FinalityThreshold == other.FinalityThreshold &&
-		m.ReattestCount == other.ReattestCount)
+		m.RequiredFinalityThreshold == other.RequiredFinalityThreshold &&
+		m.ReattestCount == other.ReattestCount &&
+		m.FastTransferExpired == other.FastTransferExpired &&
+		m.StuckPending == other.StuckPending &&
+		m.SourceBlock == other.SourceBlock &&
+		m.SourceTime.Equal(other.SourceTime) &&
+		m.Amount == other.Amount &&
+		m.AmountFormatted == other.AmountFormatted)
 }
@@ -1,7 +1,10 @@
 package types_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
 	"math/big"
 	"os"
 	"testing"
@@ -9,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/pascaldekloe/etherstream"
 	"github.com/stretchr/testify/assert"
@@ -59,3 +63,120 @@ func TestStateHandling(t *testing.T) {
 	ms := &types.MessageState{IrisLookupID: "test123"}
 	assert.Equal(t, "test123", ms.IrisLookupID)
 }
+
+func TestIsFastTransfer(t *testing.T) {
+	require.False(t, (&types.MessageState{}).IsFastTransfer())
+	require.False(t, (&types.MessageState{FinalityThreshold: types.FinalityThresholdFinalized}).IsFastTransfer())
+	require.True(t, (&types.MessageState{FinalityThreshold: 1000}).IsFastTransfer())
+	require.True(t, (&types.MessageState{ExpirationBlock: 1}).IsFastTransfer())
+
+	// before the attestation the requested finality threshold is used, the executed one once it is known
+	require.True(t, (&types.MessageState{MinFinalityThreshold: 1000}).IsFastTransfer())
+	require.False(t, (&types.MessageState{MinFinalityThreshold: 1000, FinalityThreshold: types.FinalityThresholdFinalized}).IsFastTransfer())
+}
+
+// TestNewMessageStateMetadata verifies the forwarding metadata of a metadata message body is kept on the message state
+func TestNewMessageStateMetadata(t *testing.T) {
+	sender := common.LeftPadBytes(common.HexToAddress("0x1c5d2c0B4c5dFdC1D4F7B8cA8eA3F1cE0F7d0a01").Bytes(), 32)
+	recipient := common.LeftPadBytes([]byte{0xab, 0xcd}, 32)
+	memo := `{"wasm":{"contract":"noble1hook","msg":{"execute":{}}}}`
+
+	body := binary.BigEndian.AppendUint64(nil, 7)
+	body = append(body, sender...)
+	body = binary.BigEndian.AppendUint64(body, 12)
+	body = append(body, common.LeftPadBytes([]byte("osmo"), 32)...)
+	body = append(body, recipient...)
+	body = append(body, memo...)
+
+	header := binary.BigEndian.AppendUint32(nil, 0)
+	header = binary.BigEndian.AppendUint32(header, 4)
+	header = binary.BigEndian.AppendUint32(header, 0)
+	header = binary.BigEndian.AppendUint64(header, 42)
+	header = append(header, make([]byte, 96)...) // sender, recipient, destination caller
+
+	messageState, err := types.NewMessageState(append(header, body...), "0x01")
+	require.NoError(t, err)
+	require.NotNil(t, messageState.Metadata)
+	require.EqualValues(t, 7, messageState.Metadata.Nonce)
+	require.Equal(t, sender, messageState.Metadata.Sender)
+	require.EqualValues(t, 12, messageState.Metadata.Channel)
+	require.Equal(t, "osmo", messageState.Metadata.Prefix)
+	require.Equal(t, recipient, messageState.Metadata.Recipient)
+	require.Equal(t, memo, messageState.Metadata.Memo)
+	require.Equal(t, "channel-12", messageState.Channel)
+
+	// burn messages carry no metadata
+	burn := append(binary.BigEndian.AppendUint32(nil, 0), make([]byte, 128)...)
+	messageState, err = types.NewMessageState(append(header, burn...), "0x02")
+	require.NoError(t, err)
+	require.Nil(t, messageState.Metadata)
+	require.Empty(t, messageState.Channel)
+}
+
+func TestNewMessageStateUnparseable(t *testing.T) {
+	header := binary.BigEndian.AppendUint32(nil, 0)
+	header = binary.BigEndian.AppendUint32(header, 4)
+	header = binary.BigEndian.AppendUint32(header, 0)
+	header = binary.BigEndian.AppendUint64(header, 42)
+	header = append(header, make([]byte, 96)...) // sender, recipient, destination caller
+
+	for _, body := range [][]byte{nil, make([]byte, 10)} {
+		_, err := types.NewMessageState(append(header, body...), "0x01")
+		var unparseable *types.UnparseableMessageError
+		require.ErrorAs(t, err, &unparseable)
+		require.Equal(t, types.Domain(4), unparseable.SourceDomain)
+		require.Equal(t, len(body), unparseable.BodyLength)
+	}
+
+	// a truncated header isn't a message at all
+	_, err := types.NewMessageState(header[:20], "0x02")
+	var unparseable *types.UnparseableMessageError
+	require.Error(t, err)
+	require.False(t, errors.As(err, &unparseable))
+}
+
+func TestVerifyAttestedMessage(t *testing.T) {
+	// v2 header: version 1, source domain 0, destination domain 4, nonce, sender, recipient, destination caller,
+	// min and executed finality threshold, followed by a burn body
+	emitted := make([]byte, 148+228)
+	binary.BigEndian.PutUint32(emitted[0:4], 1)
+	binary.BigEndian.PutUint32(emitted[8:12], 4)
+	binary.BigEndian.PutUint32(emitted[140:144], 1000)
+	copy(emitted[148+36:148+68], bytes.Repeat([]byte{1}, 32)) // mint recipient
+	lookupID := common.Bytes2Hex(crypto.Keccak256(emitted))
+
+	// attestations fetched without the message, from the v1 api, match
+	require.NoError(t, (&types.MessageState{IrisLookupID: lookupID, MsgSentBytes: emitted}).VerifyAttestedMessage())
+
+	// Circle fills in the nonce, executed finality threshold, executed fee and expiration block
+	attested := bytes.Clone(emitted)
+	attested[43] = 7
+	binary.BigEndian.PutUint32(attested[144:148], 1000)
+	attested[148+195] = 1
+	attested[148+227] = 200
+	msg := &types.MessageState{IrisLookupID: lookupID, MsgSentBytes: emitted, AttestedMessage: attested}
+	require.NoError(t, msg.VerifyAttestedMessage())
+
+	// the attestation of another message of the same tx, to another recipient
+	other := bytes.Clone(attested)
+	copy(other[148+36:148+68], bytes.Repeat([]byte{2}, 32))
+	msg.AttestedMessage = other
+	err := msg.VerifyAttestedMessage()
+	require.ErrorContains(t, err, "attestation was returned for message 0x"+common.Bytes2Hex(crypto.Keccak256(other)))
+	require.ErrorContains(t, err, "not 0x"+lookupID)
+
+	// v1 messages must match exactly
+	v1 := []byte("message sent bytes")
+	require.NoError(t, (&types.MessageState{MsgSentBytes: v1, AttestedMessage: v1}).VerifyAttestedMessage())
+	require.Error(t, (&types.MessageState{MsgSentBytes: v1, AttestedMessage: []byte("message sent bytez")}).VerifyAttestedMessage())
+	require.Error(t, (&types.MessageState{MsgSentBytes: v1, AttestedMessage: v1[1:]}).VerifyAttestedMessage())
+}
+
+func TestIsTerminal(t *testing.T) {
+	for _, status := range []string{types.Complete, types.AlreadyMinted, types.SimulatedComplete, types.Failed, types.Filtered} {
+		require.True(t, types.IsTerminal(status), status)
+	}
+	for _, status := range []string{"", types.Created, types.Pending, types.Attested} {
+		require.False(t, types.IsTerminal(status), status)
+	}
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Route is a source to destination domain pair
+type Route struct {
+	Source Domain
+	Dest   Domain
+}
+
+// ParseRoute parses a route formatted as "<source domain>-<dest domain>", e.g. "0-4"
+func ParseRoute(s string) (Route, error) {
+	source, dest, ok := strings.Cut(s, "-")
+	if !ok {
+		return Route{}, fmt.Errorf("invalid route %q, expected <source domain>-<dest domain>", s)
+	}
+	sourceDomain, err := strconv.ParseUint(source, 10, 32)
+	if err != nil {
+		return Route{}, fmt.Errorf("invalid route %q source domain: %w", s, err)
+	}
+	destDomain, err := strconv.ParseUint(dest, 10, 32)
+	if err != nil {
+		return Route{}, fmt.Errorf("invalid route %q dest domain: %w", s, err)
+	}
+	return Route{Source: Domain(sourceDomain), Dest: Domain(destDomain)}, nil
+}
+
+func (r Route) String() string {
+	return fmt.Sprintf("%d-%d", r.Source, r.Dest)
+}
+
+// PauseStatus is the current pause state reported by the API
+type PauseStatus struct {
+	Paused       bool     `json:"paused"`        // broadcasting is paused on every route
+	PausedRoutes []string `json:"paused_routes"` // routes paused individually
+}
+
+// PauseState records whether broadcasting is paused on every route or on specific routes. It is safe for
+// concurrent use.
+type PauseState struct {
+	mu     sync.RWMutex
+	all    bool
+	routes map[Route]bool
+}
+
+func NewPauseState() *PauseState {
+	return &PauseState{routes: make(map[Route]bool)}
+}
+
+// Pause pauses broadcasting on route, or on every route if route is nil
+func (p *PauseState) Pause(route *Route) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if route == nil {
+		p.all = true
+		return
+	}
+	p.routes[*route] = true
+}
+
+// Resume resumes broadcasting on route, or on every route, including the ones paused individually, if route is nil
+func (p *PauseState) Resume(route *Route) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if route == nil {
+		p.all = false
+		p.routes = make(map[Route]bool)
+		return
+	}
+	delete(p.routes, *route)
+}
+
+// IsPaused returns true if broadcasting messages from source to dest is paused
+func (p *PauseState) IsPaused(source, dest Domain) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.all || p.routes[Route{Source: source, Dest: dest}]
+}
+
+// Status returns the current pause state
+func (p *PauseState) Status() PauseStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	status := PauseStatus{Paused: p.all, PausedRoutes: make([]string, 0, len(p.routes))}
+	for route := range p.routes {
+		status.PausedRoutes = append(status.PausedRoutes, route.String())
+	}
+	sort.Strings(status.PausedRoutes)
+	return status
+}
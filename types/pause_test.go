@@ -0,0 +1,45 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestParseRoute(t *testing.T) {
+	route, err := types.ParseRoute("0-4")
+	require.NoError(t, err)
+	require.Equal(t, types.Route{Source: 0, Dest: 4}, route)
+	require.Equal(t, "0-4", route.String())
+
+	for _, invalid := range []string{"", "0", "0-", "a-4", "0-4-1"} {
+		_, err := types.ParseRoute(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestPauseState(t *testing.T) {
+	p := types.NewPauseState()
+	require.False(t, p.IsPaused(0, 4))
+	require.Equal(t, types.PauseStatus{PausedRoutes: []string{}}, p.Status())
+
+	route := types.Route{Source: 0, Dest: 4}
+	p.Pause(&route)
+	require.True(t, p.IsPaused(0, 4))
+	require.False(t, p.IsPaused(4, 0))
+
+	p.Pause(nil)
+	require.True(t, p.IsPaused(4, 0))
+	require.Equal(t, types.PauseStatus{Paused: true, PausedRoutes: []string{"0-4"}}, p.Status())
+
+	// resuming a route keeps the global pause
+	p.Resume(&route)
+	require.True(t, p.IsPaused(0, 4))
+
+	p.Pause(&route)
+	p.Resume(nil)
+	require.False(t, p.IsPaused(0, 4))
+	require.False(t, p.IsPaused(4, 0))
+}
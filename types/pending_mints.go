@@ -0,0 +1,99 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PendingMints tracks broadcast mints by destination tx hash until their tx is confirmed or failed on the
+// destination, recording the outcome on the message with DestConfirmed and DestError.
+type PendingMints struct {
+	mu    sync.Mutex
+	mints map[string]*pendingMint
+}
+
+type pendingMint struct {
+	msg    *MessageState
+	sentAt time.Time
+}
+
+func NewPendingMints() *PendingMints {
+	return &PendingMints{mints: make(map[string]*pendingMint)}
+}
+
+// Track starts tracking the mint of msg sent in txHash. The message is unconfirmed until the tx is resolved.
+func (p *PendingMints) Track(txHash string, msg *MessageState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	msg.DestConfirmed = false
+	msg.DestError = ""
+	p.mints[txHash] = &pendingMint{msg: msg, sentAt: time.Now()}
+}
+
+// Untrack stops tracking txHash without resolving its message, e.g. when the tx was replaced
+func (p *PendingMints) Untrack(txHash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.mints, txHash)
+}
+
+// TxHashes returns the hashes of the tracked mint txs
+func (p *PendingMints) TxHashes() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hashes := make([]string, 0, len(p.mints))
+	for hash := range p.mints {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// Confirm marks the message minted in txHash as confirmed and stops tracking it. Returns the message, nil if
+// txHash isn't tracked.
+func (p *PendingMints) Confirm(txHash string) *MessageState {
+	return p.resolve(txHash, "")
+}
+
+// Fail records why the mint tx txHash failed on the destination and stops tracking it. Returns the message, nil
+// if txHash isn't tracked.
+func (p *PendingMints) Fail(txHash string, reason string) *MessageState {
+	return p.resolve(txHash, reason)
+}
+
+func (p *PendingMints) resolve(txHash string, reason string) *MessageState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	mint, ok := p.mints[txHash]
+	if !ok {
+		return nil
+	}
+	delete(p.mints, txHash)
+	mint.msg.DestConfirmed = reason == ""
+	mint.msg.DestError = reason
+	return mint.msg
+}
+
+// Expire fails the mints sent more than timeout ago that are still unresolved, their tx was likely dropped.
+// Returns the messages of the expired mints.
+func (p *PendingMints) Expire(timeout time.Duration) []*MessageState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var expired []*MessageState
+	for hash, mint := range p.mints {
+		if time.Since(mint.sentAt) < timeout {
+			continue
+		}
+		delete(p.mints, hash)
+		mint.msg.DestError = fmt.Sprintf("mint tx not confirmed within %s, it may have been dropped", timeout)
+		expired = append(expired, mint.msg)
+	}
+	return expired
+}
+
+func (p *PendingMints) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.mints)
+}
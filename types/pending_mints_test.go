@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestPendingMints(t *testing.T) {
+	mints := types.NewPendingMints()
+	confirmed, reverted, dropped := &types.MessageState{}, &types.MessageState{}, &types.MessageState{DestError: "old error"}
+
+	mints.Track("0xconfirmed", confirmed)
+	mints.Track("0xreverted", reverted)
+	mints.Track("0xdropped", dropped)
+	require.Empty(t, dropped.DestError)
+	require.ElementsMatch(t, []string{"0xconfirmed", "0xreverted", "0xdropped"}, mints.TxHashes())
+
+	require.Same(t, confirmed, mints.Confirm("0xconfirmed"))
+	require.True(t, confirmed.DestConfirmed)
+	require.Empty(t, confirmed.DestError)
+
+	require.Same(t, reverted, mints.Fail("0xreverted", "mint tx reverted"))
+	require.False(t, reverted.DestConfirmed)
+	require.Equal(t, "mint tx reverted", reverted.DestError)
+
+	// resolved mints are no longer tracked
+	require.Nil(t, mints.Confirm("0xreverted"))
+	require.Equal(t, 1, mints.Len())
+
+	require.Empty(t, mints.Expire(time.Hour))
+	require.Equal(t, []*types.MessageState{dropped}, mints.Expire(0))
+	require.False(t, dropped.DestConfirmed)
+	require.Contains(t, dropped.DestError, "may have been dropped")
+	require.Zero(t, mints.Len())
+
+	// a replaced tx is dropped without resolving its message
+	mints.Track("0xoriginal", dropped)
+	mints.Untrack("0xoriginal")
+	require.Zero(t, mints.Len())
+}
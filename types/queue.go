@@ -0,0 +1,148 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+// defaultEnqueueTimeout is used when enqueue-timeout is not set
+const defaultEnqueueTimeout = 10 * time.Second
+
+// PriorityQueue holds txs containing v2 Fast Transfers. Fast Transfer attestations expire, so processors
+// always drain this queue before the standard processing queue to avoid them being starved by a backlog
+// of standard transfers.
+var PriorityQueue = make(chan *TxState, 10000)
+
+var (
+	// enqueueTimeout bounds how long a listener waits for room on the processing queue
+	enqueueTimeout atomic.Int64
+	// enqueueMetrics records enqueue timeouts, nil until ConfigureEnqueue is called
+	enqueueMetrics atomic.Pointer[relayer.PromMetrics]
+)
+
+func init() {
+	enqueueTimeout.Store(int64(defaultEnqueueTimeout))
+}
+
+// ConfigureEnqueue sets how long listeners wait for room on a full processing queue and the metrics timeouts
+// are recorded with
+func ConfigureEnqueue(timeoutSeconds uint, metrics *relayer.PromMetrics) {
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	if timeout == 0 {
+		timeout = defaultEnqueueTimeout
+	}
+	enqueueTimeout.Store(int64(timeout))
+	enqueueMetrics.Store(metrics)
+}
+
+// Enqueue passes a tx observed by a chain's listener to the processing queue, or to the PriorityQueue if it
+// contains a Fast Transfer, told apart by the finality threshold requested by the burn. When the queue stays full for the
+// enqueue timeout, e.g. because every processor is waiting on the attestation API, the tx is held back so the
+// listener keeps tracking blocks, and RetryHeld passes it on once the queue has room. A held tx is never lost to a
+// listener that moves past its block. Returns whether the tx was enqueued right away.
+func Enqueue(logger log.Logger, processingQueue chan *TxState, tx *TxState, chain string, domain Domain) bool {
+	if tx.HasFastTransfer() {
+		processingQueue = PriorityQueue
+	}
+
+	select {
+	case processingQueue <- tx:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(time.Duration(enqueueTimeout.Load()))
+	defer timer.Stop()
+
+	select {
+	case processingQueue <- tx:
+		return true
+	case <-timer.C:
+		logger.Error("Processing queue is full, holding tx until there is room", "tx", tx.TxHash, "msgs", len(tx.Msgs), "queue_size", len(processingQueue))
+		if m := enqueueMetrics.Load(); m != nil {
+			m.IncQueueEnqueueTimeout(chain, fmt.Sprint(domain))
+		}
+		held.add(tx, processingQueue)
+		return false
+	}
+}
+
+// retryHeldInterval is how often RetryHeld tries to pass the held txs to their queue
+const retryHeldInterval = time.Second
+
+// RetryHeld passes the txs held back by Enqueue to their queue as it gets room, oldest first, until ctx is done
+func RetryHeld(ctx context.Context, logger log.Logger) {
+	ticker := time.NewTicker(retryHeldInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if enqueued, left := held.retry(); enqueued > 0 {
+				logger.Info("Enqueued held txs", "enqueued", enqueued, "held", left)
+			}
+		}
+	}
+}
+
+// held holds the txs Enqueue couldn't pass to a full queue
+var held = &heldTxs{hashes: make(map[string]struct{})}
+
+type heldTx struct {
+	tx    *TxState
+	queue chan *TxState
+}
+
+// heldTxs is the set of held txs in the order they were held, a tx emitted again while held is held once
+type heldTxs struct {
+	mu     sync.Mutex
+	txs    []heldTx
+	hashes map[string]struct{}
+}
+
+func (h *heldTxs) add(tx *TxState, queue chan *TxState) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.hashes[tx.TxHash]; ok {
+		return
+	}
+	h.hashes[tx.TxHash] = struct{}{}
+	h.txs = append(h.txs, heldTx{tx: tx, queue: queue})
+}
+
+// retry passes the held txs to their queue without blocking, keeping the ones that still don't fit. Returns the
+// number of txs enqueued and left held.
+func (h *heldTxs) retry() (enqueued int, left int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	kept := h.txs[:0]
+	for _, t := range h.txs {
+		select {
+		case t.queue <- t.tx:
+			delete(h.hashes, t.tx.TxHash)
+			enqueued++
+		default:
+			kept = append(kept, t)
+		}
+	}
+	clear(h.txs[len(kept):])
+	h.txs = kept
+	return enqueued, len(kept)
+}
+
+func (h *heldTxs) len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.txs)
+}
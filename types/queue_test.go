@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+// TestEnqueueTimeout verifies a full processing queue holds the tx after the timeout instead of blocking, and the
+// held tx is enqueued once there is room
+func TestEnqueueTimeout(t *testing.T) {
+	m := relayer.NewPromMetrics(prometheus.NewRegistry())
+	ConfigureEnqueue(1, m)
+	t.Cleanup(func() { ConfigureEnqueue(0, nil) })
+
+	processingQueue := make(chan *TxState, 1)
+	require.True(t, Enqueue(log.NewNopLogger(), processingQueue, &TxState{TxHash: "a"}, "ethereum", 0))
+	require.False(t, Enqueue(log.NewNopLogger(), processingQueue, &TxState{TxHash: "b"}, "ethereum", 0))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.QueueEnqueueTimeout.WithLabelValues("ethereum", "0")))
+
+	// a tx emitted again while held, e.g. by a flush, is held once
+	require.False(t, Enqueue(log.NewNopLogger(), processingQueue, &TxState{TxHash: "b"}, "ethereum", 0))
+	require.Equal(t, 1, held.len())
+
+	enqueued, left := held.retry()
+	require.Zero(t, enqueued)
+	require.Equal(t, 1, left)
+
+	require.Equal(t, "a", (<-processingQueue).TxHash)
+	enqueued, left = held.retry()
+	require.Equal(t, 1, enqueued)
+	require.Zero(t, left)
+	require.Equal(t, "b", (<-processingQueue).TxHash)
+	require.True(t, Enqueue(log.NewNopLogger(), processingQueue, &TxState{TxHash: "c"}, "ethereum", 0))
+}
+
+// TestEnqueuePriority verifies listeners pass txs whose burn requested a fast finality threshold to the priority queue
+func TestEnqueuePriority(t *testing.T) {
+	// the minimum finality threshold follows the nonce, sender, recipient and destination caller of a v2 header
+	header := binary.BigEndian.AppendUint32(nil, 1)
+	header = append(header, make([]byte, 136)...)
+	header = binary.BigEndian.AppendUint32(header, 1000)
+	header = binary.BigEndian.AppendUint32(header, 0)
+	require.EqualValues(t, 1000, minFinalityThreshold(1, header))
+	require.Zero(t, minFinalityThreshold(0, header), "v1 messages have no finality threshold")
+
+	processingQueue := make(chan *TxState, 1)
+	fast := &TxState{TxHash: "fast", Msgs: []*MessageState{{MinFinalityThreshold: 1000}}}
+	standard := &TxState{TxHash: "standard", Msgs: []*MessageState{{MinFinalityThreshold: FinalityThresholdFinalized}}}
+
+	require.True(t, Enqueue(log.NewNopLogger(), processingQueue, fast, "ethereum", 0))
+	require.True(t, Enqueue(log.NewNopLogger(), processingQueue, standard, "ethereum", 0))
+	require.Equal(t, "fast", (<-PriorityQueue).TxHash)
+	require.Equal(t, "standard", (<-processingQueue).TxHash)
+}
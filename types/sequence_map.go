@@ -1,32 +1,159 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"sync"
 )
 
-// SequenceMap holds a minter account's txn count to avoid account sequence mismatch errors
+// sequenceKey identifies one minter wallet of a destination domain
+type sequenceKey struct {
+	domain Domain
+	wallet int
+}
+
+// SequenceMap holds each minter account's txn count to avoid account sequence mismatch errors
 type SequenceMap struct {
 	mu sync.Mutex
-	// map destination domain -> minter account sequence
-	sequenceMap map[Domain]uint64
+	// map destination domain and wallet index -> minter account sequence
+	sequenceMap map[sequenceKey]uint64
+	// map destination domain and wallet index -> minter account address, sequences are persisted by address
+	addresses map[sequenceKey]string
 }
 
 func NewSequenceMap() *SequenceMap {
 	return &SequenceMap{
-		sequenceMap: map[Domain]uint64{},
+		sequenceMap: map[sequenceKey]uint64{},
+		addresses:   map[sequenceKey]string{},
 	}
 }
 
+// Put sets the sequence of the first minter wallet of a domain
 func (m *SequenceMap) Put(destDomain Domain, val uint64) {
+	m.PutWallet(destDomain, 0, val)
+}
+
+// Next returns and increments the sequence of the first minter wallet of a domain
+func (m *SequenceMap) Next(destDomain Domain) uint64 {
+	return m.NextWallet(destDomain, 0)
+}
+
+// Get returns the sequence of the first minter wallet of a domain
+func (m *SequenceMap) Get(destDomain Domain) uint64 {
+	return m.GetWallet(destDomain, 0)
+}
+
+func (m *SequenceMap) PutWallet(destDomain Domain, wallet int, val uint64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.sequenceMap[destDomain] = val
+	m.sequenceMap[sequenceKey{destDomain, wallet}] = val
 }
 
-func (m *SequenceMap) Next(destDomain Domain) uint64 {
+// PutAccount sets the sequence of a minter wallet of a domain along with its account address
+func (m *SequenceMap) PutAccount(destDomain Domain, wallet int, address string, val uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := sequenceKey{destDomain, wallet}
+	m.sequenceMap[key] = val
+	m.addresses[key] = address
+}
+
+func (m *SequenceMap) NextWallet(destDomain Domain, wallet int) uint64 {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	result := m.sequenceMap[destDomain]
-	m.sequenceMap[destDomain]++
+	key := sequenceKey{destDomain, wallet}
+	result := m.sequenceMap[key]
+	m.sequenceMap[key]++
 	return result
 }
+
+func (m *SequenceMap) GetWallet(destDomain Domain, wallet int) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sequenceMap[sequenceKey{destDomain, wallet}]
+}
+
+// persistedSequence is a minter account sequence in the sequence file
+type persistedSequence struct {
+	Address  string `json:"address"`
+	Sequence uint64 `json:"sequence"`
+}
+
+// Save writes the sequences of the minter accounts of domains to path, keyed by account address. The file is
+// replaced atomically so a crash mid-write doesn't lose the previous sequences.
+func (m *SequenceMap) Save(path string, domains []Domain) error {
+	m.mu.Lock()
+	var sequences []persistedSequence
+	for key, sequence := range m.sequenceMap {
+		address := m.addresses[key]
+		if address == "" || !slices.Contains(domains, key.domain) {
+			continue
+		}
+		sequences = append(sequences, persistedSequence{Address: address, Sequence: sequence})
+	}
+	m.mu.Unlock()
+
+	bz, err := json.Marshal(sequences)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create sequence file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write sequence file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write sequence file: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Reconcile raises the sequences of the minter accounts of domains to the ones saved at path for the same account
+// address, keeping the current (on-chain) sequence where it is higher. Saved accounts that are no longer configured
+// are ignored. A missing file is not an error. Returns the number of sequences raised.
+func (m *SequenceMap) Reconcile(path string, domains []Domain) (int, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("unable to read sequence file: %w", err)
+	}
+
+	var sequences []persistedSequence
+	if err := json.Unmarshal(bz, &sequences); err != nil {
+		return 0, fmt.Errorf("unable to parse sequence file %s: %w", path, err)
+	}
+
+	saved := make(map[string]uint64, len(sequences))
+	for _, persisted := range sequences {
+		if persisted.Address != "" {
+			saved[persisted.Address] = persisted.Sequence
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var raised int
+	for key, address := range m.addresses {
+		if !slices.Contains(domains, key.domain) {
+			continue
+		}
+		if sequence, ok := saved[address]; ok && sequence > m.sequenceMap[key] {
+			m.sequenceMap[key] = sequence
+			raised++
+		}
+	}
+	return raised, nil
+}
@@ -0,0 +1,45 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestSequenceMapPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sequences.json")
+
+	// a missing file leaves the on-chain sequences
+	fresh := types.NewSequenceMap()
+	raised, err := fresh.Reconcile(path, []types.Domain{4})
+	require.NoError(t, err)
+	require.Zero(t, raised)
+
+	saved := types.NewSequenceMap()
+	saved.PutAccount(4, 0, "noble1a", 10)
+	saved.PutAccount(4, 1, "noble1b", 20)
+	saved.PutAccount(4, 2, "noble1c", 30) // removed from the config before the restart
+	saved.PutAccount(0, 0, "noble1d", 99) // not persisted
+	require.NoError(t, saved.Save(path, []types.Domain{4}))
+
+	// the higher of the persisted and on-chain sequence of the same account wins, even after the minter keys
+	// were reordered
+	restarted := types.NewSequenceMap()
+	restarted.PutAccount(4, 0, "noble1b", 25)
+	restarted.PutAccount(4, 1, "noble1a", 7)
+	restarted.PutAccount(0, 0, "noble1d", 3)
+	raised, err = restarted.Reconcile(path, []types.Domain{0, 4})
+	require.NoError(t, err)
+	require.Equal(t, 1, raised)
+	require.EqualValues(t, 25, restarted.GetWallet(4, 0))
+	require.EqualValues(t, 10, restarted.GetWallet(4, 1))
+	require.EqualValues(t, 3, restarted.GetWallet(0, 0))
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = restarted.Reconcile(path, []types.Domain{4})
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"sort"
 	"sync"
 )
 
@@ -44,3 +45,46 @@ func (sm *StateMap) Store(key string, value *TxState) {
 
 	sm.internal.Store(key, value)
 }
+
+// Range calls f for each transaction in the state until f returns false.
+// f is called with the state locked and must not call other StateMap methods.
+func (sm *StateMap) Range(f func(key string, value *TxState) bool) {
+	sm.Mu.Lock()
+	defer sm.Mu.Unlock()
+
+	sm.internal.Range(func(key, value any) bool {
+		return f(key.(string), value.(*TxState))
+	})
+}
+
+// DeleteFunc deletes every transaction f returns true for in one pass with the state locked, so no transaction
+// changes between being checked and deleted. f must not call other StateMap methods.
+func (sm *StateMap) DeleteFunc(f func(key string, value *TxState) bool) {
+	sm.Mu.Lock()
+	defer sm.Mu.Unlock()
+
+	sm.internal.Range(func(key, value any) bool {
+		if f(key.(string), value.(*TxState)) {
+			sm.internal.Delete(key)
+		}
+		return true
+	})
+}
+
+// Snapshot returns copies of every transaction in the state sorted by tx hash, safe to read without holding the lock
+func (sm *StateMap) Snapshot() []*TxState {
+	var txs []*TxState
+	sm.Range(func(_ string, tx *TxState) bool {
+		snapshot := *tx
+		snapshot.Msgs = make([]*MessageState, len(tx.Msgs))
+		for i, msg := range tx.Msgs {
+			m := *msg
+			snapshot.Msgs[i] = &m
+		}
+		txs = append(txs, &snapshot)
+		return true
+	})
+
+	sort.Slice(txs, func(i, j int) bool { return txs[i].TxHash < txs[j].TxHash })
+	return txs
+}
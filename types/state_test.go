@@ -48,3 +48,56 @@ func TestStateHandling(t *testing.T) {
 	loadedMsg3, _ := stateMap.Load(txHash)
 	require.Len(t, loadedMsg3.Msgs, 2)
 }
+
+func TestStateRange(t *testing.T) {
+	stateMap := NewStateMap()
+	stateMap.Store("a", &TxState{TxHash: "a"})
+	stateMap.Store("b", &TxState{TxHash: "b"})
+
+	seen := make(map[string]bool)
+	stateMap.Range(func(key string, value *TxState) bool {
+		require.Equal(t, key, value.TxHash)
+		seen[key] = true
+		return true
+	})
+	require.Equal(t, map[string]bool{"a": true, "b": true}, seen)
+
+	var visited int
+	stateMap.Range(func(string, *TxState) bool {
+		visited++
+		return false
+	})
+	require.Equal(t, 1, visited)
+}
+
+func TestStateSnapshot(t *testing.T) {
+	stateMap := NewStateMap()
+	stateMap.Store("0xb", &TxState{TxHash: "0xb", Msgs: []*MessageState{{Status: Pending}}})
+	stateMap.Store("0xa", &TxState{TxHash: "0xa", RetryAttempt: 2, Msgs: []*MessageState{{Status: Complete}}})
+
+	snapshot := stateMap.Snapshot()
+	require.Len(t, snapshot, 2)
+	require.Equal(t, "0xa", snapshot[0].TxHash)
+	require.Equal(t, 2, snapshot[0].RetryAttempt)
+	require.Equal(t, "0xb", snapshot[1].TxHash)
+
+	// the snapshot doesn't share messages with the state
+	snapshot[1].Msgs[0].Status = Failed
+	tx, _ := stateMap.Load("0xb")
+	require.Equal(t, Pending, tx.Msgs[0].Status)
+}
+
+func TestStateDeleteFunc(t *testing.T) {
+	stateMap := NewStateMap()
+	stateMap.Store("done", &TxState{TxHash: "done", Msgs: []*MessageState{{Status: Complete}}})
+	stateMap.Store("active", &TxState{TxHash: "active", Msgs: []*MessageState{{Status: Pending}}})
+
+	stateMap.DeleteFunc(func(_ string, tx *TxState) bool {
+		return tx.Msgs[0].Status == Complete
+	})
+
+	_, ok := stateMap.Load("done")
+	require.False(t, ok)
+	_, ok = stateMap.Load("active")
+	require.True(t, ok)
+}
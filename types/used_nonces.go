@@ -0,0 +1,89 @@
+package types
+
+import (
+	"sync"
+)
+
+// DefaultUsedNonceCacheSize is used when used-nonce-cache-size is not set
+const DefaultUsedNonceCacheSize = 10000
+
+// UsedNonces remembers the source nonces known to be used on their destination, so requeued messages skip the
+// used nonce query. It is replaced by the processor on startup with the configured size.
+var UsedNonces = NewUsedNonceCache(DefaultUsedNonceCacheSize)
+
+type usedNonceKey struct {
+	sourceDomain Domain
+	nonce        uint64
+}
+
+// UsedNonceCache holds the (source domain, nonce) pairs known to be used on their destination domain after a
+// used nonce check or a successful mint. It keeps at most size nonces, evicting the oldest first. It is safe for
+// concurrent use.
+type UsedNonceCache struct {
+	size int
+
+	mu sync.Mutex
+	// nonce -> dest domain the nonce was used on
+	nonces map[usedNonceKey]Domain
+	// nonces in the order they were added
+	order []usedNonceKey
+}
+
+func NewUsedNonceCache(size int) *UsedNonceCache {
+	return &UsedNonceCache{
+		size:   size,
+		nonces: make(map[usedNonceKey]Domain),
+	}
+}
+
+// IsUsed returns true if the nonce of the source domain is known to be used
+func (c *UsedNonceCache) IsUsed(sourceDomain Domain, nonce uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.nonces[usedNonceKey{sourceDomain: sourceDomain, nonce: nonce}]
+	return ok
+}
+
+// Add records the nonce of the source domain as used on the dest domain, evicting the oldest nonces beyond the
+// cache size
+func (c *UsedNonceCache) Add(sourceDomain Domain, nonce uint64, destDomain Domain) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := usedNonceKey{sourceDomain: sourceDomain, nonce: nonce}
+	if _, ok := c.nonces[key]; !ok {
+		c.order = append(c.order, key)
+	}
+	c.nonces[key] = destDomain
+
+	for len(c.order) > c.size {
+		delete(c.nonces, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// Invalidate drops the nonces sent from or used on the domain, e.g. after a reorg on the domain's chain that may
+// have removed a burn or a mint. Returns the number of nonces dropped.
+func (c *UsedNonceCache) Invalidate(domain Domain) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := c.order[:0]
+	for _, key := range c.order {
+		if key.sourceDomain == domain || c.nonces[key] == domain {
+			delete(c.nonces, key)
+			continue
+		}
+		kept = append(kept, key)
+	}
+	dropped := len(c.order) - len(kept)
+	c.order = kept
+	return dropped
+}
+
+func (c *UsedNonceCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.nonces)
+}
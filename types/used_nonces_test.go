@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsedNonceCache(t *testing.T) {
+	c := NewUsedNonceCache(2)
+
+	c.Add(0, 1, 4)
+	c.Add(5, 1, 4)
+	require.True(t, c.IsUsed(0, 1))
+	require.True(t, c.IsUsed(5, 1))
+	require.False(t, c.IsUsed(0, 2))
+
+	// the oldest nonce is evicted beyond the cache size
+	c.Add(0, 2, 5)
+	require.False(t, c.IsUsed(0, 1))
+	require.True(t, c.IsUsed(0, 2))
+	require.Equal(t, 2, c.Len())
+
+	// a reorg on domain 5 drops the nonces sent from and used on it
+	require.Equal(t, 2, c.Invalidate(5))
+	require.False(t, c.IsUsed(5, 1))
+	require.False(t, c.IsUsed(0, 2))
+	require.Zero(t, c.Len())
+}
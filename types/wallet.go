@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWalletCooldown is how long a minter wallet is skipped after a broadcast from it failed
+const DefaultWalletCooldown = 5 * time.Minute
+
+// MinterPrivateKeys returns the minter keys of a chain. The <NAME>_PRIV_KEY env variable overrides the
+// single configured key and the comma separated <NAME>_PRIV_KEYS env variable overrides the key list.
+func MinterPrivateKeys(name, privateKey string, privateKeys []string) ([]string, error) {
+	envKey := strings.ToUpper(name) + "_PRIV_KEY"
+	if key := os.Getenv(envKey); key != "" {
+		privateKey = key
+	}
+	if keys := os.Getenv(strings.ToUpper(name) + "_PRIV_KEYS"); keys != "" {
+		privateKeys = strings.Split(keys, ",")
+	}
+
+	var resolved []string
+	seen := make(map[string]struct{})
+	for _, key := range append([]string{privateKey}, privateKeys...) {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		resolved = append(resolved, key)
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("env variable %s is empty, priv key not found for chain %s", envKey, name)
+	}
+	return resolved, nil
+}
+
+// WalletPool hands out a chain's minter wallets in round-robin order. A wallet whose broadcast failed,
+// e.g. because it ran out of funds, is skipped until its cooldown expires. If every wallet is cooling
+// down, the one that failed longest ago is used.
+type WalletPool struct {
+	mu       sync.Mutex
+	cooldown time.Duration
+	next     int
+	failedAt []time.Time
+}
+
+func NewWalletPool(size int, cooldown time.Duration) *WalletPool {
+	return &WalletPool{
+		cooldown: cooldown,
+		failedAt: make([]time.Time, size),
+	}
+}
+
+func (p *WalletPool) Size() int {
+	return len(p.failedAt)
+}
+
+// Next returns the index of the wallet to broadcast the next tx with
+func (p *WalletPool) Next() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	oldest := -1
+	for i := 0; i < len(p.failedAt); i++ {
+		wallet := (p.next + i) % len(p.failedAt)
+		failedAt := p.failedAt[wallet]
+		if failedAt.IsZero() || time.Since(failedAt) >= p.cooldown {
+			p.next = wallet + 1
+			return wallet
+		}
+		if oldest == -1 || failedAt.Before(p.failedAt[oldest]) {
+			oldest = wallet
+		}
+	}
+
+	p.next = oldest + 1
+	return oldest
+}
+
+// Failed skips the wallet until its cooldown expires
+func (p *WalletPool) Failed(wallet int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failedAt[wallet] = time.Now()
+}
+
+// Succeeded makes the wallet available again
+func (p *WalletPool) Succeeded(wallet int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failedAt[wallet] = time.Time{}
+}
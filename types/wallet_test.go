@@ -0,0 +1,60 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// TestWalletPool verifies wallets are handed out round-robin and failed wallets are skipped until their cooldown expires
+func TestWalletPool(t *testing.T) {
+	pool := types.NewWalletPool(3, time.Hour)
+	require.Equal(t, []int{0, 1, 2, 0}, []int{pool.Next(), pool.Next(), pool.Next(), pool.Next()})
+
+	pool.Failed(1)
+	require.Equal(t, []int{2, 0, 2}, []int{pool.Next(), pool.Next(), pool.Next()})
+
+	// with every wallet failing, the one that failed longest ago is used
+	pool.Failed(2)
+	pool.Failed(0)
+	require.Equal(t, 1, pool.Next())
+
+	pool.Succeeded(0)
+	require.Equal(t, []int{0, 0}, []int{pool.Next(), pool.Next()})
+
+	// expired cooldowns make wallets available again
+	pool = types.NewWalletPool(2, 0)
+	pool.Failed(0)
+	require.Equal(t, []int{0, 1}, []int{pool.Next(), pool.Next()})
+}
+
+// TestSequenceMapWallets verifies each wallet of a domain has its own sequence
+func TestSequenceMapWallets(t *testing.T) {
+	sequenceMap := types.NewSequenceMap()
+	sequenceMap.Put(4, 10)
+	sequenceMap.PutWallet(4, 1, 20)
+
+	require.Equal(t, uint64(10), sequenceMap.Next(4))
+	require.Equal(t, uint64(20), sequenceMap.NextWallet(4, 1))
+	require.Equal(t, uint64(11), sequenceMap.GetWallet(4, 0))
+	require.Equal(t, uint64(21), sequenceMap.GetWallet(4, 1))
+	require.Equal(t, uint64(0), sequenceMap.GetWallet(0, 1))
+}
+
+func TestMinterPrivateKeys(t *testing.T) {
+	keys, err := types.MinterPrivateKeys("test", "a", []string{"b", "a", " c "})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, keys)
+
+	t.Setenv("TEST_PRIV_KEY", "d")
+	t.Setenv("TEST_PRIV_KEYS", "e,f")
+	keys, err = types.MinterPrivateKeys("test", "a", []string{"b"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"d", "e", "f"}, keys)
+
+	_, err = types.MinterPrivateKeys("missing", "", nil)
+	require.ErrorContains(t, err, "MISSING_PRIV_KEY")
+}